@@ -187,3 +187,16 @@ func (a *Accordion) RemoveItem(itemID string) {
 		}
 	}
 }
+
+// Actions returns the accordion's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "toggle_accordion" toggles the item named by lvt-data-item
+func (a *Accordion) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle_accordion": func(ctx *base.ActionContext) error {
+			a.Toggle(ctx.Data("item"))
+			return nil
+		},
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"html/template"
 	"strings"
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -403,3 +405,25 @@ func TestItemWithIcon(t *testing.T) {
 		t.Error("expected icon in output")
 	}
 }
+
+func TestActions(t *testing.T) {
+	items := []Item{
+		{ID: "q1", Title: "Question 1"},
+		{ID: "q2", Title: "Question 2"},
+	}
+	acc := New("faq", items)
+
+	actions := acc.Actions()
+	handler, ok := actions["toggle_accordion"]
+	if !ok {
+		t.Fatal("expected toggle_accordion action")
+	}
+
+	ctx := base.NewActionContext("toggle_accordion", "faq", map[string]string{"item": "q2"})
+	if err := handler(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !acc.IsOpen("q2") {
+		t.Error("expected q2 to be open after toggle_accordion")
+	}
+}
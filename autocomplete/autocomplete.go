@@ -5,6 +5,7 @@
 //   - NewMulti() creates a multi-select autocomplete (template: "lvt:autocomplete:multi:v1")
 //
 // Required lvt-* attributes: lvt-input, lvt-click, lvt-focus, lvt-blur, lvt-click-away
+// Optional: lvt-scroll (for WithVirtualSuggestions)
 //
 // Example usage:
 //
@@ -22,8 +23,6 @@
 package autocomplete
 
 import (
-	"strings"
-
 	"github.com/livetemplate/components/base"
 )
 
@@ -84,6 +83,10 @@ type Autocomplete struct {
 	// ClearOnSelect clears input after selection (useful for multi)
 	ClearOnSelect bool
 
+	// Window limits the rendered suggestions to the ones in view, for very
+	// long suggestion lists (nil renders every suggestion)
+	Window *base.Window
+
 	// filterFunc is a custom filter function
 	filterFunc func(query string, suggestions []Suggestion) []Suggestion
 }
@@ -135,6 +138,9 @@ func (ac *Autocomplete) SetQuery(query string) {
 	ac.Query = query
 	ac.Filter()
 	ac.HighlightedIndex = -1
+	if ac.Window != nil {
+		ac.Window.Offset = 0
+	}
 
 	// Show suggestions if query meets minimum
 	ac.Open = len(query) >= ac.MinChars && len(ac.FilteredSuggestions) > 0
@@ -154,26 +160,42 @@ func (ac *Autocomplete) Filter() {
 	}
 }
 
-// defaultFilter performs case-insensitive substring matching.
+// defaultFilter matches the query against each suggestion's Label, Value and
+// Description, ignoring case and diacritics, best match first (see
+// base.Matcher). Labels also match fuzzily; Value and Description must
+// contain the query.
 func (ac *Autocomplete) defaultFilter(query string) []Suggestion {
-	if query == "" {
+	m := base.NewMatcher(query)
+	if m.Empty() {
 		return ac.Suggestions
 	}
 
-	queryLower := strings.ToLower(query)
-	var filtered []Suggestion
-
-	for _, s := range ac.Suggestions {
-		if strings.Contains(strings.ToLower(s.Label), queryLower) ||
-			strings.Contains(strings.ToLower(s.Value), queryLower) ||
-			strings.Contains(strings.ToLower(s.Description), queryLower) {
-			filtered = append(filtered, s)
+	ranked := base.RankFunc(ac.Suggestions, func(s Suggestion) (base.Match, bool) {
+		best, ok := m.Match(s.Label)
+		for _, text := range []string{s.Value, s.Description} {
+			match, matched := m.Match(text)
+			if matched && match.Kind >= base.MatchSubstring && (!ok || match.Better(best)) {
+				// Ranges are only highlighted in the Label.
+				best, ok = base.Match{Kind: match.Kind, Score: match.Score}, true
+			}
 		}
-	}
+		return best, ok
+	})
 
+	var filtered []Suggestion
+	for _, r := range ranked {
+		filtered = append(filtered, r.Item)
+	}
 	return filtered
 }
 
+// Highlight returns a suggestion's Label split into the parts that match the
+// query and the parts that don't, for rendering matches in <mark>.
+func (ac *Autocomplete) Highlight(s Suggestion) []base.Segment {
+	match, _ := base.NewMatcher(ac.Query).Match(s.Label)
+	return match.Segments(s.Label)
+}
+
 // SelectIndex selects the suggestion at the given index.
 func (ac *Autocomplete) SelectIndex(index int) bool {
 	if index < 0 || index >= len(ac.FilteredSuggestions) {
@@ -257,6 +279,7 @@ func (ac *Autocomplete) HighlightNext() {
 	if ac.HighlightedIndex >= len(ac.FilteredSuggestions) {
 		ac.HighlightedIndex = 0
 	}
+	defer ac.scrollToHighlighted()
 
 	// Skip disabled
 	for i := 0; i < len(ac.FilteredSuggestions); i++ {
@@ -277,6 +300,7 @@ func (ac *Autocomplete) HighlightPrevious() {
 	if ac.HighlightedIndex < 0 {
 		ac.HighlightedIndex = len(ac.FilteredSuggestions) - 1
 	}
+	defer ac.scrollToHighlighted()
 
 	// Skip disabled
 	for i := 0; i < len(ac.FilteredSuggestions); i++ {
@@ -290,6 +314,39 @@ func (ac *Autocomplete) HighlightPrevious() {
 	}
 }
 
+// WindowSuggestions returns the filtered suggestions to render, limited to
+// the Window if one is set. Use SuggestionIndex for their indexes.
+func (ac *Autocomplete) WindowSuggestions() []Suggestion {
+	return base.WindowOf(ac.Window, ac.FilteredSuggestions)
+}
+
+// SuggestionIndex returns the index in FilteredSuggestions of the suggestion
+// at index i in WindowSuggestions.
+func (ac *Autocomplete) SuggestionIndex(i int) int {
+	if ac.Window == nil {
+		return i
+	}
+	return ac.Window.Start() + i
+}
+
+// ScrollTo moves the Window to start at the suggestion at offset.
+func (ac *Autocomplete) ScrollTo(offset int) {
+	if ac.Window == nil {
+		return
+	}
+	ac.Window.SetTotal(len(ac.FilteredSuggestions))
+	ac.Window.ScrollTo(offset)
+}
+
+// scrollToHighlighted keeps the highlighted suggestion in the Window.
+func (ac *Autocomplete) scrollToHighlighted() {
+	if ac.Window == nil {
+		return
+	}
+	ac.Window.SetTotal(len(ac.FilteredSuggestions))
+	ac.Window.ScrollIntoView(ac.HighlightedIndex)
+}
+
 // IsHighlighted checks if a suggestion index is highlighted.
 func (ac *Autocomplete) IsHighlighted(index int) bool {
 	return ac.HighlightedIndex == index
@@ -395,3 +452,99 @@ func (mac *MultiAutocomplete) FilteredExcludingSelected() []Suggestion {
 	}
 	return filtered
 }
+
+// Actions returns the autocomplete's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "query" updates the query from the input value
+//   - "focus", "blur" and "clear" open, close and reset the suggestions
+//   - "select" selects the suggestion at lvt-data-index
+//   - "keydown" handles ArrowDown, ArrowUp, Enter and Escape
+//   - "scroll" moves the Window to lvt-data-offset (or scrollTop in pixels)
+func (ac *Autocomplete) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"query": func(ctx *base.ActionContext) error {
+			ac.SetQuery(ctx.Data("value"))
+			return nil
+		},
+		"focus": func(ctx *base.ActionContext) error {
+			ac.Focus()
+			return nil
+		},
+		"blur": func(ctx *base.ActionContext) error {
+			ac.Blur()
+			return nil
+		},
+		"clear": func(ctx *base.ActionContext) error {
+			ac.Clear()
+			return nil
+		},
+		"select": func(ctx *base.ActionContext) error {
+			ac.SelectIndex(ctx.DataInt("index"))
+			return nil
+		},
+		"keydown": func(ctx *base.ActionContext) error {
+			switch ctx.Data("key") {
+			case "ArrowDown":
+				ac.HighlightNext()
+			case "ArrowUp":
+				ac.HighlightPrevious()
+			case "Enter":
+				ac.SelectHighlighted()
+			case "Escape":
+				ac.Blur()
+			}
+			return nil
+		},
+		"scroll": func(ctx *base.ActionContext) error {
+			if ac.Window != nil {
+				ac.Window.SetTotal(len(ac.FilteredSuggestions))
+				ac.Window.ScrollFromAction(ctx)
+			}
+			return nil
+		},
+	}
+}
+
+// Actions returns the multi-select autocomplete's action handlers.
+// It extends the Autocomplete actions with "select_multi", "remove" and
+// "clear_multi", and makes Enter add the highlighted suggestion.
+func (mac *MultiAutocomplete) Actions() map[string]base.ActionHandler {
+	actions := mac.Autocomplete.Actions()
+	actions["select_multi"] = func(ctx *base.ActionContext) error {
+		value := ctx.Data("value")
+		for _, s := range mac.FilteredSuggestions {
+			if s.Value == value {
+				mac.SelectMulti(s)
+				return nil
+			}
+		}
+		for _, s := range mac.Suggestions {
+			if s.Value == value {
+				mac.SelectMulti(s)
+				return nil
+			}
+		}
+		return nil
+	}
+	actions["remove"] = func(ctx *base.ActionContext) error {
+		mac.RemoveSelected(ctx.Data("value"))
+		return nil
+	}
+	actions["clear_multi"] = func(ctx *base.ActionContext) error {
+		mac.ClearMulti()
+		return nil
+	}
+	keydown := actions["keydown"]
+	actions["keydown"] = func(ctx *base.ActionContext) error {
+		if ctx.Data("key") == "Enter" {
+			i := mac.HighlightedIndex
+			if i >= 0 && i < len(mac.FilteredSuggestions) {
+				mac.SelectMulti(mac.FilteredSuggestions[i])
+			}
+			return nil
+		}
+		return keydown(ctx)
+	}
+	return actions
+}
@@ -1,8 +1,12 @@
 package autocomplete
 
 import (
+	"fmt"
+	"html/template"
 	"strings"
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -231,6 +235,56 @@ func TestFilterByDescription(t *testing.T) {
 	}
 }
 
+func TestFilterUnicode(t *testing.T) {
+	suggestions := []Suggestion{
+		{Value: "1", Label: "Zürich"},
+		{Value: "2", Label: "İstanbul"},
+		{Value: "3", Label: "Kraków"},
+	}
+	ac := New("test", WithSuggestions(suggestions))
+
+	for query, want := range map[string]string{"zur": "1", "ISTAN": "2", "krakow": "3"} {
+		ac.Query = query
+		ac.Filter()
+		if len(ac.FilteredSuggestions) != 1 || ac.FilteredSuggestions[0].Value != want {
+			t.Errorf("Filter(%q) = %v, want %s", query, ac.FilteredSuggestions, want)
+		}
+	}
+}
+
+func TestFilterRanked(t *testing.T) {
+	suggestions := []Suggestion{
+		{Value: "1", Label: "Hamburg", Description: "Germany"},
+		{Value: "2", Label: "Bergen", Description: "Norway"},
+		{Value: "3", Label: "Heidelberg"},
+		{Value: "4", Label: "Oslo", Description: "Norway, near Bergen"},
+		{Value: "5", Label: "Bordeaux, Gironde"},
+	}
+	ac := New("test", WithSuggestions(suggestions), WithMaxSuggestions(3))
+
+	ac.Query = "berg"
+	ac.Filter()
+
+	var got []string
+	for _, s := range ac.FilteredSuggestions {
+		got = append(got, s.Value)
+	}
+	// Label prefix, description word start, then label substring; the
+	// fuzzy matches are cut by MaxSuggestions.
+	want := []string{"2", "4", "3"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	segments := ac.Highlight(suggestions[2])
+	if len(segments) != 2 || segments[0].Text != "Heidel" || segments[1].Text != "berg" || !segments[1].Match {
+		t.Errorf("unexpected highlight %+v", segments)
+	}
+	if segments := ac.Highlight(suggestions[3]); len(segments) != 1 || segments[0].Match {
+		t.Errorf("expected description matches not to highlight the label, got %+v", segments)
+	}
+}
+
 func TestSelectIndex(t *testing.T) {
 	suggestions := []Suggestion{
 		{Value: "1", Label: "One"},
@@ -718,3 +772,177 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Expected Templates() to return a TemplateSet")
 	}
 }
+
+func TestActions(t *testing.T) {
+	suggestions := []Suggestion{
+		{Value: "go", Label: "Go"},
+		{Value: "gleam", Label: "Gleam"},
+	}
+	ac := New("lang", WithSuggestions(suggestions))
+	actions := ac.Actions()
+
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		handler, ok := actions[name]
+		if !ok {
+			t.Fatalf("expected %q action", name)
+		}
+		if err := handler(base.NewActionContext(name, "lang", data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+
+	run("query", map[string]string{"value": "g"})
+	if !ac.Open || len(ac.FilteredSuggestions) != 2 {
+		t.Fatalf("expected open with 2 suggestions, got open=%v len=%d", ac.Open, len(ac.FilteredSuggestions))
+	}
+
+	run("keydown", map[string]string{"key": "ArrowDown"})
+	run("keydown", map[string]string{"key": "Enter"})
+	if ac.Selected == nil || ac.Selected.Value != "go" {
+		t.Errorf("expected 'go' to be selected via keyboard, got %v", ac.Selected)
+	}
+
+	run("clear", nil)
+	if ac.Selected != nil {
+		t.Error("expected selection to be cleared")
+	}
+}
+
+func TestMultiActions(t *testing.T) {
+	suggestions := []Suggestion{
+		{Value: "go", Label: "Go"},
+		{Value: "rust", Label: "Rust"},
+	}
+	mac := NewMulti("langs", WithSuggestions(suggestions))
+	actions := mac.Actions()
+
+	ctx := base.NewActionContext("select_multi", "langs", map[string]string{"value": "rust"})
+	if err := actions["select_multi"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !mac.IsSelectedMulti("rust") {
+		t.Error("expected 'rust' to be selected")
+	}
+
+	ctx = base.NewActionContext("remove", "langs", map[string]string{"value": "rust"})
+	if err := actions["remove"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mac.HasSelectedItems() {
+		t.Error("expected no selected items after remove")
+	}
+}
+
+func virtualSuggestions(n int) []Suggestion {
+	suggestions := make([]Suggestion, n)
+	for i := range suggestions {
+		suggestions[i] = Suggestion{Value: fmt.Sprint(i), Label: fmt.Sprintf("City %d", i)}
+	}
+	return suggestions
+}
+
+func TestVirtualSuggestions(t *testing.T) {
+	ac := New("city", WithSuggestions(virtualSuggestions(1000)), WithVirtualSuggestions(8, 40))
+	ac.SetQuery("City")
+
+	if len(ac.FilteredSuggestions) != 1000 {
+		t.Fatalf("expected every match without a limit, got %d", len(ac.FilteredSuggestions))
+	}
+	if got := ac.WindowSuggestions(); len(got) != 13 || ac.SuggestionIndex(0) != 0 {
+		t.Errorf("expected 13 suggestions from 0, got %d", len(got))
+	}
+
+	if err := ac.Actions()["scroll"](base.NewActionContext("scroll", "city", map[string]string{"scrollTop": "4000"})); err != nil {
+		t.Fatalf("scroll returned error: %v", err)
+	}
+	if got := ac.WindowSuggestions(); got[0].Value != "95" || ac.SuggestionIndex(0) != 95 {
+		t.Errorf("expected suggestions from 95, got %s", got[0].Value)
+	}
+
+	ac.HighlightedIndex = 107
+	ac.HighlightNext()
+	if ac.Window.Offset != 101 {
+		t.Errorf("expected the highlight scrolled into view at 101, got %d", ac.Window.Offset)
+	}
+	ac.HighlightedIndex = 0
+	ac.HighlightPrevious()
+	if ac.HighlightedIndex != 999 || ac.Window.Offset != 992 {
+		t.Errorf("expected wrapping to the last suggestion, got %d at %d", ac.HighlightedIndex, ac.Window.Offset)
+	}
+
+	// 11 labels contain "City 99"; 17 more contain its letters in order.
+	ac.SetQuery("City 99")
+	if ac.Window.Offset != 0 || len(ac.FilteredSuggestions) != 28 || len(ac.WindowSuggestions()) != 13 {
+		t.Errorf("expected a new query to scroll to the top, got offset %d", ac.Window.Offset)
+	}
+}
+
+func TestVirtualSuggestionsTemplate(t *testing.T) {
+	ts := Templates()
+	tmpl, err := template.New("test").ParseFS(ts.FS, ts.Pattern)
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	for _, styled := range []bool{true, false} {
+		ac := New("city", WithSuggestions(virtualSuggestions(100)), WithVirtualSuggestions(10, 30), WithStyled(styled))
+		ac.SetQuery("City")
+		ac.ScrollTo(50)
+		ac.HighlightedIndex = 52
+
+		var buf strings.Builder
+		if err := tmpl.ExecuteTemplate(&buf, "lvt:autocomplete:default:v1", ac); err != nil {
+			t.Fatalf("failed to execute template: %v", err)
+		}
+
+		html := strings.Join(strings.Fields(buf.String()), " ")
+		for _, want := range []string{
+			`lvt-scroll="scroll_city"`,
+			`style="height: 1350px"`,
+			`style="height: 1050px"`,
+			`lvt-data-index="45"`,
+			`lvt-data-index="64"`,
+			`lvt-data-index="52" aria-selected="true"`,
+		} {
+			if !strings.Contains(html, want) {
+				t.Errorf("styled=%v: expected output to contain %q", styled, want)
+			}
+		}
+		if strings.Contains(html, `lvt-data-index="0"`) || strings.Contains(html, `lvt-data-index="65"`) {
+			t.Errorf("styled=%v: expected suggestions outside the window not to render", styled)
+		}
+	}
+}
+
+func TestHighlightTemplate(t *testing.T) {
+	ts := Templates()
+	tmpl, err := template.New("test").ParseFS(ts.FS, ts.Pattern)
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	suggestions := []Suggestion{{Value: "zh", Label: "Zürich"}}
+	for _, name := range []string{"lvt:autocomplete:default:v1", "lvt:autocomplete:multi:v1"} {
+		for _, styled := range []bool{true, false} {
+			var data any
+			if name == "lvt:autocomplete:default:v1" {
+				ac := New("city", WithSuggestions(suggestions), WithStyled(styled))
+				ac.SetQuery("zur")
+				data = ac
+			} else {
+				ac := NewMulti("city", WithSuggestions(suggestions), WithStyled(styled))
+				ac.SetQuery("zur")
+				data = ac
+			}
+
+			var buf strings.Builder
+			if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+				t.Fatalf("failed to execute %s: %v", name, err)
+			}
+			if !strings.Contains(buf.String(), ">Zür</mark>ich") {
+				t.Errorf("%s styled=%v: expected the match to be highlighted", name, styled)
+			}
+		}
+	}
+}
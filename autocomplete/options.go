@@ -1,5 +1,9 @@
 package autocomplete
 
+import (
+	"github.com/livetemplate/components/base"
+)
+
 // Option is a functional option for configuring autocomplete components.
 type Option func(*Autocomplete)
 
@@ -34,6 +38,17 @@ func WithMaxSuggestions(max int) Option {
 	}
 }
 
+// WithVirtualSuggestions renders only the suggestions in view, size
+// suggestions of itemHeight pixels, and lifts the MaxSuggestions limit so
+// every match can be scrolled to. The list sends "scroll" actions as it
+// scrolls.
+func WithVirtualSuggestions(size, itemHeight int) Option {
+	return func(ac *Autocomplete) {
+		ac.Window = base.NewWindow(size, itemHeight)
+		ac.MaxSuggestions = 0
+	}
+}
+
 // WithAllowCustom allows values not in the suggestions list.
 func WithAllowCustom(allow bool) Option {
 	return func(ac *Autocomplete) {
@@ -1,6 +1,7 @@
 package base
 
 import (
+	"context"
 	"strconv"
 )
 
@@ -24,6 +25,9 @@ type ActionContext struct {
 
 	// data holds the key-value pairs from lvt-data-* attributes.
 	data map[string]string
+
+	// ctx is the request context, if any.
+	ctx context.Context
 }
 
 // NewActionContext creates a new ActionContext with the given action name,
@@ -93,6 +97,24 @@ func (ctx *ActionContext) HasData(key string) bool {
 	return ok
 }
 
+// Context returns the request context, or context.Background() if none was set.
+// Handlers that do I/O (e.g. a datatable querying its DataSource) use it for
+// cancellation.
+func (ctx *ActionContext) Context() context.Context {
+	if ctx.ctx == nil {
+		return context.Background()
+	}
+	return ctx.ctx
+}
+
+// WithContext returns a shallow copy of the ActionContext that uses c as its
+// request context.
+func (ctx *ActionContext) WithContext(c context.Context) *ActionContext {
+	copied := *ctx
+	copied.ctx = c
+	return &copied
+}
+
 // AllData returns a copy of all data key-value pairs.
 func (ctx *ActionContext) AllData() map[string]string {
 	result := make(map[string]string, len(ctx.data))
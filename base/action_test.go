@@ -1,6 +1,7 @@
 package base
 
 import (
+	"context"
 	"testing"
 )
 
@@ -215,3 +216,21 @@ func TestActionContext_AllData(t *testing.T) {
 		t.Errorf("expected 2 keys, got %d", len(allData))
 	}
 }
+
+func TestActionContext_Context(t *testing.T) {
+	ctx := NewActionContext("test", "myid", nil)
+	if ctx.Context() != context.Background() {
+		t.Error("expected context.Background() when no context is set")
+	}
+
+	type key struct{}
+	c := context.WithValue(context.Background(), key{}, "v")
+	withCtx := ctx.WithContext(c)
+
+	if withCtx.Context().Value(key{}) != "v" {
+		t.Error("expected WithContext to set the request context")
+	}
+	if ctx.Context() != context.Background() {
+		t.Error("WithContext should not modify the original ActionContext")
+	}
+}
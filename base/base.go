@@ -3,6 +3,7 @@
 // All components embed the Base struct which provides ID handling and common functionality.
 // Components use functional options for configuration and implement action handlers
 // that are automatically registered with the LiveTemplate framework.
+// Router dispatches raw action names to the handlers of registered components.
 package base
 
 // Base provides common functionality for all components.
@@ -68,6 +69,7 @@ func (b *Base) Namespace() string {
 
 // ActionName generates a namespaced action name for this component.
 // For example: ActionName("toggle") returns "toggle_myid" if ID is "myid".
+// Router.Resolve performs the reverse mapping.
 func (b *Base) ActionName(action string) string {
 	return action + "_" + b.ComponentID
 }
@@ -0,0 +1,357 @@
+package base
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MatchKind is how a query matched a text. Better kinds have higher values.
+type MatchKind int
+
+const (
+	// MatchFuzzy means the query's letters appear in order in the text
+	MatchFuzzy MatchKind = iota + 1
+	// MatchSubstring means the query appears inside a word of the text
+	MatchSubstring
+	// MatchWordStart means the query appears at the start of a word
+	MatchWordStart
+	// MatchPrefix means the text starts with the query
+	MatchPrefix
+)
+
+// Range is a matched part of a text, as byte offsets [Start, End).
+type Range struct {
+	Start int
+	End   int
+}
+
+// Match is the result of matching a query against a text.
+type Match struct {
+	// Kind is how the query matched (zero for an empty query)
+	Kind MatchKind
+	// Score ranks matches of the same kind; higher is better
+	Score int
+	// Ranges are the parts of the text that matched, in order
+	Ranges []Range
+}
+
+// Better reports whether m ranks before o: prefix matches before word-start
+// matches, before substring matches, before fuzzy matches.
+func (m Match) Better(o Match) bool {
+	if m.Kind != o.Kind {
+		return m.Kind > o.Kind
+	}
+	return m.Score > o.Score
+}
+
+// Segment is a run of text that either matched a query or not.
+type Segment struct {
+	Text  string
+	Match bool
+}
+
+// Segments splits text at the match ranges, so templates can highlight it:
+//
+//	{{range .Segments}}{{if .Match}}<mark>{{.Text}}</mark>{{else}}{{.Text}}{{end}}{{end}}
+func (m Match) Segments(text string) []Segment {
+	var segments []Segment
+	pos := 0
+	for _, r := range m.Ranges {
+		if r.Start < pos || r.End > len(text) {
+			break
+		}
+		if r.Start > pos {
+			segments = append(segments, Segment{Text: text[pos:r.Start]})
+		}
+		segments = append(segments, Segment{Text: text[r.Start:r.End], Match: true})
+		pos = r.End
+	}
+	if pos < len(text) {
+		segments = append(segments, Segment{Text: text[pos:]})
+	}
+	return segments
+}
+
+// Matcher matches texts against a search query, ignoring case and
+// diacritics, so "osterreich" matches "Österreich" and "istanbul" matches
+// "İstanbul".
+//
+// Example:
+//
+//	m := base.NewMatcher("yor")
+//	match, ok := m.Match("New York") // MatchWordStart, Ranges [{4 7}]
+type Matcher struct {
+	query   []rune
+	letters []rune
+}
+
+// NewMatcher creates a Matcher for query.
+func NewMatcher(query string) *Matcher {
+	q := foldText(query).runes
+	m := &Matcher{query: q}
+	for _, r := range q {
+		if !unicode.IsSpace(r) {
+			m.letters = append(m.letters, r)
+		}
+	}
+	return m
+}
+
+// Empty returns true if the query has nothing to match, in which case every
+// text matches.
+func (m *Matcher) Empty() bool {
+	return len(m.letters) == 0
+}
+
+// Match matches text against the query.
+func (m *Matcher) Match(text string) (Match, bool) {
+	if m.Empty() {
+		return Match{}, true
+	}
+	t := foldText(text)
+	if match, ok := m.matchSubstring(t); ok {
+		return match, true
+	}
+	if match, ok := m.matchFuzzy(t, true); ok {
+		return match, true
+	}
+	// Jumping to word starts can skip letters needed later.
+	return m.matchFuzzy(t, false)
+}
+
+// matchSubstring finds the query in t, preferring an occurrence at the start
+// of a word.
+func (m *Matcher) matchSubstring(t folded) (Match, bool) {
+	first := -1
+	for i := 0; i+len(m.query) <= len(t.runes); i++ {
+		if !hasRunesAt(t.runes, m.query, i) {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		if i == 0 {
+			// Shorter texts rank first, so an exact match is the best match.
+			return t.match(MatchPrefix, len(m.query)-len(t.runes), []Range{{i, i + len(m.query)}}), true
+		}
+		if isWordStart(t.runes, i) {
+			return t.match(MatchWordStart, -i, []Range{{i, i + len(m.query)}}), true
+		}
+	}
+	if first < 0 {
+		return Match{}, false
+	}
+	return t.match(MatchSubstring, -first, []Range{{first, first + len(m.query)}}), true
+}
+
+// matchFuzzy matches the query's letters in order, scoring word starts and
+// runs of consecutive letters. With wordStarts, a letter that doesn't
+// continue a run is matched at the next word starting with it, if any.
+func (m *Matcher) matchFuzzy(t folded, wordStarts bool) (Match, bool) {
+	var ranges []Range
+	score := 0
+	i := 0
+	for _, r := range m.letters {
+		j := nextRune(t.runes, r, i)
+		if j < 0 {
+			return Match{}, false
+		}
+		if wordStarts && (j > i || len(ranges) == 0) {
+			for k := j; k >= 0; k = nextRune(t.runes, r, k+1) {
+				if isWordStart(t.runes, k) {
+					j = k
+					break
+				}
+			}
+		}
+
+		switch {
+		case len(ranges) > 0 && ranges[len(ranges)-1].End == j:
+			ranges[len(ranges)-1].End++
+			score += 2
+		default:
+			ranges = append(ranges, Range{j, j + 1})
+			score -= j - i
+		}
+		if isWordStart(t.runes, j) {
+			score += 3
+		}
+		i = j + 1
+	}
+	return t.match(MatchFuzzy, score, ranges), true
+}
+
+// Ranked is an item matched by Rank, with its match.
+type Ranked[T any] struct {
+	Item  T
+	Match Match
+}
+
+// Rank returns the items whose text matches query, best match first; items
+// that match equally keep their order. An empty query returns every item.
+func Rank[T any](query string, items []T, text func(T) string) []Ranked[T] {
+	m := NewMatcher(query)
+	return RankFunc(items, func(item T) (Match, bool) {
+		return m.Match(text(item))
+	})
+}
+
+// RankFunc is like Rank, with match matching each item, e.g. against several
+// of its fields.
+func RankFunc[T any](items []T, match func(T) (Match, bool)) []Ranked[T] {
+	ranked := make([]Ranked[T], 0, len(items))
+	for _, item := range items {
+		if m, ok := match(item); ok {
+			ranked = append(ranked, Ranked[T]{Item: item, Match: m})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Match.Better(ranked[j].Match)
+	})
+	return ranked
+}
+
+// Fold returns s in lower case without diacritics, for comparing texts the
+// way Matcher does.
+func Fold(s string) string {
+	return string(foldText(s).runes)
+}
+
+// folded is a text in lower case without diacritics, with the byte range of
+// the original text each rune came from.
+type folded struct {
+	runes      []rune
+	start, end []int
+}
+
+// foldText folds s, dropping combining marks and expanding letters such as
+// "ß" to "ss".
+func foldText(s string) folded {
+	t := folded{
+		runes: make([]rune, 0, len(s)),
+		start: make([]int, 0, len(s)),
+		end:   make([]int, 0, len(s)),
+	}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		start, end := i, i+size
+		i = end
+		if unicode.Is(unicode.Mn, r) {
+			// A combining mark belongs to the letter before it.
+			for k := len(t.end) - 1; k >= 0 && t.start[k] == t.start[len(t.end)-1]; k-- {
+				t.end[k] = end
+			}
+			continue
+		}
+		r = unicode.ToLower(r)
+		if base, ok := foldings[r]; ok {
+			for _, b := range base {
+				t.add(b, start, end)
+			}
+			continue
+		}
+		t.add(r, start, end)
+	}
+	return t
+}
+
+// add appends r, folded from the bytes [start, end) of the original text.
+func (t *folded) add(r rune, start, end int) {
+	t.runes = append(t.runes, r)
+	t.start = append(t.start, start)
+	t.end = append(t.end, end)
+}
+
+// match returns a Match with ranges of folded runes mapped to byte ranges of
+// the original text, merging ranges that touch.
+func (t folded) match(kind MatchKind, score int, ranges []Range) Match {
+	m := Match{Kind: kind, Score: score}
+	for _, r := range ranges {
+		br := Range{t.start[r.Start], t.end[r.End-1]}
+		if n := len(m.Ranges); n > 0 && br.Start <= m.Ranges[n-1].End {
+			m.Ranges[n-1].End = max(m.Ranges[n-1].End, br.End)
+			continue
+		}
+		m.Ranges = append(m.Ranges, br)
+	}
+	return m
+}
+
+// hasRunesAt returns true if s contains sub at index i.
+func hasRunesAt(s, sub []rune, i int) bool {
+	for k, r := range sub {
+		if s[i+k] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// nextRune returns the index of the first r in s at or after i, or -1.
+func nextRune(s []rune, r rune, i int) int {
+	for ; i < len(s); i++ {
+		if s[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// isWordStart returns true if s[i] starts a word.
+func isWordStart(s []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := s[i-1], s[i]
+	return isWordRune(cur) && !isWordRune(prev)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// foldings maps lower-case letters to the letters they are matched as.
+var foldings = func() map[rune]string {
+	letters := map[string]string{
+		"a":  "àáâãäåāăąǎ",
+		"c":  "çćĉċč",
+		"d":  "ďđð",
+		"e":  "èéêëēĕėęě",
+		"g":  "ĝğġģ",
+		"h":  "ĥħ",
+		"i":  "ìíîïĩīĭįıǐ",
+		"j":  "ĵ",
+		"k":  "ķĸ",
+		"l":  "ĺļľŀł",
+		"n":  "ñńņňŉŋ",
+		"o":  "òóôõöøōŏőǒ",
+		"r":  "ŕŗř",
+		"s":  "śŝşšſș",
+		"t":  "ţťŧț",
+		"u":  "ùúûüũūŭůűųǔ",
+		"w":  "ŵ",
+		"y":  "ýÿŷ",
+		"z":  "źżž",
+		"ae": "æ",
+		"ij": "ĳ",
+		"oe": "œ",
+		"ss": "ß",
+		"th": "þ",
+		"α":  "ά",
+		"ε":  "έ",
+		"η":  "ή",
+		"ι":  "ίϊΐ",
+		"ο":  "ό",
+		"σ":  "ς",
+		"υ":  "ύϋΰ",
+		"ω":  "ώ",
+	}
+	foldings := make(map[rune]string)
+	for base, runes := range letters {
+		for _, r := range runes {
+			foldings[r] = base
+		}
+	}
+	return foldings
+}()
@@ -0,0 +1,142 @@
+package base
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Hello World", "hello world"},
+		{"Österreich", "osterreich"},
+		{"İstanbul", "istanbul"},
+		{"Straße", "strasse"},
+		{"Ærøskøbing", "aeroskobing"},
+		{"Łódź", "lodz"},
+		{"Café", "cafe"},
+		{"ΟΔΥΣΣΕΥΣ", "οδυσσευσ"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Fold(tt.input); got != tt.expected {
+			t.Errorf("Fold(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestMatcher_Kinds(t *testing.T) {
+	tests := []struct {
+		query, text string
+		kind        MatchKind
+		ok          bool
+	}{
+		{"new", "New York", MatchPrefix, true},
+		{"york", "New York", MatchWordStart, true},
+		{"ork", "New York", MatchSubstring, true},
+		{"nwyk", "New York", MatchFuzzy, true},
+		{"osterr", "Österreich", MatchPrefix, true},
+		{"ÖSTER", "osterreich", MatchPrefix, true},
+		{"istanbul", "İstanbul", MatchPrefix, true},
+		{"strasse", "Straße", MatchPrefix, true},
+		{"sao paulo", "São Paulo", MatchPrefix, true},
+		{"xyz", "New York", 0, false},
+		{"kroy", "New York", 0, false},
+		{"", "anything", 0, true},
+		{"  ", "anything", 0, true},
+	}
+
+	for _, tt := range tests {
+		m, ok := NewMatcher(tt.query).Match(tt.text)
+		if ok != tt.ok || m.Kind != tt.kind {
+			t.Errorf("Match(%q, %q) = %v %v, want %v %v", tt.query, tt.text, m.Kind, ok, tt.kind, tt.ok)
+		}
+	}
+}
+
+func TestMatcher_Ranges(t *testing.T) {
+	tests := []struct {
+		query, text string
+		ranges      []Range
+	}{
+		{"yor", "New York", []Range{{4, 7}}},
+		{"öst", "Österreich", []Range{{0, 4}}},
+		{"s", "Straße", []Range{{0, 1}}},
+		{"sse", "Straße", []Range{{4, 7}}},
+		{"cafe", "Cafés", []Range{{0, 6}}},
+		{"nwyk", "New York", []Range{{0, 1}, {2, 3}, {4, 5}, {7, 8}}},
+		{"neyo", "New York", []Range{{0, 2}, {4, 6}}},
+	}
+
+	for _, tt := range tests {
+		m, ok := NewMatcher(tt.query).Match(tt.text)
+		if !ok {
+			t.Errorf("Match(%q, %q) did not match", tt.query, tt.text)
+			continue
+		}
+		if !reflect.DeepEqual(m.Ranges, tt.ranges) {
+			t.Errorf("Match(%q, %q).Ranges = %v, want %v", tt.query, tt.text, m.Ranges, tt.ranges)
+		}
+	}
+}
+
+func TestMatcher_FuzzyBacktracks(t *testing.T) {
+	// Jumping to the word starting with "a" leaves no "b" after it.
+	m, ok := NewMatcher("ab").Match("xaxb a")
+	if !ok || m.Kind != MatchFuzzy {
+		t.Fatalf("expected a fuzzy match, got %v %v", m.Kind, ok)
+	}
+	if !reflect.DeepEqual(m.Ranges, []Range{{1, 2}, {3, 4}}) {
+		t.Errorf("unexpected ranges %v", m.Ranges)
+	}
+}
+
+func TestMatch_Segments(t *testing.T) {
+	m, _ := NewMatcher("yor").Match("New York City")
+	want := []Segment{
+		{Text: "New "},
+		{Text: "Yor", Match: true},
+		{Text: "k City"},
+	}
+	if got := m.Segments("New York City"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Segments = %v, want %v", got, want)
+	}
+
+	m, _ = NewMatcher("").Match("New York")
+	if got := m.Segments("New York"); !reflect.DeepEqual(got, []Segment{{Text: "New York"}}) {
+		t.Errorf("expected one unmatched segment, got %v", got)
+	}
+}
+
+func TestRank(t *testing.T) {
+	items := []string{"Zealand", "Alaska", "Lakeside", "New Zealand", "Zeal", "Ozempic"}
+	ranked := Rank("zeal", items, func(s string) string { return s })
+
+	var got []string
+	for _, r := range ranked {
+		got = append(got, r.Item)
+	}
+	// Prefix (shortest first), then word start, then fuzzy.
+	want := []string{"Zeal", "Zealand", "New Zealand"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Rank = %v, want %v", got, want)
+	}
+
+	ranked = Rank("", items, func(s string) string { return s })
+	if len(ranked) != len(items) || ranked[0].Item != "Zealand" {
+		t.Errorf("expected every item in order for an empty query, got %v", ranked)
+	}
+
+	ranked = Rank("ak", items, func(s string) string { return s })
+	got = got[:0]
+	for _, r := range ranked {
+		got = append(got, r.Item)
+	}
+	want = []string{"Lakeside", "Alaska"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Rank = %v, want %v", got, want)
+	}
+}
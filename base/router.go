@@ -0,0 +1,193 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// Errors returned (wrapped in *RouteError) by Router.Dispatch and Router.Resolve.
+var (
+	// ErrUnknownComponent means no registered component ID matches the action name.
+	ErrUnknownComponent = errors.New("unknown component")
+
+	// ErrUnknownAction means the component exists but has no handler for the action.
+	ErrUnknownAction = errors.New("unknown action")
+
+	// ErrAmbiguousAction means the action name splits into more than one
+	// registered action/component pair.
+	ErrAmbiguousAction = errors.New("ambiguous action")
+)
+
+// RouteError describes an action name that could not be routed.
+// Use errors.Is with ErrUnknownComponent, ErrUnknownAction or
+// ErrAmbiguousAction to check the cause.
+type RouteError struct {
+	// Name is the raw action name, e.g. "toggle_all_users".
+	Name string
+
+	// Action is the resolved action part, when known.
+	Action string
+
+	// ComponentID is the resolved component ID, when known.
+	ComponentID string
+
+	// Err is the underlying cause.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RouteError) Error() string {
+	msg := "route " + e.Name + ": " + e.Err.Error()
+	if e.ComponentID != "" {
+		msg += " (component " + e.ComponentID
+		if e.Action != "" {
+			msg += ", action " + e.Action
+		}
+		msg += ")"
+	}
+	return msg
+}
+
+// Unwrap returns the underlying cause.
+func (e *RouteError) Unwrap() error {
+	return e.Err
+}
+
+// Routable is a component that can be registered with a Router.
+// Every component embedding Base and implementing ActionProvider satisfies it.
+type Routable interface {
+	ID() string
+	ActionProvider
+}
+
+// Router dispatches raw action names like "filter_users" to the handlers of
+// registered components.
+//
+// Action names are built by Base.ActionName as "<action>_<id>". Because both
+// parts may contain underscores ("toggle_all_users"), the router tries every
+// split point and keeps the one where the ID is registered and the component
+// has a handler for the action.
+//
+// Handlers are looked up with Actions when an action is resolved, so options
+// applied to a component after it is registered take effect. A Router is safe
+// for concurrent use; the components themselves are not locked.
+//
+// Example:
+//
+//	router := base.NewRouter()
+//	router.Register(state.Users, state.CountrySelect)
+//
+//	// In the LiveTemplate action handler
+//	err := router.Dispatch(actionName, data)
+type Router struct {
+	mu sync.RWMutex
+
+	// components maps component IDs to the registered components.
+	components map[string]Routable
+}
+
+// NewRouter creates an empty Router.
+func NewRouter() *Router {
+	return &Router{
+		components: make(map[string]Routable),
+	}
+}
+
+// Register adds components to the router. Registering a component with an
+// ID that is already registered replaces the previous component.
+func (r *Router) Register(components ...Routable) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range components {
+		r.components[c.ID()] = c
+	}
+}
+
+// Unregister removes the component with the given ID.
+func (r *Router) Unregister(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.components, id)
+}
+
+// Has returns true if a component with the given ID is registered.
+func (r *Router) Has(id string) bool {
+	_, ok := r.component(id)
+	return ok
+}
+
+// component returns the registered component with the given ID.
+func (r *Router) component(id string) (Routable, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.components[id]
+	return c, ok
+}
+
+// Resolve splits a raw action name into its action and component ID.
+//
+// Example:
+//
+//	action, id, err := router.Resolve("toggle_all_users") // "toggle_all", "users"
+func (r *Router) Resolve(name string) (action, componentID string, err error) {
+	action, componentID, _, err = r.resolve(name)
+	return action, componentID, err
+}
+
+// resolve is Resolve, also returning the handler for the action.
+func (r *Router) resolve(name string) (action, componentID string, handler ActionHandler, err error) {
+	var knownID string
+	found := 0
+
+	for i := strings.IndexByte(name, '_'); i >= 0; {
+		a, id := name[:i], name[i+1:]
+		if c, ok := r.component(id); ok {
+			knownID = id
+			if h, ok := c.Actions()[a]; ok {
+				if found > 0 {
+					return "", "", nil, &RouteError{Name: name, Err: ErrAmbiguousAction}
+				}
+				action, componentID, handler = a, id, h
+				found++
+			}
+		}
+
+		next := strings.IndexByte(name[i+1:], '_')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+
+	switch {
+	case found == 1:
+		return action, componentID, handler, nil
+	case knownID != "":
+		return "", "", nil, &RouteError{
+			Name:        name,
+			Action:      strings.TrimSuffix(name, "_"+knownID),
+			ComponentID: knownID,
+			Err:         ErrUnknownAction,
+		}
+	default:
+		return "", "", nil, &RouteError{Name: name, Err: ErrUnknownComponent}
+	}
+}
+
+// Dispatch resolves a raw action name, builds an ActionContext from data and
+// invokes the matching handler. Handler errors are returned unchanged.
+func (r *Router) Dispatch(name string, data map[string]string) error {
+	return r.DispatchContext(context.Background(), name, data)
+}
+
+// DispatchContext is like Dispatch but passes c to the handler through
+// ActionContext.Context.
+func (r *Router) DispatchContext(c context.Context, name string, data map[string]string) error {
+	action, id, handler, err := r.resolve(name)
+	if err != nil {
+		return err
+	}
+	return handler(NewActionContext(action, id, data).WithContext(c))
+}
@@ -0,0 +1,214 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// fakeComponent records the actions dispatched to it.
+type fakeComponent struct {
+	Base
+	names []string
+	calls []string
+	data  []string
+}
+
+func newFakeComponent(id string, names ...string) *fakeComponent {
+	return &fakeComponent{Base: NewBase(id, "fake"), names: names}
+}
+
+func (f *fakeComponent) Actions() map[string]ActionHandler {
+	actions := make(map[string]ActionHandler, len(f.names))
+	for _, name := range f.names {
+		actions[name] = func(ctx *ActionContext) error {
+			f.calls = append(f.calls, ctx.Action)
+			f.data = append(f.data, ctx.Data("value"))
+			if ctx.Action == "fail" {
+				return errors.New("handler failed")
+			}
+			return nil
+		}
+	}
+	return actions
+}
+
+func TestRouter_Resolve(t *testing.T) {
+	router := NewRouter()
+	router.Register(
+		newFakeComponent("users", "toggle_all", "toggle_row", "sort"),
+		newFakeComponent("user_dropdown", "toggle", "select"),
+	)
+
+	tests := []struct {
+		name   string
+		action string
+		id     string
+	}{
+		{"sort_users", "sort", "users"},
+		{"toggle_all_users", "toggle_all", "users"},
+		{"toggle_row_users", "toggle_row", "users"},
+		{"toggle_user_dropdown", "toggle", "user_dropdown"},
+		{"select_user_dropdown", "select", "user_dropdown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, id, err := router.Resolve(tt.name)
+			if err != nil {
+				t.Fatalf("Resolve(%q) returned error: %v", tt.name, err)
+			}
+			if action != tt.action || id != tt.id {
+				t.Errorf("Resolve(%q) = (%q, %q), want (%q, %q)", tt.name, action, id, tt.action, tt.id)
+			}
+		})
+	}
+}
+
+func TestRouter_ResolveErrors(t *testing.T) {
+	router := NewRouter()
+	router.Register(newFakeComponent("users", "sort"))
+
+	_, _, err := router.Resolve("sort_orders")
+	if !errors.Is(err, ErrUnknownComponent) {
+		t.Errorf("expected ErrUnknownComponent, got %v", err)
+	}
+
+	_, _, err = router.Resolve("filter_users")
+	if !errors.Is(err, ErrUnknownAction) {
+		t.Errorf("expected ErrUnknownAction, got %v", err)
+	}
+	var routeErr *RouteError
+	if !errors.As(err, &routeErr) {
+		t.Fatalf("expected *RouteError, got %T", err)
+	}
+	if routeErr.ComponentID != "users" || routeErr.Action != "filter" {
+		t.Errorf("expected component 'users' and action 'filter', got %q and %q", routeErr.ComponentID, routeErr.Action)
+	}
+
+	_, _, err = router.Resolve("nounderscore")
+	if !errors.Is(err, ErrUnknownComponent) {
+		t.Errorf("expected ErrUnknownComponent for name without separator, got %v", err)
+	}
+}
+
+func TestRouter_ResolveAmbiguous(t *testing.T) {
+	router := NewRouter()
+	router.Register(
+		newFakeComponent("all_users", "toggle"),
+		newFakeComponent("users", "toggle_all"),
+	)
+
+	_, _, err := router.Resolve("toggle_all_users")
+	if !errors.Is(err, ErrAmbiguousAction) {
+		t.Errorf("expected ErrAmbiguousAction, got %v", err)
+	}
+}
+
+func TestRouter_Dispatch(t *testing.T) {
+	users := newFakeComponent("users", "filter", "fail")
+	router := NewRouter()
+	router.Register(users)
+
+	if err := router.Dispatch("filter_users", map[string]string{"value": "ann"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users.calls) != 1 || users.calls[0] != "filter" {
+		t.Errorf("expected one 'filter' call, got %v", users.calls)
+	}
+	if users.data[0] != "ann" {
+		t.Errorf("expected data value 'ann', got %q", users.data[0])
+	}
+
+	if err := router.Dispatch("fail_users", nil); err == nil || err.Error() != "handler failed" {
+		t.Errorf("expected handler error to be returned unchanged, got %v", err)
+	}
+
+	if err := router.Dispatch("sort_users", nil); !errors.Is(err, ErrUnknownAction) {
+		t.Errorf("expected ErrUnknownAction, got %v", err)
+	}
+}
+
+func TestRouter_DispatchContext(t *testing.T) {
+	type key struct{}
+	var got any
+
+	router := NewRouter()
+	router.Register(&contextComponent{Base: NewBase("users", "fake"), handle: func(ctx *ActionContext) error {
+		got = ctx.Context().Value(key{})
+		return nil
+	}})
+
+	c := context.WithValue(context.Background(), key{}, "request")
+	if err := router.DispatchContext(c, "load_users", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "request" {
+		t.Errorf("expected handler to receive the request context, got %v", got)
+	}
+}
+
+// contextComponent exposes a single "load" action.
+type contextComponent struct {
+	Base
+	handle ActionHandler
+}
+
+func (c *contextComponent) Actions() map[string]ActionHandler {
+	return map[string]ActionHandler{"load": c.handle}
+}
+
+func TestRouter_RegisterUnregister(t *testing.T) {
+	router := NewRouter()
+	router.Register(newFakeComponent("users", "sort"))
+
+	if !router.Has("users") {
+		t.Error("expected 'users' to be registered")
+	}
+
+	router.Unregister("users")
+	if router.Has("users") {
+		t.Error("expected 'users' to be unregistered")
+	}
+}
+
+func TestRouter_ActionsAddedAfterRegister(t *testing.T) {
+	users := newFakeComponent("users", "sort")
+	router := NewRouter()
+	router.Register(users)
+
+	// An option applied after registration adds the action.
+	users.names = append(users.names, "export")
+	if err := router.Dispatch("export_users", nil); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+	if len(users.calls) != 1 || users.calls[0] != "export" {
+		t.Errorf("expected export to be dispatched, got %v", users.calls)
+	}
+}
+
+func TestRouter_Concurrent(t *testing.T) {
+	router := NewRouter()
+	router.Register(newFakeComponent("users", "sort"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		id := "c" + strconv.Itoa(i)
+		go func() {
+			defer wg.Done()
+			router.Register(newFakeComponent(id, "sort"))
+			router.Unregister(id)
+		}()
+		go func() {
+			defer wg.Done()
+			if _, _, err := router.Resolve("sort_users"); err != nil {
+				t.Errorf("Resolve returned error: %v", err)
+			}
+			router.Has(id)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,122 @@
+package base
+
+// DefaultOverscan is the number of items a Window renders beyond each edge
+// of the viewport, so short scrolls do not show blank space.
+const DefaultOverscan = 5
+
+// Window is the visible range of a long, virtualised list. Components render
+// only the items from Start to End, with spacers of Before and After pixels
+// in place of the rest, so the scrollbar still reflects the whole list.
+//
+// The scroll container sends a "scroll" action with lvt-data-offset (the
+// index of the first item in view) or scrollTop (in pixels); pass it to
+// ScrollFromAction.
+//
+// Example:
+//
+//	w := base.NewWindow(20, 36) // 20 rows of 36px in view
+//	w.SetTotal(len(items))
+//	visible := base.WindowOf(w, items)
+type Window struct {
+	// Offset is the index of the first item in view
+	Offset int
+	// Size is the number of items in view (0 renders every item)
+	Size int
+	// Overscan is the number of extra items rendered before and after the view
+	Overscan int
+	// ItemHeight is the fixed height of an item in pixels
+	ItemHeight int
+	// Total is the number of items in the list
+	Total int
+}
+
+// NewWindow creates a Window showing size items of itemHeight pixels.
+func NewWindow(size, itemHeight int) *Window {
+	return &Window{
+		Size:       size,
+		Overscan:   DefaultOverscan,
+		ItemHeight: itemHeight,
+	}
+}
+
+// SetTotal sets the number of items and keeps the offset within the list.
+func (w *Window) SetTotal(total int) {
+	w.Total = max(total, 0)
+	w.ScrollTo(w.Offset)
+}
+
+// ScrollTo moves the view to start at offset, clamped so the view stays
+// within the list.
+func (w *Window) ScrollTo(offset int) {
+	w.Offset = min(max(offset, 0), max(w.Total-w.Size, 0))
+}
+
+// ScrollIntoView scrolls the least distance that brings item index into
+// view, e.g. to follow keyboard highlighting.
+func (w *Window) ScrollIntoView(index int) {
+	if w.Size <= 0 {
+		return
+	}
+	if index < w.Offset {
+		w.ScrollTo(index)
+	} else if index >= w.Offset+w.Size {
+		w.ScrollTo(index - w.Size + 1)
+	}
+}
+
+// ScrollFromAction scrolls to the offset in a "scroll" action: lvt-data-offset
+// as an item index, or scrollTop in pixels.
+func (w *Window) ScrollFromAction(ctx *ActionContext) {
+	if !ctx.HasData("offset") && ctx.HasData("scrollTop") && w.ItemHeight > 0 {
+		w.ScrollTo(int(ctx.DataFloat("scrollTop")) / w.ItemHeight)
+		return
+	}
+	w.ScrollTo(ctx.DataInt("offset"))
+}
+
+// Start returns the index of the first rendered item.
+func (w Window) Start() int {
+	if w.Size <= 0 {
+		return 0
+	}
+	return min(max(w.Offset-w.Overscan, 0), w.Total)
+}
+
+// End returns the index after the last rendered item.
+func (w Window) End() int {
+	if w.Size <= 0 {
+		return w.Total
+	}
+	return min(w.Offset+w.Size+w.Overscan, w.Total)
+}
+
+// Before returns the height in pixels of the items before Start.
+func (w Window) Before() int {
+	return w.Start() * w.ItemHeight
+}
+
+// After returns the height in pixels of the items after End.
+func (w Window) After() int {
+	return (w.Total - w.End()) * w.ItemHeight
+}
+
+// Height returns the height in pixels of the viewport (0 if Size is 0).
+func (w Window) Height() int {
+	return max(w.Size, 0) * w.ItemHeight
+}
+
+// AtEnd reports whether the view reaches the last item, e.g. to load more
+// items in infinite scrolling.
+func (w Window) AtEnd() bool {
+	return w.Size <= 0 || w.Offset+w.Size >= w.Total
+}
+
+// WindowOf sets the window's Total to len(items) and returns the items to
+// render. A nil window renders every item.
+func WindowOf[T any](w *Window, items []T) []T {
+	if w == nil {
+		return items
+	}
+	w.SetTotal(len(items))
+	return items[w.Start():w.End()]
+}
@@ -0,0 +1,110 @@
+package base
+
+import (
+	"testing"
+)
+
+func TestNewWindow(t *testing.T) {
+	w := NewWindow(10, 32)
+
+	if w.Size != 10 || w.ItemHeight != 32 || w.Overscan != DefaultOverscan {
+		t.Errorf("unexpected window %+v", w)
+	}
+	if w.Height() != 320 {
+		t.Errorf("expected height 320, got %d", w.Height())
+	}
+}
+
+func TestWindow_Range(t *testing.T) {
+	tests := []struct {
+		name          string
+		offset, total int
+		start, end    int
+		before, after int
+	}{
+		{"top", 0, 100, 0, 15, 0, 850},
+		{"middle", 50, 100, 45, 65, 450, 350},
+		{"clamped to end", 95, 100, 85, 100, 850, 0},
+		{"past the end", 500, 100, 85, 100, 850, 0},
+		{"shorter than view", 3, 4, 0, 4, 0, 0},
+		{"empty", 0, 0, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWindow(10, 10)
+			w.SetTotal(tt.total)
+			w.ScrollTo(tt.offset)
+
+			if w.Start() != tt.start || w.End() != tt.end {
+				t.Errorf("range = [%d, %d), want [%d, %d)", w.Start(), w.End(), tt.start, tt.end)
+			}
+			if w.Before() != tt.before || w.After() != tt.after {
+				t.Errorf("spacers = %d/%d, want %d/%d", w.Before(), w.After(), tt.before, tt.after)
+			}
+		})
+	}
+}
+
+func TestWindow_ScrollIntoView(t *testing.T) {
+	w := NewWindow(10, 10)
+	w.SetTotal(100)
+
+	w.ScrollIntoView(25)
+	if w.Offset != 16 {
+		t.Errorf("expected offset 16 after scrolling down, got %d", w.Offset)
+	}
+	w.ScrollIntoView(20)
+	if w.Offset != 16 {
+		t.Errorf("expected visible item not to scroll, got %d", w.Offset)
+	}
+	w.ScrollIntoView(3)
+	if w.Offset != 3 {
+		t.Errorf("expected offset 3 after scrolling up, got %d", w.Offset)
+	}
+}
+
+func TestWindow_ScrollFromAction(t *testing.T) {
+	w := NewWindow(10, 20)
+	w.SetTotal(100)
+
+	w.ScrollFromAction(NewActionContext("scroll", "list", map[string]string{"offset": "40"}))
+	if w.Offset != 40 {
+		t.Errorf("expected offset 40, got %d", w.Offset)
+	}
+	w.ScrollFromAction(NewActionContext("scroll", "list", map[string]string{"scrollTop": "610.5"}))
+	if w.Offset != 30 {
+		t.Errorf("expected offset 30 from scrollTop, got %d", w.Offset)
+	}
+	if w.AtEnd() {
+		t.Error("expected AtEnd to be false")
+	}
+	w.ScrollTo(90)
+	if !w.AtEnd() {
+		t.Error("expected AtEnd to be true")
+	}
+}
+
+func TestWindowOf(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	if got := WindowOf(nil, items); len(got) != 50 {
+		t.Errorf("expected a nil window to render every item, got %d", len(got))
+	}
+
+	w := NewWindow(5, 10)
+	w.Overscan = 1
+	w.Offset = 20
+	got := WindowOf(w, items)
+	if w.Total != 50 || len(got) != 7 || got[0] != 19 {
+		t.Errorf("unexpected window items %v (total %d)", got, w.Total)
+	}
+
+	w.Offset = 48
+	if got := WindowOf(w, items[:10]); w.Offset != 5 || len(got) != 6 || got[0] != 4 {
+		t.Errorf("expected the offset clamped when the list shrinks, got %v at %d", got, w.Offset)
+	}
+}
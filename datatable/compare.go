@@ -0,0 +1,182 @@
+package datatable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// compareValues compares two cell values and reports whether they were
+// comparable. Numbers of any Go numeric type compare numerically, time.Time
+// chronologically, bools as false < true and strings case-insensitively in
+// natural order.
+// A string compared with a number or time is parsed first, so values coming
+// from lvt-data-* attributes can be compared with typed cell data.
+func compareValues(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			return compareFloats(af, bf), true
+		}
+		if bs, ok := b.(string); ok {
+			if bf, err := strconv.ParseFloat(strings.TrimSpace(bs), 64); err == nil {
+				return compareFloats(af, bf), true
+			}
+		}
+		return 0, false
+	}
+	if _, ok := toFloat(b); ok {
+		c, ok := compareValues(b, a)
+		return -c, ok
+	}
+
+	if at, ok := toTime(a, false); ok {
+		if bt, ok := toTime(b, true); ok {
+			return at.Compare(bt), true
+		}
+		return 0, false
+	}
+	if _, ok := toTime(b, false); ok {
+		c, ok := compareValues(b, a)
+		return -c, ok
+	}
+
+	if ab, ok := a.(bool); ok {
+		if bb, ok := toBool(b); ok {
+			return compareBools(ab, bb), true
+		}
+		return 0, false
+	}
+	if _, ok := b.(bool); ok {
+		c, ok := compareValues(b, a)
+		return -c, ok
+	}
+
+	return naturalCompare(foldCase(cellString(a)), foldCase(cellString(b))), true
+}
+
+// toFloat converts Go numeric types to float64.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// toTime converts time.Time (or *time.Time) to time.Time. When parse is true,
+// strings in RFC 3339 or "2006-01-02" layout are accepted too.
+func toTime(v any, parse bool) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case *time.Time:
+		if t != nil {
+			return *t, true
+		}
+	case string:
+		if !parse {
+			return time.Time{}, false
+		}
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if parsed, err := time.Parse(layout, strings.TrimSpace(t)); err == nil {
+				return parsed, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// toBool converts bools and boolean strings.
+func toBool(v any) (bool, bool) {
+	switch b := v.(type) {
+	case bool:
+		return b, true
+	case string:
+		if parsed, err := strconv.ParseBool(strings.TrimSpace(b)); err == nil {
+			return parsed, true
+		}
+	}
+	return false, false
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
+
+// cellString returns the plain string form of a cell value.
+func cellString(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case time.Time:
+		return s.Format(time.RFC3339)
+	case fmt.Stringer:
+		return s.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// foldCase applies Unicode simple case folding so that strings differing
+// only in case compare equal (including "Σ"/"σ"/"ς" and "K"/"k"/"K").
+// Each rune is mapped to the lower case of the smallest rune in its
+// folding orbit.
+func foldCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		min := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < min {
+				min = f
+			}
+		}
+		b.WriteRune(unicode.ToLower(min))
+	}
+	return b.String()
+}
@@ -2,19 +2,27 @@
 //
 // Available variants:
 //   - New() creates a data table (template: "lvt:datatable:default:v1")
+//   - NewTyped() creates a data table from a slice of structs (same template)
 //
 // Required lvt-* attributes: lvt-click
+// Optional: lvt-scroll, lvt-debounce (for WithVirtualRows and WithInfiniteScroll),
+// lvt-click-away (for WithRowActions), lvt-dblclick, lvt-keydown (for WithOnRowOpen)
 //
 // Example usage:
 //
 //	// In your controller/state
-//	Users: datatable.New[User]("users",
+//	Users: datatable.New("users",
 //	    datatable.WithColumns([]datatable.Column{
 //	        {ID: "name", Label: "Name", Sortable: true},
 //	        {ID: "email", Label: "Email", Sortable: true},
 //	        {ID: "role", Label: "Role"},
 //	    }),
-//	    datatable.WithData(users),
+//	    datatable.WithRows(rows),
+//	    datatable.WithPageSize(10),
+//	)
+//
+//	// Or, with columns taken from `datatable` struct tags on User
+//	Users: datatable.NewTyped("users", users,
 //	    datatable.WithPageSize(10),
 //	)
 //
@@ -23,9 +31,12 @@
 package datatable
 
 import (
+	"context"
 	"encoding/json"
+	"html/template"
 
 	"github.com/livetemplate/components/base"
+	"github.com/livetemplate/components/menu"
 )
 
 // SortDirection indicates the sort order.
@@ -45,8 +56,8 @@ type Column struct {
 	Label string
 	// Sortable allows sorting by this column
 	Sortable bool
-	// Filterable allows filtering by this column
-	Filterable bool
+	// Filterable sets how users can filter by this column (see FilterSpec)
+	Filterable FilterSpec
 	// Width is optional column width (e.g., "100px", "20%")
 	Width string
 	// Align is text alignment ("left", "center", "right")
@@ -55,6 +66,20 @@ type Column struct {
 	Hidden bool
 	// Format is a format hint (e.g., "date", "currency", "number")
 	Format string
+	// Comparator overrides the default sort order for this column
+	Comparator Comparator `json:"-"`
+	// Editable allows inline editing of this column's cells
+	Editable bool
+	// Editor is the input used for editing (defaults to EditorText)
+	Editor EditorKind
+	// EditOptions are the choices for EditorSelect
+	EditOptions []EditOption
+	// Validator checks edited values before they are committed
+	Validator Validator `json:"-"`
+	// Aggregate is shown in group headers and the totals footer
+	Aggregate Aggregate
+	// Pinned keeps the column at the left or right edge while scrolling
+	Pinned Pin
 }
 
 // Row represents a table row with data and metadata.
@@ -67,6 +92,14 @@ type Row struct {
 	Selected bool
 	// Disabled prevents row selection
 	Disabled bool
+	// Children are nested rows shown below this row when it is expanded
+	Children []Row
+	// Expanded indicates the row's children or detail panel are shown
+	Expanded bool
+	// Depth is the nesting level (set on rows returned by GetPageRows)
+	Depth int
+	// Group is set on the group header rows returned by GetPageRows
+	Group *RowGroup `json:",omitempty"`
 }
 
 // DataTable is a table component with sorting, filtering, and pagination.
@@ -77,36 +110,87 @@ type DataTable struct {
 	// Columns defines the table columns
 	Columns []Column
 
-	// Rows is the data
+	// Rows is the data. Rows are looked up by ID through an index that is
+	// rebuilt when Rows is replaced; use SetData rather than changing row IDs
+	// or children in place.
 	Rows []Row
 
-	// SortColumn is the currently sorted column ID
+	// SortColumn is the primary sort column ID (mirrors SortKeys[0])
 	SortColumn string
 
-	// SortDirection is the current sort direction
+	// SortDirection is the primary sort direction (mirrors SortKeys[0])
 	SortDirection SortDirection
 
+	// SortKeys lists the sort columns in priority order
+	SortKeys []SortKey
+
 	// FilterValue is the current filter/search text
 	FilterValue string
 
 	// FilterColumn limits filtering to a specific column (empty for all)
 	FilterColumn string
 
+	// ColumnFilters are per-column filters, all of which must match
+	ColumnFilters []ColumnFilter
+
 	// Page is the current page (0-indexed)
 	Page int
 
 	// PageSize is rows per page (0 for all)
 	PageSize int
 
+	// PageSizeOptions lists the page sizes offered by the page size selector
+	PageSizeOptions []int
+
+	// PageWindow is the number of page links shown either side of the current page
+	PageWindow int
+
+	// PageInfoFormat formats the start, end and total row numbers for PageInfo
+	PageInfoFormat string
+
+	// NoResultsMessage is the PageInfo text when no rows match
+	NoResultsMessage string
+
+	// Window is the range of page rows rendered in virtualised mode (nil
+	// renders every row; see WithVirtualRows)
+	Window *base.Window `json:",omitempty"`
+
+	// Infinite shows every page up to Page, appending pages as the user
+	// scrolls, instead of one page at a time
+	Infinite bool
+
 	// Selectable enables row selection
 	Selectable bool
 
 	// MultiSelect allows multiple row selection
 	MultiSelect bool
 
-	// SelectedIDs tracks selected row IDs
+	// SelectedIDs tracks selected row IDs (SelectionInclude mode)
 	SelectedIDs map[string]bool
 
+	// SelectionMode is SelectionExclude after SelectAllMatching
+	SelectionMode SelectionMode `json:",omitempty"`
+
+	// ExcludedIDs tracks deselected row IDs (SelectionExclude mode)
+	ExcludedIDs map[string]bool `json:",omitempty"`
+
+	// BulkActions are shown in the toolbar while rows are selected
+	BulkActions []BulkAction
+
+	// RowActions are shown in the actions menu at the end of each row
+	RowActions []RowAction
+
+	// OpenRowMenu is the ID of the row whose actions menu is open
+	OpenRowMenu string
+
+	// RowClickEnabled sends "click" actions when a row is clicked (set by
+	// WithOnRowClick)
+	RowClickEnabled bool
+
+	// RowOpenEnabled sends "open" actions when a row is double-clicked or
+	// Enter is pressed on it (set by WithOnRowOpen)
+	RowOpenEnabled bool
+
 	// Striped enables alternating row colors
 	Striped bool
 
@@ -125,8 +209,107 @@ type DataTable struct {
 	// EmptyMessage is shown when no data
 	EmptyMessage string
 
-	// filteredRows caches filtered results
-	filteredRows []Row
+	// StickyHeader keeps the header row visible while scrolling
+	StickyHeader bool
+
+	// ColumnChooser shows the column chooser button
+	ColumnChooser bool
+
+	// ColumnChooserOpen indicates the column chooser is open
+	ColumnChooserOpen bool
+
+	// OpenFacet is the column ID of the facet whose value list is open
+	OpenFacet string
+
+	// ViewsEnabled shows the saved views bar (set by WithViewStore)
+	ViewsEnabled bool
+
+	// SavedViews lists the names of the saved views (see LoadViews)
+	SavedViews []string
+
+	// CurrentView is the name of the last saved or applied view
+	CurrentView string
+
+	// ExportFormats lists the export buttons to show (requires WithOnExport)
+	ExportFormats []ExportFormat
+
+	// Editing is the cell being edited inline (nil when not editing)
+	Editing *CellEdit
+
+	// ExpandedIDs tracks expanded (true) and explicitly collapsed (false) row IDs
+	ExpandedIDs map[string]bool
+
+	// DetailTemplate is the name of the template rendered below expanded rows
+	DetailTemplate string
+
+	// GroupBy lists the column IDs rows are grouped by, outermost first
+	GroupBy []string
+
+	// CollapsedGroups tracks collapsed group keys (see RowGroup.Key)
+	CollapsedGroups map[string]bool
+
+	// view caches the indexes of rows passing the filters, in sort order
+	view []int
+
+	// tree caches the visible rows of a tree table, in display order
+	tree []Row
+
+	// grouped caches the rows and group headers of a grouped table
+	grouped []Row
+
+	// groupedCount is the number of rows in grouped, without group headers
+	groupedCount int
+
+	// lookup indexes Rows by row ID
+	lookup *rowLookup
+
+	// counts caches counts derived from every row
+	counts *rowCounts
+
+	// detailTmpl holds DetailTemplate
+	detailTmpl *template.Template
+
+	// source supplies rows when set; Rows then holds only the current page
+	source DataSource
+
+	// total is the row count reported by source
+	total int
+
+	// loadingMore makes the next Load append a page (see LoadMore)
+	loadingMore bool
+
+	// paged is set when decoding a table that had a DataSource: Rows then
+	// holds only the encoded page and total its row count
+	paged bool
+
+	// details holds the detail panels of a decoded table, used when
+	// detailTmpl is not set
+	details map[string]template.HTML
+
+	// facets caches Facets; with a DataSource it is set by Load
+	facets []Facet
+
+	// rowMenus holds the row actions menus of a decoded table, used when the
+	// row actions' Enabled predicates are not set
+	rowMenus map[string][]menu.Item
+
+	// views stores saved views
+	views ViewStore
+
+	// onExport handles the "export" action
+	onExport func(ctx context.Context, req ExportRequest) error
+
+	// onCellEdit approves or rejects committed cell edits
+	onCellEdit func(rowID, columnID string, oldValue, newValue any) error
+
+	// cellEdited is called with each committed cell value (set by NewTyped)
+	cellEdited func(rowID, columnID string, value any)
+
+	// onRowClick handles the "click" action
+	onRowClick func(ctx context.Context, row Row) error
+
+	// onRowOpen handles the "open" action
+	onRowOpen func(ctx context.Context, row Row) error
 }
 
 // New creates a data table.
@@ -142,10 +325,12 @@ func New(id string, opts ...Option) *DataTable {
 	dt := &DataTable{
 		Base:          base.NewBase(id, "datatable"),
 		SelectedIDs:   make(map[string]bool),
+		ExpandedIDs:   make(map[string]bool),
 		SortDirection: SortNone,
 		EmptyMessage:  "No data available",
 		Striped:       true,
 		Hoverable:     true,
+		PageWindow:    defaultPageWindow,
 	}
 
 	for _, opt := range opts {
@@ -155,43 +340,32 @@ func New(id string, opts ...Option) *DataTable {
 	return dt
 }
 
-// Sort sorts by a column. Toggles direction if same column.
+// Sort sorts by a single column. Toggles direction if it is already the
+// primary sort column. Use AddSort for multi-column sorting.
 func (dt *DataTable) Sort(columnID string) {
-	if dt.SortColumn == columnID {
-		// Toggle direction
-		switch dt.SortDirection {
-		case SortNone, SortDesc:
-			dt.SortDirection = SortAsc
-		case SortAsc:
-			dt.SortDirection = SortDesc
-		}
-	} else {
-		dt.SortColumn = columnID
-		dt.SortDirection = SortAsc
+	direction := SortAsc
+	if keys := dt.activeSortKeys(); len(keys) > 0 && keys[0].Column == columnID && keys[0].Direction == SortAsc {
+		direction = SortDesc
 	}
-	dt.filteredRows = nil // Clear cache
+	dt.setSortKeys([]SortKey{{Column: columnID, Direction: direction}})
 }
 
 // ClearSort removes sorting.
 func (dt *DataTable) ClearSort() {
-	dt.SortColumn = ""
-	dt.SortDirection = SortNone
-	dt.filteredRows = nil
+	dt.setSortKeys(nil)
 }
 
 // SetFilter sets the filter value.
 func (dt *DataTable) SetFilter(value string) {
 	dt.FilterValue = value
-	dt.Page = 0 // Reset to first page
-	dt.filteredRows = nil
+	dt.filtersChanged()
 }
 
 // ClearFilter clears the filter.
 func (dt *DataTable) ClearFilter() {
 	dt.FilterValue = ""
 	dt.FilterColumn = ""
-	dt.Page = 0
-	dt.filteredRows = nil
+	dt.filtersChanged()
 }
 
 // NextPage goes to the next page.
@@ -244,9 +418,23 @@ func (dt *DataTable) TotalPages() int {
 	return pages
 }
 
-// TotalRows returns the total number of rows (after filtering).
+// TotalRows returns the total number of rows (after filtering). Grouped
+// tables count the rows of expanded groups, without group headers.
 func (dt *DataTable) TotalRows() int {
-	return len(dt.GetFilteredRows())
+	if dt.pagedRows() {
+		return dt.total
+	}
+	if dt.isTree() {
+		return len(dt.treeRows())
+	}
+	if dt.IsGrouped() {
+		dt.groupedRows()
+		return dt.groupedCount
+	}
+	if !dt.IsFiltered() {
+		return len(dt.Rows)
+	}
+	return len(dt.viewIndexes())
 }
 
 // HasNextPage returns true if there's a next page.
@@ -259,42 +447,107 @@ func (dt *DataTable) HasPreviousPage() bool {
 	return dt.Page > 0
 }
 
-// GetFilteredRows returns rows after filtering (cached).
+// GetFilteredRows returns rows after filtering and sorting.
+// The text filter matches case-insensitively across the searchable columns
+// (see FilterColumn and Column.Filterable); all ColumnFilters must match too.
+// With a DataSource only the loaded page is available. Tree tables return the
+// visible rows in display order (see Row.Children); grouped tables return
+// the rows in group order, without group headers.
 func (dt *DataTable) GetFilteredRows() []Row {
-	// Simple implementation - in production this would be optimized
-	if dt.FilterValue == "" {
+	if dt.isTree() {
+		return dt.treeRows()
+	}
+	if dt.pagedRows() || (!dt.IsFiltered() && !dt.IsSorted() && !dt.IsGrouped()) {
 		return dt.Rows
 	}
+	return dt.rowsAt(dt.viewIndexes())
+}
 
-	if dt.filteredRows != nil {
-		return dt.filteredRows
+// GetPageRows returns rows for the current page. Grouped tables include a
+// header row (Row.Group set) before each group, on the page of the group's
+// first row; headers don't count towards PageSize.
+func (dt *DataTable) GetPageRows() []Row {
+	if dt.isTree() {
+		if dt.pagedRows() {
+			return dt.treeRows()
+		}
+		return pageOf(dt, dt.treeRows())
+	}
+	if dt.IsGrouped() {
+		if dt.pagedRows() {
+			return dt.groupedRows()
+		}
+		return dt.pageOfGroups(dt.groupedRows())
 	}
+	if dt.pagedRows() {
+		return dt.Rows
+	}
+	if !dt.IsFiltered() && !dt.IsSorted() {
+		return pageOf(dt, dt.Rows)
+	}
+	return dt.rowsAt(pageOf(dt, dt.viewIndexes()))
+}
 
-	// Filter implementation would go here
-	// For now, return all rows (actual filtering done server-side)
-	dt.filteredRows = dt.Rows
-	return dt.filteredRows
+// viewIndexes returns the indexes into Rows of the filtered rows in sort
+// order, grouped rows first ordered by group (cached until the filters, sort
+// keys or data change).
+func (dt *DataTable) viewIndexes() []int {
+	if dt.lookupRows(); dt.view != nil {
+		return dt.view
+	}
+	q := dt.query()
+	if dt.IsGrouped() {
+		q.Sort = dt.groupSortKeys(q.Sort)
+	}
+	dt.view = queryIndexes(dt.Rows, dt.Columns, q)
+	return dt.view
 }
 
-// GetPageRows returns rows for the current page.
-func (dt *DataTable) GetPageRows() []Row {
-	filtered := dt.GetFilteredRows()
+// resetView clears the cached filtered and sorted rows and keeps the current
+// page within the (possibly smaller) result set.
+func (dt *DataTable) resetView() {
+	dt.view = nil
+	dt.tree = nil
+	dt.grouped = nil
+	dt.counts = nil
+	if !dt.pagedRows() {
+		dt.facets = nil
+	}
+	dt.clampPage()
+}
 
+// pageOf returns the current page's slice of items.
+func pageOf[T any](dt *DataTable, items []T) []T {
 	if dt.PageSize <= 0 {
-		return filtered
+		return items
 	}
 
 	start := dt.Page * dt.PageSize
 	end := start + dt.PageSize
+	if dt.Infinite {
+		start = 0
+	}
 
-	if start >= len(filtered) {
+	if start >= len(items) {
 		return nil
 	}
-	if end > len(filtered) {
-		end = len(filtered)
+	if end > len(items) {
+		end = len(items)
 	}
 
-	return filtered[start:end]
+	return items[start:end]
+}
+
+// rowsAt returns the rows at the given indexes.
+func (dt *DataTable) rowsAt(indexes []int) []Row {
+	if indexes == nil {
+		return nil
+	}
+	rows := make([]Row, len(indexes))
+	for i, idx := range indexes {
+		rows[i] = dt.Rows[idx]
+	}
+	return rows
 }
 
 // SelectRow selects a row by ID.
@@ -303,115 +556,208 @@ func (dt *DataTable) SelectRow(id string) {
 		return
 	}
 
+	if dt.IsSelectingAllMatching() {
+		delete(dt.ExcludedIDs, id)
+		dt.setRowSelected(id, true)
+		return
+	}
+
 	if !dt.MultiSelect {
 		// Clear other selections
+		for other := range dt.SelectedIDs {
+			if row := dt.rowByID(other); row != nil {
+				row.Selected = false
+			}
+		}
 		dt.SelectedIDs = make(map[string]bool)
 	}
 	dt.SelectedIDs[id] = true
 
 	// Update row state
-	for i := range dt.Rows {
-		if dt.Rows[i].ID == id {
-			dt.Rows[i].Selected = true
-		} else if !dt.MultiSelect {
-			dt.Rows[i].Selected = false
-		}
+	if row := dt.rowByID(id); row != nil {
+		row.Selected = true
 	}
+	dt.selectionChanged()
 }
 
 // DeselectRow deselects a row by ID.
 func (dt *DataTable) DeselectRow(id string) {
-	delete(dt.SelectedIDs, id)
-	for i := range dt.Rows {
-		if dt.Rows[i].ID == id {
-			dt.Rows[i].Selected = false
+	if dt.IsSelectingAllMatching() {
+		if dt.ExcludedIDs == nil {
+			dt.ExcludedIDs = make(map[string]bool)
 		}
+		dt.ExcludedIDs[id] = true
+	} else {
+		delete(dt.SelectedIDs, id)
 	}
+	dt.setRowSelected(id, false)
 }
 
 // ToggleRowSelection toggles row selection.
 func (dt *DataTable) ToggleRowSelection(id string) {
-	if dt.SelectedIDs[id] {
+	if dt.IsRowSelected(id) {
 		dt.DeselectRow(id)
 	} else {
 		dt.SelectRow(id)
 	}
 }
 
-// SelectAll selects all rows.
+// SelectAll selects all loaded rows, including the children of tree rows.
+// Use SelectAllMatching to select every row matching the filters, across
+// pages, or SelectPage to select the current page.
 func (dt *DataTable) SelectAll() {
 	if !dt.Selectable || !dt.MultiSelect {
 		return
 	}
-	for i := range dt.Rows {
-		if !dt.Rows[i].Disabled {
-			dt.Rows[i].Selected = true
-			dt.SelectedIDs[dt.Rows[i].ID] = true
+	walkRows(dt.Rows, func(row *Row) {
+		if row.Disabled {
+			return
+		}
+		row.Selected = true
+		if dt.IsSelectingAllMatching() {
+			delete(dt.ExcludedIDs, row.ID)
+		} else {
+			dt.SelectedIDs[row.ID] = true
+		}
+	})
+	dt.selectionChanged()
+}
+
+// SelectPage selects the enabled rows on the current page, keeping the
+// selection on other pages.
+func (dt *DataTable) SelectPage() {
+	if !dt.Selectable || !dt.MultiSelect {
+		return
+	}
+	for _, row := range dt.GetPageRows() {
+		if row.Group == nil && !row.Disabled {
+			dt.SelectRow(row.ID)
 		}
 	}
 }
 
-// DeselectAll deselects all rows.
+// DeselectPage deselects the rows on the current page, keeping the selection
+// on other pages. A select-all-matching selection excludes them instead.
+func (dt *DataTable) DeselectPage() {
+	for _, row := range dt.GetPageRows() {
+		if row.Group == nil {
+			dt.DeselectRow(row.ID)
+		}
+	}
+}
+
+// DeselectAll deselects all rows, including a select-all-matching selection.
 func (dt *DataTable) DeselectAll() {
 	dt.SelectedIDs = make(map[string]bool)
-	for i := range dt.Rows {
-		dt.Rows[i].Selected = false
-	}
+	dt.SelectionMode = ""
+	dt.ExcludedIDs = nil
+	walkRows(dt.Rows, func(row *Row) {
+		row.Selected = false
+	})
+	dt.selectionChanged()
 }
 
-// SelectedCount returns the number of selected rows.
+// SelectedCount returns the number of selected rows. After SelectAllMatching
+// it counts every matching row not deselected; with a DataSource, disabled
+// rows that were never loaded are included in the count.
 func (dt *DataTable) SelectedCount() int {
-	return len(dt.SelectedIDs)
+	if !dt.IsSelectingAllMatching() {
+		return len(dt.SelectedIDs)
+	}
+	if dt.pagedRows() {
+		return max(dt.total-len(dt.ExcludedIDs), 0)
+	}
+	// Count the exclusions rather than the selected rows, so that a click
+	// only looks up the excluded rows.
+	excluded := 0
+	matches := dt.filterMatcher()
+	for id, ok := range dt.ExcludedIDs {
+		if row := dt.rowByID(id); ok && row != nil && !row.Disabled && matches(*row) {
+			excluded++
+		}
+	}
+	return dt.enabledMatchingCount() - excluded
 }
 
 // HasSelection returns true if any row is selected.
 func (dt *DataTable) HasSelection() bool {
-	return len(dt.SelectedIDs) > 0
+	return dt.SelectedCount() > 0
 }
 
-// AllSelected returns true if all rows are selected.
+// AllSelected returns true if every enabled row on the current page is
+// selected, as shown by the select-all checkbox.
 func (dt *DataTable) AllSelected() bool {
-	if len(dt.Rows) == 0 {
-		return false
+	counts := dt.cachedCounts()
+	if counts.allSelected >= 0 && counts.page == dt.Page && counts.pageSize == dt.PageSize {
+		return counts.allSelected == 1
 	}
-	selectableCount := 0
-	for _, row := range dt.Rows {
-		if !row.Disabled {
-			selectableCount++
+	counts.page, counts.pageSize = dt.Page, dt.PageSize
+	counts.allSelected = 0
+	if dt.pageAllSelected() {
+		counts.allSelected = 1
+	}
+	return counts.allSelected == 1
+}
+
+// pageAllSelected returns true if every enabled row on the current page is
+// selected.
+func (dt *DataTable) pageAllSelected() bool {
+	found := false
+	for _, row := range dt.GetPageRows() {
+		if row.Group != nil || row.Disabled {
+			continue
+		}
+		if !row.Selected {
+			return false
 		}
+		found = true
 	}
-	return len(dt.SelectedIDs) == selectableCount
+	return found
 }
 
-// GetSelectedRows returns all selected rows.
+// GetSelectedRows returns the selected rows that are loaded, including the
+// children of tree rows, depth first.
 func (dt *DataTable) GetSelectedRows() []Row {
 	var selected []Row
-	for _, row := range dt.Rows {
-		if dt.SelectedIDs[row.ID] {
-			selected = append(selected, row)
+	matches := dt.filterMatcher()
+	walkRows(dt.Rows, func(row *Row) {
+		if dt.selected(*row) && (!dt.IsSelectingAllMatching() || matches(*row)) {
+			selected = append(selected, *row)
 		}
-	}
+	})
 	return selected
 }
 
 // IsRowSelected checks if a row is selected.
 func (dt *DataTable) IsRowSelected(id string) bool {
-	return dt.SelectedIDs[id]
+	if !dt.IsSelectingAllMatching() {
+		return dt.SelectedIDs[id]
+	}
+	row := dt.rowByID(id)
+	return row != nil && dt.selected(*row) && dt.filterMatcher()(*row)
 }
 
-// IsSortedBy checks if sorted by a column.
+// setRowSelected mirrors a row's selection on the loaded row.
+func (dt *DataTable) setRowSelected(id string, selected bool) {
+	if row := dt.rowByID(id); row != nil {
+		row.Selected = selected && !row.Disabled
+	}
+	dt.selectionChanged()
+}
+
+// IsSortedBy checks if sorted by a column (at any priority).
 func (dt *DataTable) IsSortedBy(columnID string) bool {
-	return dt.SortColumn == columnID && dt.SortDirection != SortNone
+	return dt.sortDirectionOf(columnID) != SortNone
 }
 
 // IsSortedAsc checks if sorted ascending by a column.
 func (dt *DataTable) IsSortedAsc(columnID string) bool {
-	return dt.SortColumn == columnID && dt.SortDirection == SortAsc
+	return dt.sortDirectionOf(columnID) == SortAsc
 }
 
 // IsSortedDesc checks if sorted descending by a column.
 func (dt *DataTable) IsSortedDesc(columnID string) bool {
-	return dt.SortColumn == columnID && dt.SortDirection == SortDesc
+	return dt.sortDirectionOf(columnID) == SortDesc
 }
 
 // GetColumn returns a column by ID.
@@ -424,7 +770,8 @@ func (dt *DataTable) GetColumn(id string) *Column {
 	return nil
 }
 
-// VisibleColumns returns non-hidden columns.
+// VisibleColumns returns non-hidden columns in display order: left-pinned
+// columns first and right-pinned columns last.
 func (dt *DataTable) VisibleColumns() []Column {
 	var visible []Column
 	for _, col := range dt.Columns {
@@ -432,7 +779,7 @@ func (dt *DataTable) VisibleColumns() []Column {
 			visible = append(visible, col)
 		}
 	}
-	return visible
+	return pinnedColumns(visible)
 }
 
 // ShowColumn unhides a column.
@@ -456,39 +803,32 @@ func (dt *DataTable) SetLoading(loading bool) {
 
 // SetData replaces all rows.
 func (dt *DataTable) SetData(rows []Row) {
+	dt.restoreExpanded(rows)
 	dt.Rows = rows
-	dt.filteredRows = nil
+	dt.indexRows()
+	dt.resetView()
 	dt.DeselectAll()
 }
 
 // IsEmpty returns true if there's no data.
 func (dt *DataTable) IsEmpty() bool {
-	return len(dt.GetFilteredRows()) == 0
+	return dt.TotalRows() == 0
 }
 
-// PageInfo returns information about the current page.
+// PageInfo returns the localisable range text for the current page, e.g.
+// "Showing 11–20 of 345" (see PageInfoFormat and NoResultsMessage).
 func (dt *DataTable) PageInfo() string {
 	total := dt.TotalRows()
-	if total == 0 {
-		return "No results"
-	}
-
-	if dt.PageSize <= 0 {
+	if total > 0 && dt.PageSize <= 0 {
 		return ""
 	}
-
-	start := dt.Page*dt.PageSize + 1
-	end := start + dt.PageSize - 1
-	if end > total {
-		end = total
-	}
-
-	return "" // Would format "Showing 1-10 of 100" but keeping simple
+	return dt.formatPageInfo(dt.StartIndex(), dt.EndIndex(), total)
 }
 
-// StartIndex returns the 1-based start index for current page.
+// StartIndex returns the 1-based start index for current page (1 in infinite
+// mode, which shows every page up to the current one).
 func (dt *DataTable) StartIndex() int {
-	if dt.PageSize <= 0 {
+	if dt.PageSize <= 0 || dt.Infinite {
 		return 1
 	}
 	return dt.Page*dt.PageSize + 1
@@ -527,6 +867,45 @@ func (r Row) GetCellString(columnID string) string {
 	return ""
 }
 
+// computedFields are the values MarshalJSON adds to the exported fields so
+// that templates rendering the JSON representation can use them.
+type computedFields struct {
+	VisibleColumns  []Column `json:"VisibleColumns"`
+	PageRows        []Row    `json:"PageRows"`
+	StartIndex      int      `json:"StartIndex"`
+	EndIndex        int      `json:"EndIndex"`
+	TotalRowsCount  int      `json:"TotalRows"`
+	HasPreviousPage bool     `json:"HasPreviousPage"`
+	HasNextPage     bool     `json:"HasNextPage"`
+	AllSelectedFlag bool     `json:"AllSelected"`
+	IsEmptyFlag     bool     `json:"IsEmpty"`
+	TotalPages      int      `json:"TotalPages"`
+	SelectedCount   int      `json:"SelectedCount"`
+	MatchingCount   int      `json:"MatchingCount"`
+	PageInfo        string   `json:"PageInfo"`
+
+	// Paged is set when Rows holds only the current page (see HasDataSource)
+	Paged bool `json:"Paged,omitempty"`
+
+	// PageLinks is the windowed page number list
+	PageLinks []PageLink `json:"PageLinks,omitempty"`
+
+	// Details holds the rendered detail panels of expanded page rows
+	Details map[string]template.HTML `json:"Details,omitempty"`
+
+	// Totals holds the formatted aggregates for the totals footer
+	Totals map[string]string `json:"Totals,omitempty"`
+
+	// Facets are the facet and range filters
+	Facets []Facet `json:"Facets,omitempty"`
+
+	// FilterChips are the active filters
+	FilterChips []FilterChip `json:"FilterChips,omitempty"`
+
+	// RowMenus holds the row actions menus of the page rows by row ID
+	RowMenus map[string][]menu.Item `json:"RowMenus,omitempty"`
+}
+
 // MarshalJSON implements json.Marshaler to include computed fields for RPC serialization.
 // This ensures that methods like VisibleColumns(), GetPageRows() are available as JSON fields
 // when the datatable is serialized and used in templates via RPC.
@@ -534,30 +913,408 @@ func (dt *DataTable) MarshalJSON() ([]byte, error) {
 	// Create an alias to avoid infinite recursion
 	type DataTableAlias DataTable
 
+	pageRows := dt.WindowRows()
+	details, err := dt.renderDetails(pageRows)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build computed fields
 	return json.Marshal(&struct {
 		*DataTableAlias
-
-		// Computed fields for template access
-		VisibleColumns  []Column `json:"VisibleColumns"`
-		PageRows        []Row    `json:"PageRows"`
-		StartIndex      int      `json:"StartIndex"`
-		EndIndex        int      `json:"EndIndex"`
-		TotalRowsCount  int      `json:"TotalRows"`
-		HasPreviousPage bool     `json:"HasPreviousPage"`
-		HasNextPage     bool     `json:"HasNextPage"`
-		AllSelectedFlag bool     `json:"AllSelected"`
-		IsEmptyFlag     bool     `json:"IsEmpty"`
+		computedFields
 	}{
-		DataTableAlias:  (*DataTableAlias)(dt),
-		VisibleColumns:  dt.VisibleColumns(),
-		PageRows:        dt.GetPageRows(),
-		StartIndex:      dt.StartIndex(),
-		EndIndex:        dt.EndIndex(),
-		TotalRowsCount:  dt.TotalRows(),
-		HasPreviousPage: dt.HasPreviousPage(),
-		HasNextPage:     dt.HasNextPage(),
-		AllSelectedFlag: dt.AllSelected(),
-		IsEmptyFlag:     dt.IsEmpty(),
+		DataTableAlias: (*DataTableAlias)(dt),
+		computedFields: computedFields{
+			VisibleColumns:  dt.VisibleColumns(),
+			PageRows:        pageRows,
+			StartIndex:      dt.StartIndex(),
+			EndIndex:        dt.EndIndex(),
+			TotalRowsCount:  dt.TotalRows(),
+			HasPreviousPage: dt.HasPreviousPage(),
+			HasNextPage:     dt.HasNextPage(),
+			AllSelectedFlag: dt.AllSelected(),
+			IsEmptyFlag:     dt.IsEmpty(),
+			TotalPages:      dt.TotalPages(),
+			SelectedCount:   dt.SelectedCount(),
+			MatchingCount:   dt.MatchingCount(),
+			PageInfo:        dt.PageInfo(),
+			Paged:           dt.pagedRows(),
+			PageLinks:       dt.PageLinks(),
+			Details:         details,
+			Totals:          dt.Totals(),
+			Facets:          dt.Facets(),
+			FilterChips:     dt.FilterChips(),
+			RowMenus:        dt.rowMenusOf(pageRows),
+		},
 	})
 }
+
+// UnmarshalJSON implements json.Unmarshaler, restoring a table encoded with
+// MarshalJSON. Callbacks, the DataSource and the detail template are not
+// encoded; decode into a table created with New and the same options to keep
+// them. Without a DataSource, a table encoded with one keeps the encoded page
+// and row count, and detail panels are restored as rendered, so the decoded
+// table renders and encodes exactly like the original.
+func (dt *DataTable) UnmarshalJSON(data []byte) error {
+	type DataTableAlias DataTable
+
+	aux := struct {
+		*DataTableAlias
+		computedFields
+	}{DataTableAlias: (*DataTableAlias)(dt)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if dt.SelectedIDs == nil {
+		dt.SelectedIDs = make(map[string]bool)
+	}
+	if dt.ExpandedIDs == nil {
+		dt.ExpandedIDs = make(map[string]bool)
+	}
+	if dt.IsSelectingAllMatching() && dt.ExcludedIDs == nil {
+		dt.ExcludedIDs = make(map[string]bool)
+	}
+	dt.paged = aux.Paged
+	dt.total = aux.TotalRowsCount
+	dt.details = aux.Details
+	dt.rowMenus = aux.RowMenus
+	dt.facets = nil
+	if dt.paged {
+		dt.facets = aux.Facets
+	}
+	dt.view = nil
+	dt.tree = nil
+	dt.grouped = nil
+	dt.indexRows()
+	return nil
+}
+
+// Actions returns the data table's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "sort" sorts by the column in lvt-data-column; with lvt-data-multi="true"
+//     (the "+" header button shown once the table is sorted) the column is
+//     added as a secondary sort key
+//   - "filter" sets the filter text from the input value; "clear_filter" resets it
+//   - "filter_column" sets a filter on lvt-data-column using lvt-data-op
+//     ("contains", "equals", "range", "in") with the input value, lvt-data-min,
+//     lvt-data-max or comma-separated lvt-data-values; an empty operand removes it
+//   - "clear_column_filter" removes the filter on lvt-data-column
+//   - "toggle_facet" toggles the facet value lvt-data-value of lvt-data-column;
+//     "toggle_facet_menu" and "close_facet_menu" open and close its value list
+//   - "filter_range" moves the lvt-data-bound ("min" or "max") of the range
+//     filter on lvt-data-column to the input value (see SetFacetRange)
+//   - "remove_filter" removes the filter chip with lvt-data-column and
+//     lvt-data-value; "clear_filters" removes every filter
+//   - "next_page", "prev_page", "first_page", "last_page" and "go_to_page"
+//     (lvt-data-page, 0-indexed) navigate pages
+//   - "jump_to_page" goes to the 1-indexed page in the input value (or
+//     lvt-data-page), clamped to the valid range
+//   - "set_page_size" sets the page size from the selected value (or
+//     lvt-data-size)
+//   - "scroll" moves the Window of a virtualised table to lvt-data-offset (or
+//     scrollTop in pixels); in infinite mode it loads the next page once the
+//     last row is in view. "load_more" loads the next page directly.
+//   - "select_row" and "toggle_row" change the selection of lvt-data-row
+//   - "toggle_all" selects or deselects the enabled rows on the current page
+//   - "select_all_matching" selects every row matching the filters, across
+//     pages; "clear_selection" deselects everything
+//   - "bulk_action" runs the WithBulkAction handler named by lvt-data-action
+//   - "toggle_row_menu" opens or closes the actions menu of lvt-data-row;
+//     "close_row_menu" closes it
+//   - "row_action" runs the row action named by lvt-data-action on
+//     lvt-data-row (see WithRowActions)
+//   - "click" and "open" pass lvt-data-row to the WithOnRowClick and
+//     WithOnRowOpen handlers
+//   - "export" passes an ExportRequest for lvt-data-format ("csv", "tsv",
+//     "json") and lvt-data-scope ("page", "filtered", "selected") to the
+//     WithOnExport handler
+//   - "begin_edit" starts editing the cell at lvt-data-row and lvt-data-column
+//   - "commit_edit" commits the input value (or lvt-data-value); with
+//     lvt-data-row and lvt-data-column it edits that cell in one step, as
+//     toggle cells do. Rejected values are shown next to the cell.
+//   - "cancel_edit" discards the edit
+//   - "toggle_expand" expands or collapses lvt-data-row, showing its children
+//     or detail panel
+//   - "toggle_column_chooser" opens or closes the column chooser;
+//     "close_column_chooser" closes it
+//   - "toggle_column" shows or hides lvt-data-column
+//   - "move_column" moves lvt-data-column to lvt-data-index in Columns;
+//     "move_column_left" and "move_column_right" move it one place
+//   - "resize_column" sets the width of lvt-data-column to lvt-data-width (or
+//     the input value) in pixels
+//   - "pin_column" pins lvt-data-column to lvt-data-pin (or the selected value):
+//     "left", "right" or "" to unpin
+//   - "save_view" saves the current state as a view named by the input value
+//     (or lvt-data-name); "apply_view" applies the selected view (or
+//     lvt-data-view); "delete_view" deletes lvt-data-view (or the current
+//     view)
+//   - "group_by" groups rows by the comma-separated lvt-data-columns (empty
+//     to ungroup); "toggle_group" collapses or expands lvt-data-group
+//
+// Sort, filter, page and apply_view actions reload the current page from the
+// DataSource, if one is set, using the action's context.
+func (dt *DataTable) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"sort": func(ctx *base.ActionContext) error {
+			if ctx.DataBool("multi") {
+				dt.AddSort(ctx.Data("column"))
+			} else {
+				dt.Sort(ctx.Data("column"))
+			}
+			return dt.Load(ctx.Context())
+		},
+		"filter": func(ctx *base.ActionContext) error {
+			dt.SetFilter(ctx.Data("value"))
+			return dt.Load(ctx.Context())
+		},
+		"clear_filter": func(ctx *base.ActionContext) error {
+			dt.ClearFilter()
+			return dt.Load(ctx.Context())
+		},
+		"filter_column": func(ctx *base.ActionContext) error {
+			filter, ok := columnFilterFromAction(ctx)
+			if ok {
+				dt.SetColumnFilter(filter)
+			} else {
+				dt.RemoveColumnFilter(filter.Column)
+			}
+			return dt.Load(ctx.Context())
+		},
+		"clear_column_filter": func(ctx *base.ActionContext) error {
+			dt.RemoveColumnFilter(ctx.Data("column"))
+			return dt.Load(ctx.Context())
+		},
+		"toggle_facet": func(ctx *base.ActionContext) error {
+			dt.ToggleFacetValue(ctx.Data("column"), ctx.Data("value"))
+			return dt.Load(ctx.Context())
+		},
+		"toggle_facet_menu": func(ctx *base.ActionContext) error {
+			dt.ToggleFacetMenu(ctx.Data("column"))
+			return nil
+		},
+		"close_facet_menu": func(ctx *base.ActionContext) error {
+			dt.OpenFacet = ""
+			return nil
+		},
+		"filter_range": func(ctx *base.ActionContext) error {
+			f := dt.facet(ctx.Data("column"))
+			if f == nil {
+				return nil
+			}
+			low, high := f.Low, f.High
+			if ctx.Data("bound") == "max" {
+				high = ctx.DataFloat("value")
+			} else {
+				low = ctx.DataFloat("value")
+			}
+			dt.SetFacetRange(f.Column, low, high)
+			return dt.Load(ctx.Context())
+		},
+		"remove_filter": func(ctx *base.ActionContext) error {
+			dt.RemoveFilter(ctx.Data("column"), ctx.Data("value"))
+			return dt.Load(ctx.Context())
+		},
+		"clear_filters": func(ctx *base.ActionContext) error {
+			dt.ClearAllFilters()
+			return dt.Load(ctx.Context())
+		},
+		"next_page": func(ctx *base.ActionContext) error {
+			dt.NextPage()
+			return dt.Load(ctx.Context())
+		},
+		"prev_page": func(ctx *base.ActionContext) error {
+			dt.PreviousPage()
+			return dt.Load(ctx.Context())
+		},
+		"first_page": func(ctx *base.ActionContext) error {
+			dt.FirstPage()
+			return dt.Load(ctx.Context())
+		},
+		"last_page": func(ctx *base.ActionContext) error {
+			dt.LastPage()
+			return dt.Load(ctx.Context())
+		},
+		"go_to_page": func(ctx *base.ActionContext) error {
+			dt.GoToPage(ctx.DataInt("page"))
+			return dt.Load(ctx.Context())
+		},
+		"jump_to_page": func(ctx *base.ActionContext) error {
+			page := ctx.DataInt("value")
+			if ctx.HasData("page") {
+				page = ctx.DataInt("page")
+			}
+			dt.JumpToPage(page)
+			return dt.Load(ctx.Context())
+		},
+		"set_page_size": func(ctx *base.ActionContext) error {
+			size := ctx.DataInt("value")
+			if ctx.HasData("size") {
+				size = ctx.DataInt("size")
+			}
+			dt.SetPageSize(size)
+			return dt.Load(ctx.Context())
+		},
+		"scroll": func(ctx *base.ActionContext) error {
+			return dt.scroll(ctx)
+		},
+		"load_more": func(ctx *base.ActionContext) error {
+			if dt.LoadMore() {
+				return dt.Load(ctx.Context())
+			}
+			return nil
+		},
+		"select_row": func(ctx *base.ActionContext) error {
+			dt.SelectRow(ctx.Data("row"))
+			return nil
+		},
+		"toggle_row": func(ctx *base.ActionContext) error {
+			dt.ToggleRowSelection(ctx.Data("row"))
+			return nil
+		},
+		"toggle_all": func(ctx *base.ActionContext) error {
+			if dt.AllSelected() {
+				dt.DeselectPage()
+			} else {
+				dt.SelectPage()
+			}
+			return nil
+		},
+		"select_all_matching": func(ctx *base.ActionContext) error {
+			dt.SelectAllMatching()
+			return nil
+		},
+		"clear_selection": func(ctx *base.ActionContext) error {
+			dt.DeselectAll()
+			return nil
+		},
+		"bulk_action": func(ctx *base.ActionContext) error {
+			return dt.runBulkAction(ctx.Context(), ctx.Data("action"))
+		},
+		"toggle_row_menu": func(ctx *base.ActionContext) error {
+			dt.ToggleRowMenu(ctx.Data("row"))
+			return nil
+		},
+		"close_row_menu": func(ctx *base.ActionContext) error {
+			dt.OpenRowMenu = ""
+			return nil
+		},
+		"row_action": func(ctx *base.ActionContext) error {
+			return dt.RunRowAction(ctx.Context(), ctx.Data("action"), ctx.Data("row"))
+		},
+		"click": func(ctx *base.ActionContext) error {
+			return dt.ClickRow(ctx.Context(), ctx.Data("row"))
+		},
+		"open": func(ctx *base.ActionContext) error {
+			return dt.OpenRow(ctx.Context(), ctx.Data("row"))
+		},
+		"export": func(ctx *base.ActionContext) error {
+			if dt.onExport == nil {
+				return errNoExportHandler
+			}
+			req, err := dt.exportRequestFromAction(ctx)
+			if err != nil {
+				return err
+			}
+			return dt.onExport(ctx.Context(), req)
+		},
+		"begin_edit": func(ctx *base.ActionContext) error {
+			return dt.BeginEdit(ctx.Data("row"), ctx.Data("column"))
+		},
+		"commit_edit": func(ctx *base.ActionContext) error {
+			rowID, columnID := ctx.Data("row"), ctx.Data("column")
+			if ctx.HasData("row") && !dt.IsEditingCell(rowID, columnID) {
+				if err := dt.BeginEdit(rowID, columnID); err != nil {
+					return err
+				}
+			}
+			// Rejected values keep the edit open and are shown inline.
+			if err := dt.CommitEdit(ctx.Data("value")); err != nil && !dt.IsEditing() {
+				return err
+			}
+			return nil
+		},
+		"cancel_edit": func(ctx *base.ActionContext) error {
+			dt.CancelEdit()
+			return nil
+		},
+		"toggle_expand": func(ctx *base.ActionContext) error {
+			dt.ToggleRowExpansion(ctx.Data("row"))
+			return nil
+		},
+		"toggle_column_chooser": func(ctx *base.ActionContext) error {
+			dt.ToggleColumnChooser()
+			return nil
+		},
+		"close_column_chooser": func(ctx *base.ActionContext) error {
+			dt.ColumnChooserOpen = false
+			return nil
+		},
+		"toggle_column": func(ctx *base.ActionContext) error {
+			dt.ToggleColumn(ctx.Data("column"))
+			return nil
+		},
+		"move_column": func(ctx *base.ActionContext) error {
+			dt.MoveColumn(ctx.Data("column"), ctx.DataInt("index"))
+			return nil
+		},
+		"move_column_left": func(ctx *base.ActionContext) error {
+			dt.MoveColumnLeft(ctx.Data("column"))
+			return nil
+		},
+		"move_column_right": func(ctx *base.ActionContext) error {
+			dt.MoveColumnRight(ctx.Data("column"))
+			return nil
+		},
+		"resize_column": func(ctx *base.ActionContext) error {
+			width := ctx.DataInt("value")
+			if ctx.HasData("width") {
+				width = ctx.DataInt("width")
+			}
+			dt.ResizeColumn(ctx.Data("column"), width)
+			return nil
+		},
+		"pin_column": func(ctx *base.ActionContext) error {
+			pin := ctx.Data("value")
+			if ctx.HasData("pin") {
+				pin = ctx.Data("pin")
+			}
+			dt.PinColumn(ctx.Data("column"), Pin(pin))
+			return nil
+		},
+		"save_view": func(ctx *base.ActionContext) error {
+			name := ctx.Data("value")
+			if ctx.HasData("name") {
+				name = ctx.Data("name")
+			}
+			return dt.SaveView(ctx.Context(), name)
+		},
+		"apply_view": func(ctx *base.ActionContext) error {
+			name := ctx.Data("value")
+			if ctx.HasData("view") {
+				name = ctx.Data("view")
+			}
+			if err := dt.ApplySavedView(ctx.Context(), name); err != nil {
+				return err
+			}
+			return dt.Load(ctx.Context())
+		},
+		"delete_view": func(ctx *base.ActionContext) error {
+			name := dt.CurrentView
+			if ctx.HasData("view") {
+				name = ctx.Data("view")
+			}
+			return dt.DeleteView(ctx.Context(), name)
+		},
+		"group_by": func(ctx *base.ActionContext) error {
+			dt.SetGroupBy(groupByFromAction(ctx.Data("columns"))...)
+			return nil
+		},
+		"toggle_group": func(ctx *base.ActionContext) error {
+			dt.ToggleGroup(ctx.Data("group"))
+			return nil
+		},
+	}
+}
@@ -1,7 +1,19 @@
 package datatable
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -635,3 +647,3469 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Expected Templates() to return a TemplateSet")
 	}
 }
+
+// parsedTemplates parses the datatable templates once for the template tests.
+var parsedTemplates = sync.OnceValues(func() (*template.Template, error) {
+	ts := Templates()
+	return template.New("test").Funcs(ts.Funcs).ParseFS(ts.FS, ts.Pattern)
+})
+
+// renderTemplate renders v, a *DataTable or its JSON map form, with the
+// default template. Runs of whitespace are collapsed to one space.
+func renderTemplate(t *testing.T, v interface{}) string {
+	t.Helper()
+	tmpl, err := parsedTemplates()
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "lvt:datatable:default:v1", v); err != nil {
+		t.Fatalf("failed to execute template: %v", err)
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+// rendering is the output of one renderVariants case.
+type rendering struct {
+	name   string
+	v      interface{}
+	styled bool
+	html   string
+}
+
+// renderVariants renders dt and its JSON map form, styled and unstyled.
+func renderVariants(t *testing.T, dt *DataTable) []rendering {
+	t.Helper()
+	var out []rendering
+	for _, styled := range []bool{true, false} {
+		dt.SetStyled(styled)
+		data, err := json.Marshal(dt)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+		for _, v := range []interface{}{dt, m} {
+			name := fmt.Sprintf("%T (styled=%v)", v, styled)
+			out = append(out, rendering{name: name, v: v, styled: styled, html: renderTemplate(t, v)})
+		}
+	}
+	return out
+}
+
+// contains reports every want missing from the output.
+func (r rendering) contains(t *testing.T, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(r.html, w) {
+			t.Errorf("%s: expected output to contain %q", r.name, w)
+		}
+	}
+}
+
+// excludes reports every unwanted string found in the output.
+func (r rendering) excludes(t *testing.T, unwanted ...string) {
+	t.Helper()
+	for _, u := range unwanted {
+		if strings.Contains(r.html, u) {
+			t.Errorf("%s: expected output not to contain %q", r.name, u)
+		}
+	}
+}
+
+func TestActions(t *testing.T) {
+	rows := make([]Row, 25)
+	for i := range rows {
+		rows[i] = Row{ID: string(rune('a' + i))}
+	}
+	dt := New("users", WithRows(rows), WithPageSize(10), WithMultiSelect(true))
+	actions := dt.Actions()
+
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		handler, ok := actions[name]
+		if !ok {
+			t.Fatalf("expected %q action", name)
+		}
+		if err := handler(base.NewActionContext(name, "users", data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+
+	run("sort", map[string]string{"column": "name"})
+	if !dt.IsSortedAsc("name") {
+		t.Error("expected sort action to sort ascending by name")
+	}
+
+	run("next_page", nil)
+	if dt.Page != 1 {
+		t.Errorf("expected Page 1 after next_page, got %d", dt.Page)
+	}
+	run("go_to_page", map[string]string{"page": "2"})
+	if dt.Page != 2 {
+		t.Errorf("expected Page 2 after go_to_page, got %d", dt.Page)
+	}
+	run("prev_page", nil)
+	if dt.Page != 1 {
+		t.Errorf("expected Page 1 after prev_page, got %d", dt.Page)
+	}
+
+	run("toggle_row", map[string]string{"row": "b"})
+	if !dt.IsRowSelected("b") {
+		t.Error("expected row 'b' to be selected")
+	}
+	run("toggle_all", nil)
+	if !dt.AllSelected() || dt.SelectedCount() != 11 {
+		t.Errorf("expected toggle_all to select the page, got %d rows", dt.SelectedCount())
+	}
+	run("toggle_all", nil)
+	if dt.AllSelected() || dt.SelectedCount() != 1 || !dt.IsRowSelected("b") {
+		t.Error("expected second toggle_all to deselect only the page")
+	}
+
+	run("filter", map[string]string{"value": "x"})
+	if dt.FilterValue != "x" || dt.Page != 0 {
+		t.Errorf("expected filter 'x' on page 0, got %q on page %d", dt.FilterValue, dt.Page)
+	}
+}
+
+func filterTestTable(opts ...Option) *DataTable {
+	rows := []Row{
+		{ID: "1", Data: map[string]any{"name": "Ängström", "country": "ÖSTERREICH", "age": 31, "active": true}},
+		{ID: "2", Data: map[string]any{"name": "Bob", "country": "Germany", "age": 45, "active": false}},
+		{ID: "3", Data: map[string]any{"name": "Carol", "country": "österreich", "age": 27.5, "active": true}},
+		{ID: "4", Data: map[string]any{"name": "Dave", "country": "France", "age": nil, "active": false}},
+	}
+	columns := []Column{
+		{ID: "name", Label: "Name"},
+		{ID: "country", Label: "Country"},
+		{ID: "age", Label: "Age"},
+		{ID: "active", Label: "Active"},
+	}
+	return New("people", append([]Option{WithColumns(columns), WithRows(rows)}, opts...)...)
+}
+
+func rowIDs(rows []Row) []string {
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	return ids
+}
+
+func TestTextFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		setup  func(dt *DataTable)
+		want   []string
+	}{
+		{"case-insensitive unicode", "österreich", nil, []string{"1", "3"}},
+		{"upper-case query", "ÄNGSTRÖM", nil, []string{"1"}},
+		{"numeric cell", "45", nil, []string{"2"}},
+		{"no match", "zzz", nil, []string{}},
+		{"filter column", "o", func(dt *DataTable) { dt.FilterColumn = "name" }, []string{"2", "3"}},
+		{"filterable columns only", "germany", func(dt *DataTable) { dt.Columns[0].Filterable = FilterSpec{Kind: FilterText} }, []string{}},
+		{"hidden columns skipped", "france", func(dt *DataTable) { dt.HideColumn("country") }, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := filterTestTable()
+			if tt.setup != nil {
+				tt.setup(dt)
+			}
+			dt.SetFilter(tt.filter)
+
+			got := rowIDs(dt.GetFilteredRows())
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected rows %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected rows %v, got %v", tt.want, got)
+				}
+			}
+			if dt.TotalRows() != len(tt.want) {
+				t.Errorf("expected TotalRows %d, got %d", len(tt.want), dt.TotalRows())
+			}
+		})
+	}
+}
+
+func TestTextFilterWithoutColumns(t *testing.T) {
+	dt := New("test", WithRows([]Row{
+		{ID: "1", Data: map[string]any{"name": "Alice"}},
+		{ID: "2", Data: map[string]any{"name": "Bob"}},
+	}))
+	dt.SetFilter("ALI")
+
+	if got := rowIDs(dt.GetFilteredRows()); len(got) != 1 || got[0] != "1" {
+		t.Errorf("expected only row 1, got %v", got)
+	}
+}
+
+func TestColumnFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter ColumnFilter
+		want   []string
+	}{
+		{"contains", ColumnFilter{Column: "country", Value: "REICH"}, []string{"1", "3"}},
+		{"equals string", ColumnFilter{Column: "country", Operator: FilterEquals, Value: "germany"}, []string{"2"}},
+		{"equals number from string", ColumnFilter{Column: "age", Operator: FilterEquals, Value: "45"}, []string{"2"}},
+		{"equals bool", ColumnFilter{Column: "active", Operator: FilterEquals, Value: true}, []string{"1", "3"}},
+		{"range", ColumnFilter{Column: "age", Operator: FilterRange, Min: 27.5, Max: 31}, []string{"1", "3"}},
+		{"open range", ColumnFilter{Column: "age", Operator: FilterRange, Min: "30"}, []string{"1", "2"}},
+		{"in", ColumnFilter{Column: "name", Operator: FilterIn, Values: []any{"bob", "dave"}}, []string{"2", "4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := filterTestTable(WithColumnFilters(tt.filter))
+
+			got := rowIDs(dt.GetFilteredRows())
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected rows %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected rows %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestColumnFilterManagement(t *testing.T) {
+	dt := filterTestTable(WithPageSize(1))
+	dt.GoToPage(2)
+
+	dt.SetColumnFilter(ColumnFilter{Column: "country", Value: "reich"})
+	if dt.Page != 0 {
+		t.Errorf("expected page reset to 0, got %d", dt.Page)
+	}
+	if f := dt.GetColumnFilter("country"); f == nil || f.Operator != FilterContains {
+		t.Fatalf("expected contains filter on country, got %+v", f)
+	}
+
+	dt.SetColumnFilter(ColumnFilter{Column: "country", Operator: FilterEquals, Value: "germany"})
+	if len(dt.ColumnFilters) != 1 {
+		t.Errorf("expected filter to be replaced, got %d filters", len(dt.ColumnFilters))
+	}
+	if dt.TotalRows() != 1 {
+		t.Errorf("expected 1 row, got %d", dt.TotalRows())
+	}
+
+	dt.SetFilter("bob")
+	dt.SetColumnFilter(ColumnFilter{Column: "active", Operator: FilterEquals, Value: true})
+	if !dt.IsEmpty() {
+		t.Error("expected text and column filters to combine")
+	}
+
+	dt.RemoveColumnFilter("active")
+	if dt.TotalRows() != 1 {
+		t.Errorf("expected 1 row after removing filter, got %d", dt.TotalRows())
+	}
+
+	dt.ClearColumnFilters()
+	dt.ClearFilter()
+	if dt.IsFiltered() || dt.TotalRows() != 4 {
+		t.Errorf("expected all 4 rows unfiltered, got %d", dt.TotalRows())
+	}
+}
+
+func TestFilteredPagination(t *testing.T) {
+	dt := filterTestTable(WithPageSize(1), WithSelectable(true))
+	dt.SetFilter("reich")
+
+	if dt.TotalPages() != 2 {
+		t.Errorf("expected 2 pages, got %d", dt.TotalPages())
+	}
+	if got := rowIDs(dt.GetPageRows()); len(got) != 1 || got[0] != "1" {
+		t.Errorf("expected page 0 to contain row 1, got %v", got)
+	}
+	dt.NextPage()
+	if got := rowIDs(dt.GetPageRows()); len(got) != 1 || got[0] != "3" {
+		t.Errorf("expected page 1 to contain row 3, got %v", got)
+	}
+	if info := dt.PageInfo(); info != "Showing 2–2 of 2" {
+		t.Errorf("expected PageInfo 'Showing 2–2 of 2', got %q", info)
+	}
+
+	dt.SelectRow("3")
+	if rows := dt.GetPageRows(); !rows[0].Selected {
+		t.Error("expected filtered rows to reflect selection made after filtering")
+	}
+
+	dt.SetFilter("nothing")
+	if info := dt.PageInfo(); info != "No results" {
+		t.Errorf("expected 'No results', got %q", info)
+	}
+}
+
+func TestReplacedRowsResetView(t *testing.T) {
+	dt := filterTestTable(WithPageSize(2))
+	dt.Sort("name")
+	dt.SetFilter("a")
+	dt.NextPage()
+	if got := rowIDs(dt.GetPageRows()); len(got) == 0 {
+		t.Fatal("expected rows on page 1")
+	}
+
+	// Assigning Rows directly bypasses SetData.
+	dt.Rows = []Row{{ID: "9", Data: map[string]any{"name": "Zara"}}}
+	if got := rowIDs(dt.GetPageRows()); len(got) != 1 || got[0] != "9" {
+		t.Errorf("expected the replaced row, got %v", got)
+	}
+	if dt.Page != 0 || dt.TotalRows() != 1 {
+		t.Errorf("expected page 0 of 1 row, got page %d of %d rows", dt.Page, dt.TotalRows())
+	}
+}
+
+func TestFilterColumnActions(t *testing.T) {
+	dt := filterTestTable()
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		if err := actions[name](base.NewActionContext(name, dt.ID(), data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+
+	run("filter_column", map[string]string{"column": "age", "op": "range", "min": "28", "max": "50"})
+	if got := rowIDs(dt.GetFilteredRows()); len(got) != 2 || got[0] != "1" || got[1] != "2" {
+		t.Errorf("expected rows [1 2], got %v", got)
+	}
+
+	run("filter_column", map[string]string{"column": "name", "op": "in", "values": "Bob, Carol"})
+	if got := rowIDs(dt.GetFilteredRows()); len(got) != 1 || got[0] != "2" {
+		t.Errorf("expected row [2], got %v", got)
+	}
+
+	run("filter_column", map[string]string{"column": "name", "op": "in", "values": ""})
+	if dt.GetColumnFilter("name") != nil {
+		t.Error("expected empty values to remove the filter")
+	}
+
+	run("clear_column_filter", map[string]string{"column": "age"})
+	if dt.IsFiltered() {
+		t.Error("expected no active filters")
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b any
+		want int
+		ok   bool
+	}{
+		{"ints", 2, 10, -1, true},
+		{"mixed numerics", int64(3), 3.0, 0, true},
+		{"number and numeric string", 10, "9", 1, true},
+		{"string and number", "9", 10, -1, true},
+		{"times", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), 1, true},
+		{"time and date string", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "2024-01-01", 0, true},
+		{"bools", false, true, -1, true},
+		{"folded strings", "STRASSE", "strasse", 0, true},
+		{"greek sigma", "ΣΟΦΙΑ", "σοφια", 0, true},
+		{"nil", nil, 1, 0, false},
+		{"number and text", 1, "abc", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := compareValues(tt.a, tt.b)
+			if ok != tt.ok || (ok && got != tt.want) {
+				t.Errorf("compareValues(%v, %v) = (%d, %v), want (%d, %v)", tt.a, tt.b, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func sortTestTable(opts ...Option) *DataTable {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	rows := []Row{
+		{ID: "a", Data: map[string]any{"file": "file10", "size": 3, "team": "red", "at": day(3), "ok": true}},
+		{ID: "b", Data: map[string]any{"file": "File2", "size": 1.5, "team": "blue", "at": day(1), "ok": false}},
+		{ID: "c", Data: map[string]any{"file": "file1", "size": nil, "team": "red", "at": nil, "ok": true}},
+		{ID: "d", Data: map[string]any{"file": "file2", "size": int64(20), "team": "blue", "at": day(2), "ok": false}},
+	}
+	columns := []Column{
+		{ID: "file", Sortable: true},
+		{ID: "size", Sortable: true},
+		{ID: "team", Sortable: true},
+		{ID: "at", Sortable: true},
+		{ID: "ok", Sortable: true},
+	}
+	return New("files", append([]Option{WithColumns(columns), WithRows(rows)}, opts...)...)
+}
+
+func TestSortRows(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []SortKey
+		want []string
+	}{
+		{"natural strings", []SortKey{{"file", SortAsc}}, []string{"c", "b", "d", "a"}},
+		{"natural strings desc", []SortKey{{"file", SortDesc}}, []string{"a", "b", "d", "c"}},
+		{"mixed numerics with nil last", []SortKey{{"size", SortAsc}}, []string{"b", "a", "d", "c"}},
+		{"nil last when descending", []SortKey{{"size", SortDesc}}, []string{"d", "a", "b", "c"}},
+		{"times", []SortKey{{"at", SortAsc}}, []string{"b", "d", "a", "c"}},
+		{"bools are stable", []SortKey{{"ok", SortAsc}}, []string{"b", "d", "a", "c"}},
+		{"multi-column", []SortKey{{"team", SortAsc}, {"size", SortDesc}}, []string{"d", "b", "a", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := sortTestTable(WithSortKeys(tt.keys...))
+
+			got := rowIDs(dt.GetFilteredRows())
+			for i := range tt.want {
+				if i >= len(got) || got[i] != tt.want[i] {
+					t.Fatalf("expected order %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSortRowsWithComparator(t *testing.T) {
+	rank := map[any]int{"high": 0, "medium": 1, "low": 2}
+	dt := New("tasks",
+		WithColumns([]Column{{ID: "priority", Comparator: func(a, b any) int { return rank[a] - rank[b] }}}),
+		WithRows([]Row{
+			{ID: "1", Data: map[string]any{"priority": "low"}},
+			{ID: "2", Data: map[string]any{"priority": "high"}},
+			{ID: "3", Data: map[string]any{"priority": "medium"}},
+		}),
+	)
+	dt.Sort("priority")
+
+	if got := rowIDs(dt.GetPageRows()); got[0] != "2" || got[1] != "3" || got[2] != "1" {
+		t.Errorf("expected order [2 3 1], got %v", got)
+	}
+}
+
+func TestSortWithFilterAndPages(t *testing.T) {
+	dt := sortTestTable(WithPageSize(1))
+	dt.SetFilter("file")
+	dt.Sort("file")
+	dt.GoToPage(1)
+
+	if got := rowIDs(dt.GetPageRows()); len(got) != 1 || got[0] != "b" {
+		t.Errorf("expected page 1 to contain row b, got %v", got)
+	}
+	if dt.Rows[0].ID != "a" {
+		t.Error("expected sorting not to reorder the underlying Rows")
+	}
+}
+
+func TestAddSort(t *testing.T) {
+	dt := sortTestTable()
+
+	dt.Sort("team")
+	dt.AddSort("size")
+	if len(dt.SortKeys) != 2 || dt.SortPriority("size") != 2 {
+		t.Fatalf("expected size as second sort key, got %+v", dt.SortKeys)
+	}
+	if dt.SortColumn != "team" || dt.SortDirection != SortAsc {
+		t.Errorf("expected SortColumn/SortDirection to mirror primary key, got %q %v", dt.SortColumn, dt.SortDirection)
+	}
+
+	dt.AddSort("size")
+	if !dt.IsSortedDesc("size") {
+		t.Error("expected second AddSort to make size descending")
+	}
+	dt.AddSort("size")
+	if dt.IsSortedBy("size") || len(dt.SortKeys) != 1 {
+		t.Errorf("expected third AddSort to remove size, got %+v", dt.SortKeys)
+	}
+
+	dt.AddSort("file")
+	dt.Sort("file")
+	if len(dt.SortKeys) != 1 || !dt.IsSortedAsc("file") {
+		t.Errorf("expected plain Sort to replace all keys, got %+v", dt.SortKeys)
+	}
+
+	dt.ClearSort()
+	if dt.IsSorted() || dt.SortKeys != nil {
+		t.Error("expected ClearSort to remove all keys")
+	}
+}
+
+func TestSortAction(t *testing.T) {
+	dt := sortTestTable()
+	actions := dt.Actions()
+
+	for _, data := range []map[string]string{
+		{"column": "team"},
+		{"column": "file", "multi": "true"},
+	} {
+		if err := actions["sort"](base.NewActionContext("sort", dt.ID(), data)); err != nil {
+			t.Fatalf("sort returned error: %v", err)
+		}
+	}
+
+	if dt.SortPriority("team") != 1 || dt.SortPriority("file") != 2 {
+		t.Errorf("expected sort keys [team file], got %+v", dt.SortKeys)
+	}
+}
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"a", "a", 0},
+		{"a", "ab", -1},
+		{"x007", "x7", -1},
+		{"v1.10", "v1.9", 1},
+	}
+
+	for _, tt := range tests {
+		got := naturalCompare(tt.a, tt.b)
+		if (got < 0 && tt.want >= 0) || (got > 0 && tt.want <= 0) || (got == 0 && tt.want != 0) {
+			t.Errorf("naturalCompare(%q, %q) = %d, want sign %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSortTemplateFuncs(t *testing.T) {
+	funcs := Templates().Funcs
+	isSortedDesc := funcs["isSortedDesc"].(func(interface{}, string) bool)
+	sortPriority := funcs["sortPriority"].(func(interface{}, string) int)
+
+	dt := sortTestTable(WithSortKeys(SortKey{"team", SortAsc}, SortKey{"size", SortDesc}))
+	data, err := json.Marshal(dt)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	for _, v := range []interface{}{dt, m} {
+		if !isSortedDesc(v, "size") {
+			t.Errorf("%T: expected size to be sorted descending", v)
+		}
+		if p := sortPriority(v, "size"); p != 2 {
+			t.Errorf("%T: expected size priority 2, got %d", v, p)
+		}
+	}
+}
+
+func TestTemplateMultiSort(t *testing.T) {
+	dt := sortTestTable()
+	for _, r := range renderVariants(t, dt) {
+		r.excludes(t, `lvt-data-multi`)
+	}
+
+	dt.Sort("team")
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t, `lvt-click="sort_files" lvt-data-column="size" lvt-data-multi="true" aria-label="Then sort by size"`)
+		r.excludes(t, `lvt-data-column="team" lvt-data-multi`)
+	}
+
+	data := map[string]string{"column": "size", "multi": "true"}
+	if err := dt.Actions()["sort"](base.NewActionContext("sort", dt.ID(), data)); err != nil {
+		t.Fatalf("sort returned error: %v", err)
+	}
+	if keys := dt.activeSortKeys(); len(keys) != 2 || keys[1] != (SortKey{"size", SortAsc}) {
+		t.Errorf("expected size added as a secondary sort key, got %v", keys)
+	}
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t, `lvt-data-column="team" lvt-data-multi="true"`)
+	}
+}
+
+func TestMemorySource(t *testing.T) {
+	source := NewMemorySource(filterTestTable().Rows, nil)
+
+	page, err := source.Query(context.Background(), Query{
+		Search:  "reich",
+		Sort:    []SortKey{{"age", SortAsc}},
+		Offset:  1,
+		Limit:   5,
+		Filters: []ColumnFilter{{Column: "active", Operator: FilterEquals, Value: true}},
+	})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if page.Total != 2 {
+		t.Errorf("expected Total 2, got %d", page.Total)
+	}
+	if got := rowIDs(page.Rows); len(got) != 1 || got[0] != "1" {
+		t.Errorf("expected rows [1], got %v", got)
+	}
+
+	page, err = source.Query(context.Background(), Query{Offset: 10, Limit: 5})
+	if err != nil || len(page.Rows) != 0 || page.Total != 4 {
+		t.Errorf("expected empty page of 4 total rows, got %d rows, total %d, err %v", len(page.Rows), page.Total, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := source.Query(ctx, Query{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+// failingSource is a DataSource that always fails.
+type failingSource struct{}
+
+func (failingSource) Query(context.Context, Query) (Page, error) {
+	return Page{}, errors.New("connection refused")
+}
+
+func TestLoad(t *testing.T) {
+	rows := filterTestTable().Rows
+	dt := New("people", WithPageSize(3), WithDataSource(NewMemorySource(rows, nil)))
+	if !dt.HasDataSource() {
+		t.Fatal("expected HasDataSource to be true")
+	}
+
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if dt.TotalRows() != 4 || len(dt.GetPageRows()) != 3 {
+		t.Errorf("expected 3 of 4 rows, got %d of %d", len(dt.GetPageRows()), dt.TotalRows())
+	}
+
+	dt.NextPage()
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := rowIDs(dt.GetPageRows()); len(got) != 1 || got[0] != "4" {
+		t.Errorf("expected page 1 rows [4], got %v", got)
+	}
+
+	dt = New("people", WithDataSource(failingSource{}))
+	if err := dt.Load(context.Background()); err == nil || err.Error() != `datatable: query "people": connection refused` {
+		t.Errorf("expected wrapped source error, got %v", err)
+	}
+
+	if err := New("plain").Load(context.Background()); err != nil {
+		t.Errorf("expected Load without a DataSource to do nothing, got %v", err)
+	}
+}
+
+type typedAddress struct {
+	City string `datatable:"city,sortable,label=City"`
+}
+
+type typedUser struct {
+	*typedAddress
+	UserID  int       `datatable:"user_id,key,hidden"`
+	Name    string    `datatable:"name,sortable,filterable,label=Name"`
+	Joined  time.Time `datatable:"joined,sortable,format=date,align=right,width=120px"`
+	Manager *string   `datatable:"manager"`
+	Secret  string    `datatable:"-"`
+	Notes   string
+	Deleted *time.Time `datatable:"deleted"`
+}
+
+func TestNewTyped(t *testing.T) {
+	boss := "Ann"
+	users := []typedUser{
+		{typedAddress: &typedAddress{City: "Vienna"}, UserID: 7, Name: "Bob", Manager: &boss},
+		{UserID: 3, Name: "Alice"},
+	}
+	dt := NewTyped("users", users, WithPageSize(10), WithMultiSelect(true))
+
+	wantColumns := []string{"city", "user_id", "name", "joined", "manager", "deleted"}
+	if len(dt.Columns) != len(wantColumns) {
+		t.Fatalf("expected columns %v, got %+v", wantColumns, dt.Columns)
+	}
+	for i, id := range wantColumns {
+		if dt.Columns[i].ID != id {
+			t.Errorf("expected column %d to be %q, got %q", i, id, dt.Columns[i].ID)
+		}
+	}
+
+	joined := dt.GetColumn("joined")
+	if !joined.Sortable || joined.Format != "date" || joined.Align != "right" || joined.Width != "120px" || joined.Label != "Joined" {
+		t.Errorf("unexpected joined column %+v", joined)
+	}
+	if col := dt.GetColumn("user_id"); !col.Hidden {
+		t.Error("expected user_id column to be hidden")
+	}
+	if col := dt.GetColumn("name"); col.Label != "Name" || col.Filterable.Kind != FilterText {
+		t.Errorf("unexpected name column %+v", col)
+	}
+
+	if dt.Rows[0].ID != "7" || dt.Rows[1].ID != "3" {
+		t.Errorf("expected row IDs from key field, got %q and %q", dt.Rows[0].ID, dt.Rows[1].ID)
+	}
+	if v := dt.Rows[0].GetCellValue("manager"); v != "Ann" {
+		t.Errorf("expected dereferenced manager 'Ann', got %v", v)
+	}
+	if v := dt.Rows[1].GetCellValue("city"); v != nil {
+		t.Errorf("expected nil city for nil embedded struct, got %v", v)
+	}
+	if v := dt.Rows[0].GetCellValue("deleted"); v != nil {
+		t.Errorf("expected nil for nil pointer, got %v", v)
+	}
+
+	dt.Sort("name")
+	if items := dt.GetPageItems(); items[0].Name != "Alice" {
+		t.Errorf("expected sorted page items to start with Alice, got %v", items[0].Name)
+	}
+
+	dt.SelectRow("3")
+	selected := dt.GetSelectedRows()
+	if len(selected) != 1 || selected[0].Name != "Alice" {
+		t.Errorf("expected typed selection [Alice], got %+v", selected)
+	}
+
+	if item, ok := dt.Item("7"); !ok || item.Name != "Bob" {
+		t.Errorf("expected Item(7) to be Bob, got %+v, %v", item, ok)
+	}
+	if _, ok := dt.Item("missing"); ok {
+		t.Error("expected Item for unknown ID to report false")
+	}
+}
+
+func TestNewTypedWithoutTags(t *testing.T) {
+	type point struct {
+		ID    string
+		X, Y  int
+		label string
+	}
+	dt := NewTyped("points", []*point{{ID: "a", X: 1, Y: 2}, nil})
+
+	if len(dt.Columns) != 3 || dt.Columns[1].ID != "X" || dt.Columns[1].Label != "X" {
+		t.Fatalf("expected exported fields as columns, got %+v", dt.Columns)
+	}
+	if dt.Rows[0].ID != "a" || dt.Rows[0].GetCellValue("Y") != 2 {
+		t.Errorf("unexpected first row %+v", dt.Rows[0])
+	}
+	if dt.Rows[1].ID != "1" {
+		t.Errorf("expected index fallback ID for nil item, got %q", dt.Rows[1].ID)
+	}
+}
+
+func TestTypedAccessorsAndKeyFunc(t *testing.T) {
+	type person struct {
+		First string `datatable:"first"`
+		Last  string `datatable:"last"`
+	}
+	dt := NewTyped("people", []person{{"Ada", "Lovelace"}, {"Alan", "Turing"}})
+
+	dt.SetKeyFunc(func(p person) string { return p.Last })
+	dt.SetAccessor("full", func(p person) any { return p.First + " " + p.Last })
+
+	if dt.Rows[1].ID != "Turing" {
+		t.Errorf("expected key func ID 'Turing', got %q", dt.Rows[1].ID)
+	}
+	if v := dt.Rows[0].GetCellValue("full"); v != "Ada Lovelace" {
+		t.Errorf("expected accessor value 'Ada Lovelace', got %v", v)
+	}
+
+	dt.SetItems([]person{{"Grace", "Hopper"}})
+	if len(dt.Rows) != 1 || dt.Rows[0].ID != "Hopper" || len(dt.Items()) != 1 {
+		t.Errorf("expected rows rebuilt from new items, got %+v", dt.Rows)
+	}
+}
+
+func TestTypedTemplateFuncs(t *testing.T) {
+	type item struct {
+		ID string `datatable:"id"`
+	}
+	dt := NewTyped("items", []item{{"x"}})
+
+	dtID := Templates().Funcs["dtID"].(func(interface{}) string)
+	if got := dtID(dt); got != "items" {
+		t.Errorf("expected template funcs to accept *Typed, got ID %q", got)
+	}
+
+	var provider base.ActionProvider = dt
+	if _, ok := provider.Actions()["sort"]; !ok {
+		t.Error("expected *Typed to provide DataTable actions")
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	day := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		format string
+		value  any
+		want   string
+	}{
+		{"", 42, "42"},
+		{"unknown", "raw", "raw"},
+		{"date", nil, ""},
+		{"date", day, "2024-03-05"},
+		{"date", "2024-03-05T14:30:00Z", "2024-03-05"},
+		{"date:Jan 2, 2006", day, "Mar 5, 2024"},
+		{"datetime", day, "2024-03-05 14:30"},
+		{"date", "not a date", "not a date"},
+		{"currency", 1234.5, "$1,234.50"},
+		{"currency", -1234.5, "-$1,234.50"},
+		{"currency:EUR", 1000000, "€1,000,000.00"},
+		{"currency:JPY", 1500.4, "¥1,500"},
+		{"currency:CHF:0", "99.6", "CHF 100"},
+		{"number", 1234567, "1,234,567"},
+		{"number", 1234.567, "1,234.57"},
+		{"number:1", float64(-0.04), "0.0"},
+		{"number:3", 12, "12.000"},
+		{"percent", 0.256, "26%"},
+		{"percent:1", 0.256, "25.6%"},
+		{"bool", true, "✓"},
+		{"bool", "false", "✗"},
+		{"bool:Yes/No", false, "No"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := FormatValue(tt.format, tt.value); got != tt.want {
+				t.Errorf("FormatValue(%q, %v) = %q, want %q", tt.format, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRelative(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-3 * time.Hour), "3 hours ago"},
+		{now.Add(49 * time.Hour), "in 2 days"},
+		{now.AddDate(0, -2, 0).Format(time.RFC3339), "2 months ago"},
+		{now.AddDate(-3, 0, 0), "3 years ago"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatValue("relative", tt.value); got != tt.want {
+			t.Errorf("FormatValue(relative, %v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	RegisterFormatter("stars", func(v any, arg string) string {
+		n, _ := toNumber(v)
+		return strings.Repeat(arg, int(n))
+	})
+
+	col := Column{ID: "rating", Format: "stars:*"}
+	if got := col.FormatValue(3); got != "***" {
+		t.Errorf("expected custom formatter output '***', got %q", got)
+	}
+
+	dt := New("test", WithColumns([]Column{col}))
+	if got := dt.FormatCell(Row{Data: map[string]any{"rating": 2}}, "rating"); got != "**" {
+		t.Errorf("expected FormatCell output '**', got %q", got)
+	}
+}
+
+func TestTemplateFormatsCells(t *testing.T) {
+	dt := New("orders",
+		WithColumns([]Column{
+			{ID: "placed", Label: "Placed", Format: "date"},
+			{ID: "total", Label: "Total", Format: "currency:EUR"},
+			{ID: "paid", Label: "Paid", Format: "bool:Paid/Open"},
+		}),
+		WithRows([]Row{
+			{ID: "1", Data: map[string]any{"placed": time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC), "total": 1234.5, "paid": true}},
+		}),
+	)
+
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t, "2024-03-05", "€1,234.50", "Paid")
+	}
+}
+
+func exportTestTable(opts ...Option) *DataTable {
+	rows := []Row{
+		{ID: "1", Data: map[string]any{"name": "Alice", "email": "alice@example.com", "balance": 1234.5, "note": "a, \"b\""}},
+		{ID: "2", Data: map[string]any{"name": "Bob", "email": "bob@example.com", "balance": 20.0, "note": "tab\there"}},
+		{ID: "3", Data: map[string]any{"name": "Carol", "email": "carol@example.com", "balance": nil, "note": ""}},
+	}
+	columns := []Column{
+		{ID: "name", Label: "Name", Sortable: true},
+		{ID: "email", Label: "Email", Hidden: true},
+		{ID: "balance", Label: "Balance", Format: "currency"},
+		{ID: "note"},
+	}
+	return New("users", append([]Option{WithColumns(columns), WithRows(rows)}, opts...)...)
+}
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ExportFormat
+		want   string
+	}{
+		{
+			name:   "csv",
+			format: ExportCSV,
+			want:   "Name,Balance,note\nCarol,,\nBob,$20.00,tab\there\nAlice,\"$1,234.50\",\"a, \"\"b\"\"\"\n",
+		},
+		{
+			name:   "tsv",
+			format: ExportTSV,
+			want:   "Name\tBalance\tnote\nCarol\t\t\nBob\t$20.00\t\"tab\there\"\nAlice\t$1,234.50\t\"a, \"\"b\"\"\"\n",
+		},
+		{
+			name:   "json",
+			format: ExportJSON,
+			want: "[\n  {\"Name\":\"Carol\",\"Balance\":\"\",\"note\":\"\"},\n" +
+				"  {\"Name\":\"Bob\",\"Balance\":\"$20.00\",\"note\":\"tab\\there\"},\n" +
+				"  {\"Name\":\"Alice\",\"Balance\":\"$1,234.50\",\"note\":\"a, \\\"b\\\"\"}\n]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := exportTestTable(WithSort("name", SortDesc))
+			var buf bytes.Buffer
+			if err := dt.Export(&buf, tt.format, ExportFiltered); err != nil {
+				t.Fatalf("Export returned error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := New("empty").Export(&buf, ExportJSON, ExportPage); err != nil || buf.String() != "[]\n" {
+		t.Errorf("expected empty JSON array, got %q (err %v)", buf.String(), err)
+	}
+	if err := exportTestTable().Export(&buf, "xml", ExportPage); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+	if err := exportTestTable().Export(&buf, ExportCSV, "everything"); err == nil {
+		t.Error("expected error for unsupported scope")
+	}
+}
+
+func TestExportScopes(t *testing.T) {
+	exportNames := func(dt *DataTable, scope ExportScope) string {
+		var buf bytes.Buffer
+		if err := dt.Export(&buf, ExportCSV, scope); err != nil {
+			t.Fatalf("Export(%s) returned error: %v", scope, err)
+		}
+		var names []string
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n")[1:] {
+			name, _, _ := strings.Cut(line, ",")
+			names = append(names, name)
+		}
+		return strings.Join(names, " ")
+	}
+
+	dt := filterTestTable(WithPageSize(2), WithSort("name", SortAsc), WithSelectable(true), WithMultiSelect(true))
+	dt.SetFilter("e")
+	dt.SelectRow("1")
+	dt.SelectRow("4")
+
+	if got := exportNames(dt, ExportPage); got != "Bob Carol" {
+		t.Errorf("page: expected Bob Carol, got %q", got)
+	}
+	if got := exportNames(dt, ExportFiltered); got != "Bob Carol Dave Ängström" {
+		t.Errorf("filtered: expected Bob Carol Dave Ängström, got %q", got)
+	}
+
+	dt.SetFilter("bob")
+	// Selected rows are exported even when filtered out.
+	if got := exportNames(dt, ExportSelected); got != "Dave Ängström" {
+		t.Errorf("selected: expected Dave Ängström, got %q", got)
+	}
+}
+
+// countingSource counts the queries made to a DataSource.
+type countingSource struct {
+	DataSource
+	queries int
+}
+
+func (s *countingSource) Query(ctx context.Context, q Query) (Page, error) {
+	s.queries++
+	return s.DataSource.Query(ctx, q)
+}
+
+func TestExportFromDataSource(t *testing.T) {
+	rows := make([]Row, 1234)
+	for i := range rows {
+		rows[i] = Row{ID: fmt.Sprint(i), Data: map[string]any{"n": i}}
+	}
+	source := &countingSource{DataSource: NewMemorySource(rows, nil)}
+	dt := New("numbers",
+		WithColumns([]Column{{ID: "n", Label: "N"}}),
+		WithPageSize(10),
+		WithSort("n", SortDesc),
+		WithDataSource(source),
+	)
+	dt.SetColumnFilter(ColumnFilter{Column: "n", Operator: FilterRange, Min: 100})
+
+	var buf bytes.Buffer
+	if err := dt.ExportContext(context.Background(), &buf, ExportCSV, ExportFiltered); err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1135 || lines[1] != "1233" || lines[1134] != "100" {
+		t.Errorf("expected header and 1134 rows from 1233 to 100, got %d lines (%s ... %s)", len(lines), lines[1], lines[len(lines)-1])
+	}
+	if source.queries != 3 {
+		t.Errorf("expected 3 batched queries, got %d", source.queries)
+	}
+
+	dt.SelectedIDs = map[string]bool{"5": true, "1000": true}
+	buf.Reset()
+	if err := dt.Export(&buf, ExportCSV, ExportSelected); err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	if buf.String() != "N\n1000\n5\n" {
+		t.Errorf("expected selected rows 1000 and 5, got %q", buf.String())
+	}
+
+	dt = New("numbers", WithDataSource(failingSource{}))
+	if err := dt.Export(&buf, ExportCSV, ExportFiltered); err == nil {
+		t.Error("expected source error")
+	}
+}
+
+func TestExportAction(t *testing.T) {
+	export := func(dt *DataTable, data map[string]string) error {
+		return dt.Actions()["export"](base.NewActionContext("export", dt.ID(), data))
+	}
+
+	dt := exportTestTable()
+	if err := export(dt, map[string]string{"format": "csv"}); !errors.Is(err, errNoExportHandler) {
+		t.Errorf("expected errNoExportHandler, got %v", err)
+	}
+
+	var got ExportRequest
+	dt = exportTestTable(WithOnExport(func(ctx context.Context, req ExportRequest) error {
+		got = req
+		return nil
+	}))
+
+	if err := export(dt, map[string]string{"format": "json", "scope": "page"}); err != nil {
+		t.Fatalf("export action returned error: %v", err)
+	}
+	want := ExportRequest{Format: ExportJSON, Scope: ExportPage, Filename: "users.json"}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if err := export(dt, nil); err != nil {
+		t.Fatalf("export action returned error: %v", err)
+	}
+	if got.Format != ExportCSV || got.Scope != ExportFiltered || got.Filename != "users.csv" {
+		t.Errorf("expected default csv/filtered request, got %+v", got)
+	}
+
+	if err := export(dt, map[string]string{"format": "pdf"}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestTemplateExportButtons(t *testing.T) {
+	dt := exportTestTable(WithExportFormats(ExportCSV, ExportJSON))
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t, `lvt-click="export_users"`, `lvt-data-format="json"`, "Export CSV")
+	}
+}
+
+func editTestTable(opts ...Option) *DataTable {
+	rows := []Row{
+		{ID: "1", Data: map[string]any{"name": "Alice", "age": 31, "role": "admin", "joined": time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), "active": true, "id": "1"}},
+		{ID: "2", Data: map[string]any{"name": "Bob", "age": 45, "role": "member", "active": false, "id": "2"}},
+	}
+	columns := []Column{
+		{ID: "id", Label: "ID"},
+		{ID: "name", Label: "Name", Editable: true, Validator: func(v any) error {
+			if strings.TrimSpace(v.(string)) == "" {
+				return errors.New("name is required")
+			}
+			return nil
+		}},
+		{ID: "age", Label: "Age", Editable: true, Editor: EditorNumber},
+		{ID: "role", Label: "Role", Editable: true, Editor: EditorSelect, EditOptions: []EditOption{{Value: "admin", Label: "Admin"}, {Value: "member"}}},
+		{ID: "joined", Label: "Joined", Editable: true, Editor: EditorDate, Format: "date"},
+		{ID: "active", Label: "Active", Editable: true, Editor: EditorToggle},
+	}
+	return New("people", append([]Option{WithColumns(columns), WithRows(rows)}, opts...)...)
+}
+
+func TestCellEdit(t *testing.T) {
+	dt := editTestTable()
+
+	if err := dt.BeginEdit("1", "id"); err == nil {
+		t.Error("expected error editing a read-only column")
+	}
+	if err := dt.BeginEdit("9", "name"); err == nil {
+		t.Error("expected error editing an unknown row")
+	}
+	if err := dt.CommitEdit("x"); !errors.Is(err, errNoEdit) {
+		t.Errorf("expected errNoEdit, got %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		row     string
+		column  string
+		start   string
+		input   string
+		want    any
+		wantErr string
+	}{
+		{name: "text", row: "1", column: "name", start: "Alice", input: "Alicia", want: "Alicia"},
+		{name: "validator", row: "1", column: "name", start: "Alice", input: "  ", wantErr: "name is required"},
+		{name: "int stays int", row: "1", column: "age", start: "31", input: " 32 ", want: 32},
+		{name: "not a number", row: "1", column: "age", start: "31", input: "old", wantErr: "must be a number"},
+		{name: "fraction of int", row: "1", column: "age", start: "31", input: "31.5", wantErr: "must be a whole number"},
+		{name: "cleared number", row: "1", column: "age", start: "31", input: "", want: nil},
+		{name: "select", row: "2", column: "role", start: "member", input: "admin", want: "admin"},
+		{name: "unknown option", row: "2", column: "role", start: "member", input: "owner", wantErr: "is not a valid option"},
+		{name: "date", row: "1", column: "joined", start: "2024-03-05", input: "2025-01-31", want: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "bad date", row: "2", column: "joined", start: "", input: "31/01/2025", wantErr: "must be a date (YYYY-MM-DD)"},
+		{name: "toggle", row: "2", column: "active", start: "false", input: "true", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := editTestTable()
+			if err := dt.BeginEdit(tt.row, tt.column); err != nil {
+				t.Fatalf("BeginEdit returned error: %v", err)
+			}
+			if !dt.IsEditingCell(tt.row, tt.column) || dt.Editing.Value != tt.start {
+				t.Fatalf("expected editor text %q, got %+v", tt.start, dt.Editing)
+			}
+
+			old := dt.rowByID(tt.row).GetCellValue(tt.column)
+			err := dt.CommitEdit(tt.input)
+			got := dt.rowByID(tt.row).GetCellValue(tt.column)
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				if !dt.IsEditing() || dt.Editing.Error != tt.wantErr || dt.Editing.Value != tt.input {
+					t.Errorf("expected edit to stay open with the error, got %+v", dt.Editing)
+				}
+				if got != old {
+					t.Errorf("expected cell to keep %v, got %v", old, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CommitEdit returned error: %v", err)
+			}
+			if dt.IsEditing() {
+				t.Error("expected edit to be closed")
+			}
+			if got != tt.want {
+				t.Errorf("expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+
+	dt.BeginEdit("1", "name")
+	dt.CancelEdit()
+	if dt.IsEditing() || dt.Rows[0].GetCellValue("name") != "Alice" {
+		t.Error("expected CancelEdit to discard the edit")
+	}
+}
+
+func TestCellEditHook(t *testing.T) {
+	var calls []string
+	dt := editTestTable(WithSort("name", SortAsc), WithOnCellEdit(func(rowID, columnID string, oldValue, newValue any) error {
+		calls = append(calls, fmt.Sprintf("%s/%s: %v -> %v", rowID, columnID, oldValue, newValue))
+		if newValue == "Zed" {
+			return errors.New("name is taken")
+		}
+		return nil
+	}))
+
+	dt.BeginEdit("1", "name")
+	if err := dt.CommitEdit("Zed"); err == nil || dt.Editing.Error != "name is taken" {
+		t.Errorf("expected hook to reject the edit, got %v (%+v)", err, dt.Editing)
+	}
+	if err := dt.CommitEdit("Carl"); err != nil {
+		t.Fatalf("CommitEdit returned error: %v", err)
+	}
+	if got := rowIDs(dt.GetPageRows()); got[0] != "2" || got[1] != "1" {
+		t.Errorf("expected rows to be re-sorted after the edit, got %v", got)
+	}
+
+	dt.BeginEdit("2", "name")
+	dt.CommitEdit("")
+	want := []string{"1/name: Alice -> Zed", "1/name: Alice -> Carl"}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("expected hook calls %v, got %v", want, calls)
+	}
+}
+
+func TestCellEditActions(t *testing.T) {
+	dt := editTestTable()
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) error {
+		return actions[name](base.NewActionContext(name, dt.ID(), data))
+	}
+
+	if err := run("begin_edit", map[string]string{"row": "2", "column": "age"}); err != nil {
+		t.Fatalf("begin_edit returned error: %v", err)
+	}
+	if err := run("commit_edit", map[string]string{"value": "abc"}); err != nil {
+		t.Errorf("expected rejected value to be shown inline, got error %v", err)
+	}
+	if dt.Editing == nil || dt.Editing.Error != "must be a number" {
+		t.Errorf("expected inline error, got %+v", dt.Editing)
+	}
+	if err := run("commit_edit", map[string]string{"value": "46"}); err != nil || dt.Rows[1].GetCellValue("age") != 46 {
+		t.Errorf("expected age 46, got %v (err %v)", dt.Rows[1].GetCellValue("age"), err)
+	}
+
+	if err := run("commit_edit", map[string]string{"row": "1", "column": "active", "value": "false"}); err != nil {
+		t.Fatalf("commit_edit returned error: %v", err)
+	}
+	if dt.Rows[0].GetCellValue("active") != false || dt.IsEditing() {
+		t.Errorf("expected toggle to commit in one step, got %v", dt.Rows[0].GetCellValue("active"))
+	}
+
+	run("begin_edit", map[string]string{"row": "1", "column": "name"})
+	run("cancel_edit", nil)
+	if dt.IsEditing() {
+		t.Error("expected cancel_edit to close the editor")
+	}
+
+	if err := run("commit_edit", map[string]string{"value": "x"}); err == nil {
+		t.Error("expected error committing without an edit")
+	}
+	if err := run("begin_edit", map[string]string{"row": "1", "column": "id"}); err == nil {
+		t.Error("expected error editing a read-only column")
+	}
+}
+
+func TestTemplateCellEditing(t *testing.T) {
+	dt := editTestTable()
+	dt.BeginEdit("2", "age")
+	dt.CommitEdit("abc")
+
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t,
+			`type="number"`,
+			`value="abc"`,
+			`lvt-change="commit_edit_`,
+			`lvt-click="cancel_edit_`,
+			"must be a number",
+			`lvt-click="begin_edit_`,
+			`lvt-data-row="1" lvt-data-column="name"`,
+			`lvt-data-column="active"`,
+			`lvt-data-value="false"`,
+		)
+	}
+
+	dt.BeginEdit("1", "role")
+	if html := renderTemplate(t, dt); !strings.Contains(html, `<option value="admin" selected>Admin</option>`) || !strings.Contains(html, `>member</option>`) {
+		t.Errorf("expected select editor with options, got %s", html)
+	}
+}
+
+func TestTypedEditableTags(t *testing.T) {
+	type member struct {
+		ID   int    `datatable:"id,key"`
+		Role string `datatable:"role,editable,editor=select,options=admin|member"`
+		Bio  string `datatable:"bio,editable"`
+	}
+	dt := NewTyped("members", []member{{ID: 1, Role: "admin"}})
+
+	role := dt.GetColumn("role")
+	if role.EditorKind() != EditorSelect || len(role.EditOptions) != 2 || role.EditOptions[1].Value != "member" {
+		t.Errorf("unexpected role column %+v", role)
+	}
+	if kind := dt.GetColumn("bio").EditorKind(); kind != EditorText {
+		t.Errorf("expected default text editor, got %q", kind)
+	}
+	if kind := dt.GetColumn("id").EditorKind(); kind != "" {
+		t.Errorf("expected no editor for read-only column, got %q", kind)
+	}
+}
+
+func TestTypedItemsFollowRowsAndEdits(t *testing.T) {
+	type member struct {
+		ID    int     `datatable:"id,key"`
+		Name  string  `datatable:"name,editable"`
+		Score *int    `datatable:"score,editable,editor=number"`
+		Level float32 `datatable:"level,editable,editor=number"`
+	}
+	members := []*member{{ID: 1, Name: "Ann"}, {ID: 2, Name: "Bob"}}
+	dt := NewTyped("members", members, WithMultiSelect(true))
+
+	edit := func(row, column, value string) {
+		t.Helper()
+		if err := dt.BeginEdit(row, column); err != nil {
+			t.Fatalf("BeginEdit returned error: %v", err)
+		}
+		if err := dt.CommitEdit(value); err != nil {
+			t.Fatalf("CommitEdit returned error: %v", err)
+		}
+	}
+	edit("2", "name", "Robert")
+	edit("2", "score", "42")
+	edit("1", "level", "2.5")
+	if members[1].Name != "Robert" || members[1].Score == nil || *members[1].Score != 42 || members[0].Level != 2.5 {
+		t.Errorf("expected edits written back to the items, got %+v %+v", *members[0], *members[1])
+	}
+	edit("2", "score", "")
+	if members[1].Score != nil {
+		t.Errorf("expected a cleared cell to clear the field, got %v", *members[1].Score)
+	}
+
+	dt.SelectRow("2")
+	if got := dt.GetSelectedRows(); len(got) != 1 || got[0].Name != "Robert" {
+		t.Errorf("expected the edited item to be selected, got %+v", got)
+	}
+
+	// Items are found by row ID, not by position in Rows.
+	dt.Rows = []Row{dt.Rows[1], dt.Rows[0]}
+	if item, ok := dt.Item("1"); !ok || item.Name != "Ann" {
+		t.Errorf("expected Item(1) to be Ann after reordering Rows, got %+v", item)
+	}
+	if got := dt.GetPageItems(); len(got) != 2 || got[0].Name != "Robert" {
+		t.Errorf("expected page items in row order, got %+v", got)
+	}
+}
+
+func treeTestTable(opts ...Option) *DataTable {
+	rows := []Row{
+		{ID: "eng", Data: map[string]any{"name": "Engineering"}, Children: []Row{
+			{ID: "web", Data: map[string]any{"name": "Web"}, Children: []Row{
+				{ID: "ann", Data: map[string]any{"name": "Ann"}},
+				{ID: "zoe", Data: map[string]any{"name": "Zoe"}},
+			}},
+			{ID: "api", Data: map[string]any{"name": "API"}, Children: []Row{
+				{ID: "bob", Data: map[string]any{"name": "Bob"}},
+			}},
+		}},
+		{ID: "ops", Data: map[string]any{"name": "Operations"}, Children: []Row{
+			{ID: "cat", Data: map[string]any{"name": "Cat"}},
+		}},
+		{ID: "hr", Data: map[string]any{"name": "HR"}},
+	}
+	columns := []Column{{ID: "name", Label: "Name", Sortable: true}}
+	return New("org", append([]Option{WithColumns(columns), WithRows(rows)}, opts...)...)
+}
+
+func TestTreeRows(t *testing.T) {
+	dt := treeTestTable()
+	if got := rowIDs(dt.GetPageRows()); fmt.Sprint(got) != "[eng ops hr]" {
+		t.Errorf("expected collapsed top level, got %v", got)
+	}
+
+	dt.ExpandRow("eng")
+	dt.ExpandRow("web")
+	rows := dt.GetPageRows()
+	if got := rowIDs(rows); fmt.Sprint(got) != "[eng web ann zoe api ops hr]" {
+		t.Errorf("expected expanded tree, got %v", got)
+	}
+	if rows[0].Depth != 0 || rows[1].Depth != 1 || rows[2].Depth != 2 || !rows[0].Expanded || rows[4].Expanded {
+		t.Errorf("unexpected depth or expansion: %+v", rows[:5])
+	}
+	if dt.TotalRows() != 7 {
+		t.Errorf("expected 7 visible rows, got %d", dt.TotalRows())
+	}
+
+	dt.Sort("name")
+	if got := rowIDs(dt.GetPageRows()); fmt.Sprint(got) != "[eng api web ann zoe hr ops]" {
+		t.Errorf("expected siblings sorted within each level, got %v", got)
+	}
+
+	dt.PageSize = 3
+	dt.NextPage()
+	if got := rowIDs(dt.GetPageRows()); fmt.Sprint(got) != "[ann zoe hr]" {
+		t.Errorf("expected second page of visible rows, got %v", got)
+	}
+
+	dt.ToggleRowExpansion("eng")
+	if dt.IsRowExpanded("eng") || dt.TotalRows() != 3 {
+		t.Errorf("expected eng collapsed with 3 visible rows, got %d", dt.TotalRows())
+	}
+
+	dt.CollapseAll()
+	dt.ExpandAll()
+	if dt.TotalRows() != 9 {
+		t.Errorf("expected every row visible after ExpandAll, got %d", dt.TotalRows())
+	}
+}
+
+func TestTreeRowsFiltering(t *testing.T) {
+	dt := treeTestTable()
+
+	dt.SetFilter("zoe")
+	rows := dt.GetFilteredRows()
+	if got := rowIDs(rows); fmt.Sprint(got) != "[eng web zoe]" {
+		t.Errorf("expected match with its ancestors, got %v", got)
+	}
+	if !rows[0].Expanded || !rows[1].Expanded {
+		t.Error("expected ancestors of matches to be expanded")
+	}
+
+	dt.ToggleRowExpansion("web")
+	if got := rowIDs(dt.GetFilteredRows()); fmt.Sprint(got) != "[eng web]" {
+		t.Errorf("expected collapsed ancestor to hide the match, got %v", got)
+	}
+
+	dt.SetFilter("api")
+	if got := rowIDs(dt.GetFilteredRows()); fmt.Sprint(got) != "[eng api]" {
+		t.Errorf("expected matching row to stay collapsed, got %v", got)
+	}
+	dt.ExpandRow("api")
+	if got := rowIDs(dt.GetFilteredRows()); fmt.Sprint(got) != "[eng api bob]" {
+		t.Errorf("expected descendants of a match to be kept, got %v", got)
+	}
+
+	dt.SetFilter("nobody")
+	if !dt.IsEmpty() {
+		t.Error("expected no visible rows")
+	}
+}
+
+func TestTreeRowsSelectionAndEditing(t *testing.T) {
+	dt := treeTestTable(WithMultiSelect(true))
+	dt.GetColumn("name").Editable = true
+	dt.ExpandRow("eng")
+	toggle := func() {
+		t.Helper()
+		if err := dt.Actions()["toggle_all"](base.NewActionContext("toggle_all", dt.ID(), nil)); err != nil {
+			t.Fatalf("toggle_all returned error: %v", err)
+		}
+	}
+
+	toggle()
+	if !dt.AllSelected() || dt.SelectedCount() != 5 {
+		t.Errorf("expected the visible rows selected, got %d (all=%v)", dt.SelectedCount(), dt.AllSelected())
+	}
+	toggle()
+	if dt.AllSelected() || dt.HasSelection() {
+		t.Errorf("expected second toggle_all to deselect the visible rows, got %d", dt.SelectedCount())
+	}
+
+	dt.SelectAll()
+	if got := rowIDs(dt.GetSelectedRows()); len(got) != 9 || !dt.IsRowSelected("ann") {
+		t.Errorf("expected SelectAll to select nested rows, got %v", got)
+	}
+	dt.DeselectRow("web")
+	if dt.IsRowSelected("web") || dt.rowByID("web").Selected {
+		t.Error("expected the nested row to be deselected")
+	}
+
+	if err := dt.BeginEdit("bob", "name"); err != nil {
+		t.Fatalf("BeginEdit returned error: %v", err)
+	}
+	if err := dt.CommitEdit("Robert"); err != nil {
+		t.Fatalf("CommitEdit returned error: %v", err)
+	}
+	if got := dt.Rows[0].Children[1].Children[0].GetCellValue("name"); got != "Robert" {
+		t.Errorf("expected the nested cell to be edited, got %v", got)
+	}
+}
+
+func TestTreeRowsSelectionExportAndCount(t *testing.T) {
+	dt := treeTestTable(WithMultiSelect(true))
+	export := func() string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := dt.Export(&buf, ExportCSV, ExportSelected); err != nil {
+			t.Fatalf("Export returned error: %v", err)
+		}
+		return buf.String()
+	}
+
+	dt.SelectRow("zoe")
+	dt.SelectRow("ann")
+	if got := export(); got != "Name\nAnn\nZoe\n" {
+		t.Errorf("expected the selected nested rows exported, got %q", got)
+	}
+
+	dt.rowByID("bob").Disabled = true
+	dt.SelectAllMatching()
+	if dt.MatchingCount() != 9 || dt.SelectedCount() != 8 {
+		t.Errorf("expected 8 of 9 rows selected, got %d of %d", dt.SelectedCount(), dt.MatchingCount())
+	}
+	dt.DeselectRow("cat")
+	if dt.SelectedCount() != 7 {
+		t.Errorf("expected 7 rows after deselecting a nested row, got %d", dt.SelectedCount())
+	}
+	if got := export(); got != "Name\nEngineering\nWeb\nAnn\nZoe\nAPI\nOperations\nHR\n" {
+		t.Errorf("expected the matching nested rows exported, got %q", got)
+	}
+
+	dt.SetFilter("o")
+	dt.SelectAllMatching()
+	if dt.MatchingCount() != 3 || dt.SelectedCount() != 2 {
+		t.Errorf("expected 2 of 3 filtered rows selected, got %d of %d", dt.SelectedCount(), dt.MatchingCount())
+	}
+	if got := export(); got != "Name\nZoe\nOperations\n" {
+		t.Errorf("expected the filtered nested rows exported, got %q", got)
+	}
+}
+
+func TestExpandAction(t *testing.T) {
+	dt := treeTestTable()
+	toggle := dt.Actions()["toggle_expand"]
+	if err := toggle(base.NewActionContext("toggle_expand", dt.ID(), map[string]string{"row": "ops"})); err != nil {
+		t.Fatalf("toggle_expand returned error: %v", err)
+	}
+	if got := rowIDs(dt.GetPageRows()); fmt.Sprint(got) != "[eng ops cat hr]" {
+		t.Errorf("expected ops expanded, got %v", got)
+	}
+	toggle(base.NewActionContext("toggle_expand", dt.ID(), map[string]string{"row": "ops"}))
+	if dt.IsRowExpanded("ops") {
+		t.Error("expected ops collapsed")
+	}
+
+	dt = New("flat", WithRows([]Row{{ID: "1", Expanded: true}, {ID: "2"}}))
+	if !dt.IsRowExpanded("1") || dt.IsRowExpanded("2") {
+		t.Error("expected Row.Expanded to set the initial state")
+	}
+	dt.SetData([]Row{{ID: "1"}, {ID: "2"}})
+	dt.ExpandRow("2")
+	dt.SetData([]Row{{ID: "1"}, {ID: "2"}})
+	if rows := dt.GetPageRows(); rows[0].Expanded || !rows[1].Expanded {
+		t.Errorf("expected expansion to survive SetData, got %+v", rows)
+	}
+}
+
+func TestDetailTemplate(t *testing.T) {
+	detail := template.Must(template.New("").Parse(`{{define "user-detail"}}<p class="bio">{{index .Data "bio"}}</p>{{end}}`))
+	dt := New("users",
+		WithColumns([]Column{{ID: "name", Label: "Name"}}),
+		WithRows([]Row{
+			{ID: "1", Data: map[string]any{"name": "Alice", "bio": "Likes <b>Go</b>"}},
+			{ID: "2", Data: map[string]any{"name": "Bob", "bio": "Hidden"}},
+		}),
+		WithDetailTemplate(detail, "user-detail"),
+	)
+
+	if !dt.IsRowExpandable(dt.Rows[1]) {
+		t.Error("expected rows to be expandable with a detail template")
+	}
+	dt.ExpandRow("1")
+	html, err := dt.RenderDetail(dt.Rows[0])
+	if err != nil || html != `<p class="bio">Likes &lt;b&gt;Go&lt;/b&gt;</p>` {
+		t.Errorf("unexpected detail %q (err %v)", html, err)
+	}
+
+	for _, r := range renderVariants(t, dt) {
+		if !strings.Contains(r.html, `<p class="bio">Likes &lt;b&gt;Go&lt;/b&gt;</p>`) || strings.Contains(r.html, "Hidden") {
+			t.Errorf("%s: expected only the expanded row's detail, got %s", r.name, r.html)
+		}
+		r.contains(t, `aria-expanded="true"`, `aria-expanded="false"`)
+	}
+
+	broken := template.Must(template.New("").Parse(`{{define "broken"}}{{.Missing}}{{end}}`))
+	dt = New("users", WithRows([]Row{{ID: "1", Expanded: true}}), WithDetailTemplate(broken, "broken"))
+	if _, err := json.Marshal(dt); err == nil {
+		t.Error("expected detail render error from MarshalJSON")
+	}
+}
+
+func TestTemplateTreeRows(t *testing.T) {
+	dt := treeTestTable()
+	dt.ExpandRow("eng")
+	dt.ExpandRow("web")
+
+	indent := map[bool]string{
+		true:  `style="padding-left: calc(1rem + 3rem)"`,
+		false: `style="padding-left: 1.5rem"`,
+	}
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t, indent[r.styled], `lvt-click="toggle_expand_org"`)
+		if n := strings.Count(r.html, `lvt-data-row="hr"`); n != 0 {
+			t.Errorf("%s: expected no expander for a leaf row, got %d", r.name, n)
+		}
+	}
+}
+
+func groupTestTable(opts ...Option) *DataTable {
+	rows := []Row{
+		{ID: "1", Data: map[string]any{"region": "EU", "country": "DE", "revenue": 100.0, "placed": time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)}},
+		{ID: "2", Data: map[string]any{"region": "US", "country": "US", "revenue": 250.0, "placed": time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}},
+		{ID: "3", Data: map[string]any{"region": "EU", "country": "FR", "revenue": 200.0, "placed": time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)}},
+		{ID: "4", Data: map[string]any{"region": "EU", "country": "DE", "revenue": nil, "placed": time.Date(2023, 12, 24, 0, 0, 0, 0, time.UTC)}},
+		{ID: "5", Data: map[string]any{"region": "APAC", "country": "JP", "revenue": 50.5, "placed": nil}},
+	}
+	columns := []Column{
+		{ID: "region", Label: "Region", Sortable: true},
+		{ID: "country", Label: "Country", Aggregate: AggregateCount},
+		{ID: "revenue", Label: "Revenue", Format: "currency", Aggregate: AggregateSum},
+		{ID: "placed", Label: "Placed", Format: "date", Aggregate: AggregateMax},
+	}
+	return New("orders", append([]Option{WithColumns(columns), WithRows(rows)}, opts...)...)
+}
+
+func TestAggregate(t *testing.T) {
+	rows := groupTestTable().Rows
+	tests := []struct {
+		kind   Aggregate
+		column string
+		want   any
+	}{
+		{AggregateCount, "revenue", 4},
+		{AggregateSum, "revenue", 600.5},
+		{AggregateAvg, "revenue", 150.125},
+		{AggregateMin, "revenue", 50.5},
+		{AggregateMax, "revenue", 250.0},
+		{AggregateMin, "placed", time.Date(2023, 12, 24, 0, 0, 0, 0, time.UTC)},
+		{AggregateMax, "country", "US"},
+		{AggregateAvg, "missing", nil},
+		{AggregateMin, "missing", nil},
+	}
+	for _, tt := range tests {
+		if got := aggregate(tt.kind, rows, tt.column); got != tt.want {
+			t.Errorf("%s(%s): expected %v, got %v", tt.kind, tt.column, tt.want, got)
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	dt := groupTestTable(WithGroupBy("region"))
+	if !dt.IsGrouped() {
+		t.Fatal("expected table to be grouped")
+	}
+
+	rows := dt.GetPageRows()
+	if got := rowIDs(rows); fmt.Sprint(got) != "[group:region:APAC 5 group:region:EU 1 3 4 group:region:US 2]" {
+		t.Fatalf("unexpected grouped rows %v", got)
+	}
+	eu := rows[2].Group
+	if eu.Label != "Region" || eu.Value != "EU" || eu.Count != 3 || rows[3].Depth != 1 {
+		t.Errorf("unexpected EU group %+v", eu)
+	}
+	if eu.Aggregates["revenue"] != "$300.00" || eu.Aggregates["country"] != "3" || eu.Aggregates["placed"] != "2024-01-09" {
+		t.Errorf("unexpected EU aggregates %v", eu.Aggregates)
+	}
+	if got := rowIDs(dt.GetFilteredRows()); fmt.Sprint(got) != "[5 1 3 4 2]" {
+		t.Errorf("expected filtered rows in group order without headers, got %v", got)
+	}
+
+	dt.Sort("region")
+	dt.Sort("region")
+	if got := rowIDs(dt.GetPageRows()); got[0] != "group:region:US" {
+		t.Errorf("expected descending groups when sorted desc, got %v", got)
+	}
+	dt.ClearSort()
+
+	dt.ToggleGroup("region:EU")
+	if got := rowIDs(dt.GetPageRows()); fmt.Sprint(got) != "[group:region:APAC 5 group:region:EU group:region:US 2]" {
+		t.Errorf("expected collapsed EU group, got %v", got)
+	}
+	if !dt.IsGroupCollapsed("region:EU") || !dt.GetPageRows()[2].Group.Collapsed {
+		t.Error("expected EU group to report collapsed")
+	}
+	dt.ToggleGroup("region:EU")
+
+	dt.SetGroupBy("region", "country")
+	if got := rowIDs(dt.GetPageRows()); fmt.Sprint(got[3:8]) != "[group:region:EU group:region:EU/country:DE 1 4 group:region:EU/country:FR]" {
+		t.Errorf("unexpected nested groups %v", got)
+	}
+
+	dt.PageSize = 4
+	if got := rowIDs(dt.GetPageRows()); dt.TotalRows() != 5 || dt.TotalPages() != 2 || len(got) != 9 {
+		t.Errorf("expected headers not to count toward pages, got %d rows on %d pages: %v", dt.TotalRows(), dt.TotalPages(), got)
+	}
+	totals := dt.Totals()
+	if totals["revenue"] != "$600.50" || totals["country"] != "5" || totals["placed"] != "2024-02-01" {
+		t.Errorf("expected totals over all filtered rows, got %v", totals)
+	}
+
+	dt.SetFilter("eu")
+	if totals := dt.Totals(); totals["revenue"] != "$300.00" {
+		t.Errorf("expected totals over filtered rows, got %v", totals)
+	}
+
+	var buf bytes.Buffer
+	if err := dt.Export(&buf, ExportCSV, ExportPage); err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	if strings.Contains(buf.String(), "group:") || strings.Count(buf.String(), "\n") != 4 {
+		t.Errorf("expected export without group headers, got %q", buf.String())
+	}
+
+	dt.SetGroupBy()
+	if dt.IsGrouped() || dt.Totals()["revenue"] != "$300.00" {
+		t.Error("expected SetGroupBy() to remove grouping")
+	}
+}
+
+func TestGroupedPaging(t *testing.T) {
+	var rows []Row
+	for i, team := range []string{"a", "a", "a", "a", "b", "b", "b", "c", "c", "c"} {
+		rows = append(rows, Row{ID: fmt.Sprint(i + 1), Data: map[string]any{"team": team}})
+	}
+	dt := New("teams", WithColumns([]Column{{ID: "team", Label: "Team"}}), WithRows(rows), WithPageSize(5), WithGroupBy("team"))
+
+	if dt.TotalRows() != 10 || dt.TotalPages() != 2 || dt.PageInfo() != "Showing 1–5 of 10" {
+		t.Errorf("expected 10 rows on 2 pages, got %d on %d (%q)", dt.TotalRows(), dt.TotalPages(), dt.PageInfo())
+	}
+	if got := rowIDs(dt.GetPageRows()); fmt.Sprint(got) != "[group:team:a 1 2 3 4 group:team:b 5]" {
+		t.Errorf("unexpected first page %v", got)
+	}
+	dt.NextPage()
+	if got := rowIDs(dt.GetPageRows()); fmt.Sprint(got) != "[6 7 group:team:c 8 9 10]" || dt.PageInfo() != "Showing 6–10 of 10" {
+		t.Errorf("unexpected second page %v (%q)", got, dt.PageInfo())
+	}
+
+	dt.ToggleGroup("team:c")
+	if got := rowIDs(dt.GetPageRows()); dt.TotalRows() != 7 || fmt.Sprint(got) != "[6 7 group:team:c]" {
+		t.Errorf("expected a trailing collapsed group on the last page, got %d rows: %v", dt.TotalRows(), got)
+	}
+	dt.ToggleGroup("team:c")
+	dt.ToggleGroup("team:b")
+	dt.GoToPage(0)
+	if got := rowIDs(dt.GetPageRows()); fmt.Sprint(got) != "[group:team:a 1 2 3 4 group:team:b group:team:c 8]" {
+		t.Errorf("expected a collapsed group on the page of the next row, got %v", got)
+	}
+}
+
+func TestGroupActions(t *testing.T) {
+	dt := groupTestTable()
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		if err := actions[name](base.NewActionContext(name, dt.ID(), data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+
+	run("group_by", map[string]string{"columns": "region, country"})
+	if fmt.Sprint(dt.GroupBy) != "[region country]" {
+		t.Errorf("expected grouping by region and country, got %v", dt.GroupBy)
+	}
+	run("toggle_group", map[string]string{"group": "region:US"})
+	if !dt.IsGroupCollapsed("region:US") {
+		t.Error("expected US group to be collapsed")
+	}
+	run("group_by", map[string]string{"columns": ""})
+	if dt.IsGrouped() {
+		t.Error("expected empty columns to remove grouping")
+	}
+}
+
+func TestTemplateGroups(t *testing.T) {
+	dt := groupTestTable(WithGroupBy("region"), WithMultiSelect(true))
+	dt.ToggleGroup("region:US")
+
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t,
+			"Region: EU",
+			"(3)",
+			"$300.00",
+			`lvt-data-group="region:US" aria-expanded="false"`,
+			"<tfoot",
+			"Total",
+			"$600.50",
+		)
+		r.excludes(t, `lvt-data-row="2"`)
+	}
+}
+
+func pagedTestTable(n int, opts ...Option) *DataTable {
+	rows := make([]Row, n)
+	for i := range rows {
+		rows[i] = Row{ID: fmt.Sprint(i + 1), Data: map[string]any{"n": i + 1}}
+	}
+	return New("paged", append([]Option{
+		WithColumns([]Column{{ID: "n", Label: "N", Filterable: FilterSpec{Kind: FilterText}}}),
+		WithRows(rows),
+		WithPageSize(10),
+	}, opts...)...)
+}
+
+func TestPageLinks(t *testing.T) {
+	labels := func(dt *DataTable) string {
+		var parts []string
+		for _, link := range dt.PageLinks() {
+			label := link.Label
+			if link.Current {
+				label = "[" + label + "]"
+			}
+			parts = append(parts, label)
+		}
+		return strings.Join(parts, " ")
+	}
+
+	dt := pagedTestTable(200)
+	tests := []struct {
+		page int
+		want string
+	}{
+		{0, "[1] 2 3 … 20"},
+		{3, "1 2 3 [4] 5 6 … 20"},
+		{5, "1 … 4 5 [6] 7 8 … 20"},
+		{16, "1 … 15 16 [17] 18 19 20"},
+		{19, "1 … 18 19 [20]"},
+	}
+	for _, tt := range tests {
+		dt.Page = tt.page
+		if got := labels(dt); got != tt.want {
+			t.Errorf("page %d: expected %q, got %q", tt.page, tt.want, got)
+		}
+	}
+
+	dt.Page = 5
+	dt.PageWindow = 0
+	if got := labels(dt); got != "1 … [6] … 20" {
+		t.Errorf("window 0: expected %q, got %q", "1 … [6] … 20", got)
+	}
+	for _, link := range dt.PageLinks() {
+		if link.Ellipsis && link.Page != -1 {
+			t.Errorf("expected ellipsis page -1, got %d", link.Page)
+		}
+	}
+
+	if links := pagedTestTable(5).PageLinks(); links != nil {
+		t.Errorf("expected no links for a single page, got %v", links)
+	}
+}
+
+func TestPageSizeAndJump(t *testing.T) {
+	dt := pagedTestTable(345, WithPageSizeOptions(10, 25, 50))
+	dt.GoToPage(3)
+
+	// Rows 31-40 are shown; with 25 per page row 31 is on page 2.
+	dt.SetPageSize(25)
+	if dt.Page != 1 || dt.StartIndex() != 26 {
+		t.Errorf("expected page 1 starting at 26, got page %d starting at %d", dt.Page, dt.StartIndex())
+	}
+
+	dt.JumpToPage(5)
+	if dt.Page != 4 {
+		t.Errorf("expected page 4, got %d", dt.Page)
+	}
+	dt.JumpToPage(100)
+	if dt.Page != dt.TotalPages()-1 {
+		t.Errorf("expected last page, got %d", dt.Page)
+	}
+	dt.JumpToPage(-3)
+	if dt.Page != 0 {
+		t.Errorf("expected first page, got %d", dt.Page)
+	}
+
+	dt.GoToPage(13)
+	dt.SetPageSize(0)
+	if dt.Page != 0 || len(dt.GetPageRows()) != 345 {
+		t.Errorf("expected all rows on page 0, got %d rows on page %d", len(dt.GetPageRows()), dt.Page)
+	}
+}
+
+func TestPageInfo(t *testing.T) {
+	dt := pagedTestTable(345)
+	dt.GoToPage(1)
+	if got := dt.PageInfo(); got != "Showing 11–20 of 345" {
+		t.Errorf("expected default page info, got %q", got)
+	}
+
+	dt = pagedTestTable(345, WithPageInfo("%[3]d Einträge, %[1]d bis %[2]d", "Keine Ergebnisse"))
+	dt.LastPage()
+	if got := dt.PageInfo(); got != "345 Einträge, 341 bis 345" {
+		t.Errorf("expected localised page info, got %q", got)
+	}
+	dt.SetFilter("nothing matches")
+	if got := dt.PageInfo(); got != "Keine Ergebnisse" {
+		t.Errorf("expected localised no results text, got %q", got)
+	}
+}
+
+func TestPageClamping(t *testing.T) {
+	dt := pagedTestTable(50)
+	dt.LastPage()
+	dt.SetData(pagedTestTable(25).Rows)
+	if dt.Page != 2 || len(dt.GetPageRows()) != 5 {
+		t.Errorf("expected page 2 with 5 rows, got page %d with %d rows", dt.Page, len(dt.GetPageRows()))
+	}
+
+	dt = groupTestTable(WithGroupBy("region"), WithPageSize(2))
+	dt.LastPage()
+	dt.ToggleGroup("region:EU")
+	if dt.Page != dt.TotalPages()-1 || len(dt.GetPageRows()) == 0 {
+		t.Errorf("expected collapsing a group to clamp to the last page, got page %d of %d", dt.Page, dt.TotalPages())
+	}
+
+	rows := pagedTestTable(30).Rows
+	src := NewMemorySource(rows, nil)
+	dt = New("paged", WithPageSize(10), WithDataSource(src))
+	dt.Page = 2
+	src.Rows = rows[:15]
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if dt.Page != 1 || len(dt.GetPageRows()) != 5 {
+		t.Errorf("expected Load to fall back to page 1 with 5 rows, got page %d with %d rows", dt.Page, len(dt.GetPageRows()))
+	}
+}
+
+func TestPaginationActions(t *testing.T) {
+	dt := pagedTestTable(100, WithPageSizeOptions(10, 50))
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		if err := actions[name](base.NewActionContext(name, dt.ID(), data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+
+	run("jump_to_page", map[string]string{"value": "7"})
+	if dt.Page != 6 {
+		t.Errorf("expected page 6, got %d", dt.Page)
+	}
+	run("set_page_size", map[string]string{"value": "50"})
+	if dt.PageSize != 50 || dt.Page != 1 {
+		t.Errorf("expected page 1 of size 50, got page %d of size %d", dt.Page, dt.PageSize)
+	}
+	run("jump_to_page", map[string]string{"page": "1"})
+	if dt.Page != 0 {
+		t.Errorf("expected page 0, got %d", dt.Page)
+	}
+}
+
+func TestTemplatePagination(t *testing.T) {
+	dt := pagedTestTable(200, WithPageSizeOptions(10, 25))
+	dt.GoToPage(5)
+
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t,
+			"Showing 51–60 of 200",
+			`lvt-change="set_page_size_`,
+			`<option value="10" selected>10</option>`,
+			`lvt-data-page="4"`,
+			`aria-current="page">6<`,
+			"…",
+			`lvt-data-page="19"`,
+			`lvt-click="first_page_`,
+			`lvt-click="last_page_`,
+			`max="20" value="6" lvt-change="jump_to_page_`,
+		)
+	}
+}
+
+func TestSelectAllMatching(t *testing.T) {
+	dt := pagedTestTable(30, WithMultiSelect(true))
+	dt.Rows[1].Disabled = true // row 2
+	dt.SetFilter("2")          // 2, 12, 20-29
+
+	// Selecting the first page only marks loaded page rows.
+	for _, row := range dt.GetPageRows() {
+		if !row.Disabled {
+			dt.SelectRow(row.ID)
+		}
+	}
+	if !dt.AllSelected() || dt.SelectedCount() != 9 {
+		t.Errorf("expected the page to be selected with 9 rows, got %v and %d", dt.AllSelected(), dt.SelectedCount())
+	}
+
+	dt.SelectAllMatching()
+	if !dt.IsSelectingAllMatching() || dt.SelectedCount() != 11 {
+		t.Errorf("expected 11 matching rows selected, got %d", dt.SelectedCount())
+	}
+	dt.DeselectRow("25")
+	if dt.SelectedCount() != 10 || dt.IsRowSelected("25") || !dt.IsRowSelected("29") {
+		t.Errorf("expected row 25 to be excluded, got count %d", dt.SelectedCount())
+	}
+	if dt.IsRowSelected("3") || dt.IsRowSelected("2") {
+		t.Error("expected non-matching and disabled rows to stay unselected")
+	}
+	if dt.AllSelected() {
+		t.Error("expected page with an excluded row not to be all selected")
+	}
+
+	var ids []string
+	err := dt.EachSelectedID(context.Background(), func(id string) error {
+		ids = append(ids, id)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachSelectedID returned error: %v", err)
+	}
+	if got := strings.Join(ids, ","); got != "12,20,21,22,23,24,26,27,28,29" {
+		t.Errorf("unexpected selected IDs %s", got)
+	}
+	if got := rowIDs(dt.GetSelectedRows()); len(got) != 10 {
+		t.Errorf("expected 10 selected rows, got %v", got)
+	}
+
+	sel := dt.Selection()
+	if sel.Mode != SelectionExclude || len(sel.ExcludedIDs) != 1 || sel.Query.Search != "2" || sel.Count != 10 {
+		t.Errorf("unexpected selection snapshot %+v", sel)
+	}
+
+	dt.SetFilter("1")
+	if dt.IsSelectingAllMatching() || dt.HasSelection() {
+		t.Error("expected a filter change to clear the select-all-matching selection")
+	}
+}
+
+func TestTogglePageSelection(t *testing.T) {
+	dt := pagedTestTable(4, WithPageSize(2), WithMultiSelect(true))
+	toggle := func() {
+		t.Helper()
+		if err := dt.Actions()["toggle_all"](base.NewActionContext("toggle_all", dt.ID(), nil)); err != nil {
+			t.Fatalf("toggle_all returned error: %v", err)
+		}
+	}
+
+	dt.NextPage()
+	toggle()
+	if got := strings.Join(sortedKeys(dt.SelectedIDs), ","); got != "3,4" || !dt.AllSelected() {
+		t.Errorf("expected only the page rows selected, got %s", got)
+	}
+	dt.FirstPage()
+	dt.SelectRow("1")
+	dt.NextPage()
+	toggle()
+	if got := strings.Join(sortedKeys(dt.SelectedIDs), ","); got != "1" {
+		t.Errorf("expected other pages to keep their selection, got %s", got)
+	}
+
+	dt.SelectAllMatching()
+	toggle()
+	if !dt.IsSelectingAllMatching() || dt.SelectedCount() != 2 || !dt.IsRowSelected("2") {
+		t.Errorf("expected the page to be excluded from the matching selection, got %d rows", dt.SelectedCount())
+	}
+}
+
+func TestSelectAllMatchingDataSource(t *testing.T) {
+	src := NewMemorySource(pagedTestTable(30).Rows, nil)
+	dt := New("paged", WithPageSize(10), WithMultiSelect(true), WithDataSource(src))
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	dt.SelectAllMatching()
+	dt.DeselectRow("3")
+	if dt.SelectedCount() != 29 {
+		t.Errorf("expected 29 selected rows, got %d", dt.SelectedCount())
+	}
+
+	dt.NextPage()
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !dt.AllSelected() {
+		t.Error("expected rows loaded after SelectAllMatching to be selected")
+	}
+
+	count := 0
+	err := dt.EachSelectedID(context.Background(), func(id string) error {
+		if id == "3" {
+			t.Error("expected excluded row 3 to be skipped")
+		}
+		count++
+		return nil
+	})
+	if err != nil || count != 29 {
+		t.Errorf("expected 29 streamed IDs, got %d (err %v)", count, err)
+	}
+
+	var buf bytes.Buffer
+	if err := dt.Export(&buf, ExportCSV, ExportSelected); err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 30 {
+		t.Errorf("expected header and 29 exported rows, got %d lines", lines)
+	}
+}
+
+func TestBulkActions(t *testing.T) {
+	var got Selection
+	dt := pagedTestTable(30, WithMultiSelect(true),
+		WithBulkAction("archive", "Archive", func(ctx context.Context, sel Selection) error {
+			got = sel
+			return nil
+		}),
+	)
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) error {
+		return actions[name](base.NewActionContext(name, dt.ID(), data))
+	}
+
+	dt.SelectRow("4")
+	dt.SelectRow("17")
+	if err := run("bulk_action", map[string]string{"action": "archive"}); err != nil {
+		t.Fatalf("bulk_action returned error: %v", err)
+	}
+	if got.Mode != SelectionInclude || strings.Join(got.IDs, ",") != "17,4" || got.Count != 2 {
+		t.Errorf("unexpected selection %+v", got)
+	}
+
+	if err := run("select_all_matching", nil); err != nil || dt.SelectedCount() != 30 {
+		t.Errorf("expected select_all_matching to select 30 rows, got %d (err %v)", dt.SelectedCount(), err)
+	}
+	if err := run("bulk_action", map[string]string{"action": "archive"}); err != nil || got.Mode != SelectionExclude || got.Count != 30 {
+		t.Errorf("expected exclude-mode selection, got %+v (err %v)", got, err)
+	}
+	if err := run("bulk_action", map[string]string{"action": "delete"}); err == nil {
+		t.Error("expected an error for an unknown bulk action")
+	}
+	if err := run("clear_selection", nil); err != nil || dt.HasSelection() {
+		t.Errorf("expected clear_selection to deselect everything (err %v)", err)
+	}
+}
+
+func TestTemplateSelectionToolbar(t *testing.T) {
+	dt := pagedTestTable(30, WithMultiSelect(true), WithBulkAction("archive", "Archive", nil))
+	dt.SelectAll()
+	for i := 10; i < 30; i++ {
+		dt.DeselectRow(dt.Rows[i].ID)
+	}
+
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t,
+			"10 selected",
+			"Select all 30 matching rows",
+			`lvt-click="clear_selection_`,
+			`lvt-data-action="archive"`,
+			"Archive",
+		)
+	}
+
+	dt.SelectAllMatching()
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t, "30 selected")
+		r.excludes(t, "Select all 30")
+	}
+}
+
+func layoutTestTable(opts ...Option) *DataTable {
+	return filterTestTable(append([]Option{WithColumnChooser(true)}, opts...)...)
+}
+
+func columnIDs(cols []Column) []string {
+	ids := make([]string, len(cols))
+	for i, col := range cols {
+		ids[i] = col.ID
+	}
+	return ids
+}
+
+func TestColumnLayout(t *testing.T) {
+	dt := layoutTestTable()
+
+	dt.MoveColumn("active", 0)
+	dt.MoveColumnRight("name")
+	dt.MoveColumnLeft("age")
+	if got := strings.Join(columnIDs(dt.Columns), ","); got != "active,country,age,name" {
+		t.Errorf("unexpected column order %s", got)
+	}
+	dt.MoveColumn("active", 99)
+	dt.MoveColumnLeft("country")
+	if got := strings.Join(columnIDs(dt.Columns), ","); got != "country,age,name,active" {
+		t.Errorf("unexpected column order after clamped moves %s", got)
+	}
+
+	dt.PinColumn("name", PinLeft)
+	dt.PinColumn("country", PinRight)
+	dt.PinColumn("age", "top")
+	if got := strings.Join(columnIDs(dt.VisibleColumns()), ","); got != "name,age,active,country" {
+		t.Errorf("expected pinned columns at the edges, got %s", got)
+	}
+
+	dt.ResizeColumn("name", 12)
+	if col := dt.GetColumn("name"); col.Width != "40px" {
+		t.Errorf("expected width clamped to 40px, got %q", col.Width)
+	}
+	dt.ResizeColumn("name", 0)
+	if col := dt.GetColumn("name"); col.Width != "" {
+		t.Errorf("expected width 0 to restore automatic sizing, got %q", col.Width)
+	}
+
+	for _, id := range []string{"name", "age", "active", "country"} {
+		dt.ToggleColumn(id)
+	}
+	if visible := columnIDs(dt.VisibleColumns()); len(visible) != 1 || visible[0] != "country" {
+		t.Errorf("expected the last visible column to stay visible, got %v", visible)
+	}
+}
+
+func TestLayoutRoundTrip(t *testing.T) {
+	dt := layoutTestTable(WithStickyHeader(true))
+	dt.MoveColumn("age", 0)
+	dt.HideColumn("country")
+	dt.ResizeColumn("name", 240)
+	dt.PinColumn("age", PinLeft)
+
+	data, err := json.Marshal(dt.Layout())
+	if err != nil {
+		t.Fatalf("failed to marshal layout: %v", err)
+	}
+	want := `{"columns":[{"id":"age","pinned":"left"},{"id":"name","width":"240px"},{"id":"country","hidden":true},{"id":"active"}],"stickyHeader":true}`
+	if string(data) != want {
+		t.Errorf("unexpected layout JSON\n got: %s\nwant: %s", data, want)
+	}
+
+	var layout Layout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		t.Fatalf("failed to unmarshal layout: %v", err)
+	}
+	layout.Columns = append([]ColumnLayout{{ID: "removed"}}, layout.Columns[:3]...)
+
+	restored := filterTestTable(WithLayout(layout))
+	if got := strings.Join(columnIDs(restored.Columns), ","); got != "age,name,country,active" {
+		t.Errorf("unexpected restored order %s", got)
+	}
+	if !restored.StickyHeader || !restored.GetColumn("country").Hidden ||
+		restored.GetColumn("name").Width != "240px" || restored.GetColumn("age").Pinned != PinLeft {
+		t.Errorf("layout not restored: %+v", restored.Layout())
+	}
+}
+
+func TestColumnStyle(t *testing.T) {
+	cols := []Column{
+		{ID: "a", Pinned: PinLeft, Width: "100px"},
+		{ID: "b", Pinned: PinLeft},
+		{ID: "c", Width: "20%"},
+		{ID: "d", Pinned: PinRight, Width: "80px"},
+	}
+	tests := []struct {
+		id     string
+		header bool
+		sticky bool
+		want   string
+	}{
+		{"a", false, false, "position: sticky; left: 0px; background-color: var(--lvt-pinned-bg, #fff); z-index: 1"},
+		{"b", false, false, "position: sticky; left: 100px; background-color: var(--lvt-pinned-bg, #fff); z-index: 1"},
+		{"b", true, false, "width: 160px; min-width: 160px; position: sticky; left: 100px; background-color: var(--lvt-pinned-bg, #fff); z-index: 1"},
+		{"c", false, false, ""},
+		{"c", true, true, "width: 20%; position: sticky; top: 0; z-index: 2; background-color: var(--lvt-pinned-bg, #fff)"},
+		{"d", true, true, "width: 80px; min-width: 80px; position: sticky; right: 0px; background-color: var(--lvt-pinned-bg, #fff); top: 0; z-index: 3"},
+	}
+	for _, tt := range tests {
+		if got := string(columnStyle(cols, tt.id, tt.header, tt.sticky)); got != tt.want {
+			t.Errorf("columnStyle(%s, header=%v, sticky=%v)\n got: %s\nwant: %s", tt.id, tt.header, tt.sticky, got, tt.want)
+		}
+	}
+}
+
+func TestColumnActions(t *testing.T) {
+	dt := layoutTestTable()
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		if err := actions[name](base.NewActionContext(name, dt.ID(), data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+
+	run("toggle_column_chooser", nil)
+	if !dt.ColumnChooserOpen {
+		t.Error("expected the column chooser to open")
+	}
+	run("toggle_column", map[string]string{"column": "age"})
+	run("move_column", map[string]string{"column": "active", "index": "1"})
+	run("move_column_right", map[string]string{"column": "name"})
+	run("resize_column", map[string]string{"column": "country", "value": "180"})
+	run("pin_column", map[string]string{"column": "country", "value": "right"})
+	run("close_column_chooser", nil)
+
+	if dt.ColumnChooserOpen {
+		t.Error("expected the column chooser to close")
+	}
+	want := Layout{Columns: []ColumnLayout{
+		{ID: "active"},
+		{ID: "name"},
+		{ID: "country", Width: "180px", Pinned: PinRight},
+		{ID: "age", Hidden: true},
+	}}
+	if got := dt.Layout(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("unexpected layout\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestTemplateColumnLayout(t *testing.T) {
+	dt := layoutTestTable(WithStickyHeader(true))
+	dt.HideColumn("age")
+	dt.PinColumn("country", PinLeft)
+	dt.ResizeColumn("country", 120)
+	dt.ToggleColumnChooser()
+
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t,
+			`lvt-click="toggle_column_chooser_`,
+			`lvt-click-away="close_column_chooser_`,
+			`lvt-click="toggle_column_`,
+			`aria-label="Move Age left"`,
+			`<option value="left" selected>Pin left</option>`,
+			`value="120"`,
+			`style="width: 120px; min-width: 120px; position: sticky; left: 0px; background-color: var(--lvt-pinned-bg, #fff); top: 0; z-index: 3"`,
+			`style="position: sticky; left: 0px; background-color: var(--lvt-pinned-bg, #fff); z-index: 1"`,
+			`style="position: sticky; top: 0; z-index: 2; background-color: var(--lvt-pinned-bg, #fff)"`,
+		)
+		head := r.html[strings.Index(r.html, "<thead"):]
+		if strings.Index(head, "Country") > strings.Index(head, "Name") {
+			t.Errorf("%s: expected the pinned column first in the header", r.name)
+		}
+	}
+}
+
+func TestViewStores(t *testing.T) {
+	ctx := context.Background()
+	stores := map[string]ViewStore{
+		"memory": NewMemoryViewStore(),
+		"file":   NewFileViewStore(t.TempDir() + "/views.json"),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			if views, err := store.ListViews(ctx, "people"); err != nil || len(views) != 0 {
+				t.Fatalf("expected no views, got %v (%v)", views, err)
+			}
+			for _, v := range []View{{Name: "young", PageSize: 5}, {Name: "adults"}, {Name: "young", PageSize: 10}} {
+				if err := store.SaveView(ctx, "people", v); err != nil {
+					t.Fatalf("failed to save view: %v", err)
+				}
+			}
+			if err := store.SaveView(ctx, "orders", View{Name: "open"}); err != nil {
+				t.Fatalf("failed to save view: %v", err)
+			}
+
+			views, err := store.ListViews(ctx, "people")
+			if err != nil {
+				t.Fatalf("failed to list views: %v", err)
+			}
+			if len(views) != 2 || views[0].Name != "adults" || views[1].Name != "young" || views[1].PageSize != 10 {
+				t.Errorf("unexpected views %+v", views)
+			}
+
+			if err := store.DeleteView(ctx, "people", "adults"); err != nil {
+				t.Fatalf("failed to delete view: %v", err)
+			}
+			if err := store.DeleteView(ctx, "people", "adults"); !errors.Is(err, ErrViewNotFound) {
+				t.Errorf("expected ErrViewNotFound, got %v", err)
+			}
+			if views, _ := store.ListViews(ctx, "people"); len(views) != 1 {
+				t.Errorf("expected one view left, got %+v", views)
+			}
+			if views, _ := store.ListViews(ctx, "orders"); len(views) != 1 {
+				t.Errorf("expected views of other tables to be kept, got %+v", views)
+			}
+		})
+	}
+}
+
+func TestSavedViews(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileViewStore(t.TempDir() + "/views.json")
+
+	dt := layoutTestTable(WithViewStore(store))
+	if err := dt.SaveView(ctx, " "); err == nil {
+		t.Error("expected an error for an empty view name")
+	}
+	dt.SetSortKeys(SortKey{Column: "age", Direction: SortDesc})
+	dt.SetColumnFilter(ColumnFilter{Column: "age", Operator: FilterRange, Min: 30})
+	dt.PageSize = 1
+	dt.MoveColumn("age", 0)
+	dt.HideColumn("country")
+	dt.ResizeColumn("name", 200)
+	if err := dt.SaveView(ctx, "over 30"); err != nil {
+		t.Fatalf("failed to save view: %v", err)
+	}
+	if dt.CurrentView != "over 30" || len(dt.SavedViews) != 1 {
+		t.Errorf("expected the saved view to be current and listed, got %q %v", dt.CurrentView, dt.SavedViews)
+	}
+
+	// A new table, as in a later session, restores the view from the file.
+	restored := layoutTestTable(WithViewStore(NewFileViewStore(store.path)))
+	if err := restored.LoadViews(ctx); err != nil {
+		t.Fatalf("failed to load views: %v", err)
+	}
+	if len(restored.SavedViews) != 1 || restored.SavedViews[0] != "over 30" {
+		t.Fatalf("expected the saved view to be listed, got %v", restored.SavedViews)
+	}
+	restored.NextPage()
+	if err := restored.ApplySavedView(ctx, "over 30"); err != nil {
+		t.Fatalf("failed to apply view: %v", err)
+	}
+	if restored.Page != 0 || restored.PageSize != 1 || restored.CurrentView != "over 30" {
+		t.Errorf("unexpected page state: page %d, size %d, view %q", restored.Page, restored.PageSize, restored.CurrentView)
+	}
+	if got := strings.Join(columnIDs(restored.VisibleColumns()), ","); got != "age,name,active" {
+		t.Errorf("unexpected columns %s", got)
+	}
+	if restored.GetColumn("name").Width != "200px" {
+		t.Errorf("expected the column width to be restored")
+	}
+	restored.PageSize = 0
+	if got := strings.Join(rowIDs(restored.GetPageRows()), ","); got != "2,1" {
+		t.Errorf("expected filtered rows sorted by age descending, got %s", got)
+	}
+
+	if err := restored.ApplySavedView(ctx, "missing"); !errors.Is(err, ErrViewNotFound) {
+		t.Errorf("expected ErrViewNotFound, got %v", err)
+	}
+	if err := restored.DeleteView(ctx, "over 30"); err != nil {
+		t.Fatalf("failed to delete view: %v", err)
+	}
+	if restored.CurrentView != "" || len(restored.SavedViews) != 0 {
+		t.Errorf("expected no views after delete, got %q %v", restored.CurrentView, restored.SavedViews)
+	}
+
+	if err := layoutTestTable().SaveView(ctx, "x"); err == nil {
+		t.Error("expected an error without a view store")
+	}
+}
+
+func TestViewActions(t *testing.T) {
+	dt := layoutTestTable(WithViewStore(NewMemoryViewStore()))
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		if err := actions[name](base.NewActionContext(name, dt.ID(), data)); err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+	}
+
+	dt.SetFilter("bob")
+	run("save_view", map[string]string{"value": "bob"})
+	dt.ClearFilter()
+	run("save_view", map[string]string{"name": "all"})
+	if got := strings.Join(dt.SavedViews, ","); got != "all,bob" {
+		t.Errorf("unexpected saved views %s", got)
+	}
+
+	run("apply_view", map[string]string{"value": "bob"})
+	if dt.FilterValue != "bob" || dt.CurrentView != "bob" {
+		t.Errorf("expected the bob view, got filter %q view %q", dt.FilterValue, dt.CurrentView)
+	}
+	run("apply_view", map[string]string{"view": "all"})
+	if dt.FilterValue != "" {
+		t.Errorf("expected the filter cleared, got %q", dt.FilterValue)
+	}
+
+	run("delete_view", nil)
+	run("delete_view", map[string]string{"view": "bob"})
+	if len(dt.SavedViews) != 0 || dt.CurrentView != "" {
+		t.Errorf("expected all views deleted, got %v %q", dt.SavedViews, dt.CurrentView)
+	}
+}
+
+func TestTemplateSavedViews(t *testing.T) {
+	ctx := context.Background()
+	dt := layoutTestTable(WithViewStore(NewMemoryViewStore()))
+	for _, name := range []string{"recent", "all"} {
+		if err := dt.SaveView(ctx, name); err != nil {
+			t.Fatalf("failed to save view: %v", err)
+		}
+	}
+
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t,
+			`lvt-change="apply_view_people"`,
+			`<option value="all" selected>all</option>`,
+			`<option value="recent" >recent</option>`,
+			`lvt-click="delete_view_people" lvt-data-view="all"`,
+			`lvt-change="save_view_people"`,
+		)
+	}
+
+	if strings.Contains(renderTemplate(t, layoutTestTable()), "save_view") {
+		t.Error("expected no views bar without a view store")
+	}
+}
+
+// roundTripTable returns a table with every exported field set, so that
+// TestJSONRoundTrip covers fields added later.
+func roundTripTable() *DataTable {
+	dt := New("orders",
+		WithColumns([]Column{
+			{ID: "region", Label: "Region", Sortable: true, Filterable: FilterSpec{Kind: FilterFacet, Options: []FilterOption{{Value: "EU", Label: "Europe"}}}, Width: "120px", Align: "left", Pinned: PinLeft},
+			{ID: "total", Label: "Total", Sortable: true, Align: "right", Format: "currency", Aggregate: AggregateSum, Filterable: FilterSpec{Kind: FilterNumber, Min: 0.0, Step: 0.5}},
+			{ID: "status", Label: "Status", Editable: true, Editor: EditorSelect, EditOptions: []EditOption{{Value: "open", Label: "Open"}}},
+			{ID: "notes", Label: "Notes", Hidden: true},
+		}),
+		WithRows([]Row{
+			{ID: "1", Data: map[string]any{"region": "EU", "total": 12.5, "status": "open"}, Selected: true},
+			{ID: "2", Data: map[string]any{"region": "EU", "total": 30.0, "status": "closed"}, Disabled: true},
+			{ID: "3", Data: map[string]any{"region": "US", "total": 7.25, "status": "open"}, Expanded: true,
+				Children: []Row{{ID: "3a", Data: map[string]any{"region": "US", "total": 1.0}}}},
+		}),
+		WithStyled(true),
+		WithSortKeys(SortKey{Column: "total", Direction: SortDesc}, SortKey{Column: "region", Direction: SortAsc}),
+		WithFilter("o"),
+		WithFilterColumn("status"),
+		WithColumnFilters(ColumnFilter{Column: "total", Operator: FilterRange, Min: 1.0, Max: 100.0}),
+		WithPageSize(2),
+		WithPageSizeOptions(2, 10),
+		WithPageWindow(1),
+		WithPageInfo("%[1]d-%[2]d/%[3]d", "None"),
+		WithMultiSelect(true),
+		WithBulkAction("archive", "Archive", nil),
+		WithBordered(true),
+		WithCompact(true),
+		WithLoading(true),
+		WithEmptyMessage("Nothing"),
+		WithStickyHeader(true),
+		WithColumnChooser(true),
+		WithExportFormats(ExportCSV),
+		WithGroupBy("region"),
+		WithVirtualRows(10, 36),
+		WithInfiniteScroll(true),
+		WithRowActions(RowAction{Name: "edit", Label: "Edit", Icon: "pencil"}),
+		WithOnRowClick(func(context.Context, Row) error { return nil }),
+		WithOnRowOpen(func(context.Context, Row) error { return nil }),
+	)
+	dt.Page = 1
+	dt.SelectedIDs = map[string]bool{"1": true}
+	dt.SelectionMode = SelectionInclude
+	dt.ExcludedIDs = map[string]bool{"2": true}
+	dt.ColumnChooserOpen = true
+	dt.ViewsEnabled = true
+	dt.SavedViews = []string{"eu"}
+	dt.CurrentView = "eu"
+	dt.Editing = &CellEdit{RowID: "1", ColumnID: "status", Value: "shipped", Error: "invalid"}
+	dt.ExpandedIDs = map[string]bool{"3": true}
+	dt.OpenFacet = "region"
+	dt.OpenRowMenu = "1"
+	dt.DetailTemplate = "order-detail"
+	dt.CollapsedGroups = map[string]bool{"US": true}
+	return dt
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	dt := roundTripTable()
+	data, err := json.Marshal(dt)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var decoded DataTable
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	want, got := reflect.ValueOf(dt).Elem(), reflect.ValueOf(&decoded).Elem()
+	for i := 0; i < want.NumField(); i++ {
+		f := want.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if want.Field(i).IsZero() {
+			t.Errorf("%s is not set in roundTripTable", f.Name)
+			continue
+		}
+		if !reflect.DeepEqual(want.Field(i).Interface(), got.Field(i).Interface()) {
+			t.Errorf("%s not restored\n got: %#v\nwant: %#v", f.Name, got.Field(i).Interface(), want.Field(i).Interface())
+		}
+	}
+	for _, f := range []struct {
+		name      string
+		want, got any
+	}{
+		{"ID", dt.ID(), decoded.ID()},
+		{"Namespace", dt.Namespace(), decoded.Namespace()},
+		{"Styled", dt.IsStyled(), decoded.IsStyled()},
+	} {
+		if f.want != f.got {
+			t.Errorf("%s not restored: got %v, want %v", f.name, f.got, f.want)
+		}
+	}
+
+	again, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatalf("failed to marshal decoded table: %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("computed fields differ after a round trip\n got: %s\nwant: %s", again, data)
+	}
+}
+
+func TestJSONRoundTripDataSource(t *testing.T) {
+	ctx := context.Background()
+	detail := template.Must(template.New("").Parse(`{{define "detail"}}<p>{{.ID}}</p>{{end}}`))
+	dt := New("people",
+		WithColumns(filterTestTable().Columns),
+		WithPageSize(3),
+		WithDataSource(NewMemorySource(filterTestTable().Rows, nil)),
+		WithDetailTemplate(detail, "detail"),
+		WithMultiSelect(true),
+	)
+	dt.ExpandRow("2")
+	if err := dt.Load(ctx); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	dt.SelectAllMatching()
+	dt.DeselectRow("1")
+
+	data, err := json.Marshal(dt)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var decoded DataTable
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if decoded.TotalRows() != 4 || decoded.TotalPages() != 2 || len(decoded.GetPageRows()) != 3 || decoded.SelectedCount() != 3 {
+		t.Errorf("expected the encoded page and counts, got %d rows of %d on %d pages, %d selected",
+			len(decoded.GetPageRows()), decoded.TotalRows(), decoded.TotalPages(), decoded.SelectedCount())
+	}
+	if html, _ := decoded.RenderDetail(decoded.Rows[1]); html != "<p>2</p>" {
+		t.Errorf("expected the encoded detail panel, got %q", html)
+	}
+	again, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatalf("failed to marshal decoded table: %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("JSON differs after a round trip\n got: %s\nwant: %s", again, data)
+	}
+
+	// Decoding into a table with the DataSource attached resumes paging.
+	resumed := New("people", WithDataSource(NewMemorySource(filterTestTable().Rows, nil)))
+	if err := json.Unmarshal(data, resumed); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	resumed.NextPage()
+	if err := resumed.Load(ctx); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := rowIDs(resumed.GetPageRows()); len(got) != 1 || got[0] != "4" || !resumed.GetPageRows()[0].Selected {
+		t.Errorf("expected selected row 4 on page 2, got %+v", resumed.GetPageRows())
+	}
+}
+
+func TestConvert(t *testing.T) {
+	if n, ok := convert[int](float64(3)); !ok || n != 3 {
+		t.Errorf("expected 3, got %v %v", n, ok)
+	}
+	if _, ok := convert[int](2.5); ok {
+		t.Error("expected a fraction not to convert to int")
+	}
+	if d, ok := convert[SortDirection](float64(SortDesc)); !ok || d != SortDesc {
+		t.Errorf("expected SortDesc, got %v %v", d, ok)
+	}
+	if p, ok := convert[Pin]("left"); !ok || p != PinLeft {
+		t.Errorf("expected PinLeft, got %q %v", p, ok)
+	}
+	if f, ok := convert[float32](0.1); !ok || f != float32(0.1) {
+		t.Errorf("expected 0.1, got %v %v", f, ok)
+	}
+	if _, ok := convert[string](float64(1)); ok {
+		t.Error("expected a number not to convert to string")
+	}
+	if _, ok := convert[int](nil); ok {
+		t.Error("expected nil not to convert")
+	}
+	col, ok := convert[Column](map[string]any{"ID": "name", "Format": "upper", "Pinned": "right"})
+	if !ok || col.ID != "name" || col.Format != "upper" || col.Pinned != PinRight {
+		t.Errorf("expected a column decoded from its map, got %+v %v", col, ok)
+	}
+}
+
+func TestTemplateJSONRepresentation(t *testing.T) {
+	tables := map[string]*DataTable{
+		"sorted":   filterTestTable(WithSortKeys(SortKey{Column: "age", Direction: SortDesc}, SortKey{Column: "name", Direction: SortAsc})),
+		"paged":    pagedTestTable(25, WithPageSizeOptions(10, 20), WithMultiSelect(true)),
+		"layout":   layoutTestTable(WithStickyHeader(true)),
+		"editing":  roundTripTable(),
+		"grouped":  roundTripTable(),
+		"unstyled": filterTestTable(WithStyled(false)),
+	}
+	tables["paged"].SelectRow("n3")
+	tables["layout"].PinColumn("age", PinRight)
+	tables["editing"].Loading = false
+	tables["editing"].GroupBy = nil
+	tables["grouped"].Loading = false
+	tables["grouped"].Editing = nil
+
+	for name, dt := range tables {
+		if name != "unstyled" {
+			dt.SetStyled(true)
+		}
+		data, err := json.Marshal(dt)
+		if err != nil {
+			t.Fatalf("%s: failed to marshal: %v", name, err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatalf("%s: failed to unmarshal: %v", name, err)
+		}
+		var decoded DataTable
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s: failed to unmarshal: %v", name, err)
+		}
+
+		want := renderTemplate(t, dt)
+		if got := renderTemplate(t, m); got != want {
+			t.Errorf("%s: map rendering differs\n got: %s\nwant: %s", name, got, want)
+		}
+		if got := renderTemplate(t, &decoded); got != want {
+			t.Errorf("%s: decoded table rendering differs\n got: %s\nwant: %s", name, got, want)
+		}
+	}
+
+	// Hand-written maps may only set the legacy sort fields.
+	m := map[string]interface{}{
+		"id":             "legacy",
+		"styled":         true,
+		"SortColumn":     "name",
+		"SortDirection":  float64(SortDesc),
+		"VisibleColumns": []interface{}{map[string]interface{}{"ID": "name", "Label": "Name", "Sortable": true}},
+	}
+	html := renderTemplate(t, m)
+	for _, want := range []string{`data-datatable="legacy"`, `class="overflow-hidden"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected legacy map output to contain %q:\n%s", want, html)
+		}
+	}
+	m["styled"] = false
+	if html := renderTemplate(t, m); !strings.Contains(html, "↓") {
+		t.Errorf("expected the legacy sort direction to be shown:\n%s", html)
+	}
+}
+
+func TestVirtualRows(t *testing.T) {
+	dt := pagedTestTable(1000, WithPageSize(0), WithVirtualRows(20, 30))
+
+	rows := dt.WindowRows()
+	if len(rows) != 25 || rows[0].ID != "1" {
+		t.Fatalf("expected rows 1-25 at the top, got %d from %s", len(rows), rows[0].ID)
+	}
+	if dt.Window.Total != 1000 || dt.Window.After() != 975*30 {
+		t.Errorf("unexpected window %+v", *dt.Window)
+	}
+
+	dt.ScrollTo(500)
+	if rows := dt.WindowRows(); len(rows) != 30 || rows[0].ID != "496" {
+		t.Errorf("expected rows from 496, got %d from %s", len(rows), rows[0].ID)
+	}
+	if dt.Window.Before() != 495*30 {
+		t.Errorf("expected before spacer %d, got %d", 495*30, dt.Window.Before())
+	}
+
+	if err := dt.Actions()["scroll"](base.NewActionContext("scroll", dt.ID(), map[string]string{"offset": "5000"})); err != nil {
+		t.Fatalf("scroll returned error: %v", err)
+	}
+	if dt.Window.Offset != 980 {
+		t.Errorf("expected offset clamped to 980, got %d", dt.Window.Offset)
+	}
+
+	dt.SetFilter("99")
+	if dt.Window.Offset != 0 {
+		t.Errorf("expected filtering to scroll to the top, got %d", dt.Window.Offset)
+	}
+	if got := len(dt.WindowRows()); got != 19 || dt.Window.Total != 19 {
+		t.Errorf("expected 19 filtered rows, got %d of %d", got, dt.Window.Total)
+	}
+}
+
+func TestInfiniteScroll(t *testing.T) {
+	dt := pagedTestTable(25, WithInfiniteScroll(true))
+	if got := len(dt.GetPageRows()); got != 10 {
+		t.Fatalf("expected the first page, got %d rows", got)
+	}
+
+	if !dt.LoadMore() || !dt.LoadMore() {
+		t.Fatal("expected LoadMore to add pages")
+	}
+	if got := rowIDs(dt.GetPageRows()); len(got) != 25 || got[0] != "1" {
+		t.Errorf("expected every row from 1, got %d rows", len(got))
+	}
+	if dt.LoadMore() {
+		t.Error("expected LoadMore to stop at the last page")
+	}
+	if dt.StartIndex() != 1 || dt.EndIndex() != 25 {
+		t.Errorf("expected rows 1-25, got %d-%d", dt.StartIndex(), dt.EndIndex())
+	}
+
+	dt.SetFilter("1")
+	if dt.Page != 0 || len(dt.GetPageRows()) != 10 {
+		t.Errorf("expected filtering to return to the first page, got page %d", dt.Page)
+	}
+
+	dt = New("off", WithPageSize(10))
+	if dt.LoadMore() {
+		t.Error("expected LoadMore to do nothing outside infinite mode")
+	}
+}
+
+func TestInfiniteScrollDataSource(t *testing.T) {
+	rows := pagedTestTable(35).Rows
+	source := &countingSource{DataSource: NewMemorySource(rows, nil)}
+	dt := New("feed",
+		WithPageSize(10),
+		WithInfiniteScroll(true),
+		WithVirtualRows(5, 20),
+		WithDataSource(source),
+	)
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	scroll := func(offset string) {
+		t.Helper()
+		if err := dt.Actions()["scroll"](base.NewActionContext("scroll", dt.ID(), map[string]string{"offset": offset})); err != nil {
+			t.Fatalf("scroll returned error: %v", err)
+		}
+	}
+
+	scroll("2")
+	if dt.Page != 0 || source.queries != 1 {
+		t.Errorf("expected no load before the bottom, got page %d after %d queries", dt.Page, source.queries)
+	}
+	scroll("5")
+	if dt.Page != 1 || len(dt.Rows) != 20 || source.queries != 2 {
+		t.Errorf("expected page 1 appended, got page %d with %d rows after %d queries", dt.Page, len(dt.Rows), source.queries)
+	}
+
+	if err := dt.Actions()["load_more"](base.NewActionContext("load_more", dt.ID(), nil)); err != nil {
+		t.Fatalf("load_more returned error: %v", err)
+	}
+	if err := dt.Actions()["load_more"](base.NewActionContext("load_more", dt.ID(), nil)); err != nil {
+		t.Fatalf("load_more returned error: %v", err)
+	}
+	if got := rowIDs(dt.Rows); len(got) != 35 || got[34] != "35" || dt.HasNextPage() {
+		t.Errorf("expected all 35 rows loaded, got %d", len(got))
+	}
+
+	// Sorting reloads every page shown so far in one query.
+	queries := source.queries
+	dt.Sort("n")
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if dt.Page != 3 || len(dt.Rows) != 35 || source.queries != queries+1 {
+		t.Errorf("expected 35 rows reloaded in one query, got %d after %d queries", len(dt.Rows), source.queries-queries)
+	}
+}
+
+func TestTemplateVirtualRows(t *testing.T) {
+	dt := pagedTestTable(100, WithPageSize(50), WithInfiniteScroll(true), WithVirtualRows(10, 40), WithSelectable(true))
+	dt.ScrollTo(10)
+
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t,
+			`lvt-scroll="scroll_paged"`,
+			"max-height: 400px",
+			`style="height: 200px"`,
+			`style="height: 1000px"`,
+			`lvt-data-row="6"`,
+			`lvt-data-row="25"`,
+			`lvt-click="load_more_paged"`,
+		)
+		r.excludes(t, `lvt-data-row="5"`, `lvt-data-row="26"`, `lvt-click="next_page_`)
+	}
+}
+
+func facetTestTable(opts ...Option) *DataTable {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	rows := []Row{
+		{ID: "1", Data: map[string]any{"status": "open", "priority": "high", "points": 3, "due": day(1)}},
+		{ID: "2", Data: map[string]any{"status": "closed", "priority": "low", "points": 8, "due": day(5)}},
+		{ID: "3", Data: map[string]any{"status": "Open", "priority": "low", "points": 5, "due": day(9)}},
+		{ID: "4", Data: map[string]any{"status": "blocked", "priority": "high", "points": 13, "due": day(20)}},
+		{ID: "5", Data: map[string]any{"status": "open", "priority": nil, "points": 1, "due": day(3)}},
+	}
+	columns := []Column{
+		{ID: "status", Label: "Status", Filterable: FilterSpec{Kind: FilterFacet}},
+		{ID: "priority", Label: "Priority", Filterable: FilterSpec{Kind: FilterFacet, Options: []FilterOption{
+			{Value: "high", Label: "High"}, {Value: "medium", Label: "Medium"}, {Value: "low", Label: "Low"},
+		}}},
+		{ID: "points", Label: "Points", Filterable: FilterSpec{Kind: FilterNumber}},
+		{ID: "due", Label: "Due", Filterable: FilterSpec{Kind: FilterDate}},
+	}
+	return New("tasks", append([]Option{WithColumns(columns), WithRows(rows)}, opts...)...)
+}
+
+func facetValues(f Facet) string {
+	var parts []string
+	for _, v := range f.Values {
+		s := fmt.Sprintf("%s=%d", v.Label, v.Count)
+		if v.Selected {
+			s += "*"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestFacets(t *testing.T) {
+	dt := facetTestTable()
+
+	facets := dt.Facets()
+	if len(facets) != 4 {
+		t.Fatalf("expected 4 facets, got %d", len(facets))
+	}
+	if got := facetValues(facets[0]); got != "open=3 blocked=1 closed=1" {
+		t.Errorf("unexpected status facet %q", got)
+	}
+	if got := facetValues(facets[1]); got != "High=2 Medium=0 Low=2" {
+		t.Errorf("unexpected priority facet %q", got)
+	}
+	if f := facets[2]; f.Min != 1 || f.Max != 13 || f.Low != 1 || f.High != 13 || f.Active {
+		t.Errorf("unexpected points facet %+v", f)
+	}
+	if f := facets[3]; f.LowLabel != "2024-01-01" || f.HighLabel != "2024-01-20" || f.Max-f.Min != 19 {
+		t.Errorf("unexpected due facet %+v", f)
+	}
+
+	// Each facet counts the rows matching the other filters.
+	dt.ToggleFacetValue("priority", "low")
+	dt.ToggleFacetValue("status", "OPEN")
+	if got := rowIDs(dt.GetFilteredRows()); len(got) != 1 || got[0] != "3" {
+		t.Fatalf("expected row 3, got %v", got)
+	}
+	facets = dt.Facets()
+	if got := facetValues(facets[0]); got != "closed=1 Open=1*" {
+		t.Errorf("expected status counts among low priority rows, got %q", got)
+	}
+	if got := facetValues(facets[1]); got != "High=1 Medium=0 Low=1*" {
+		t.Errorf("expected priority counts among open rows, got %q", got)
+	}
+	if f := facets[2]; f.Min != 5 || f.Max != 5 {
+		t.Errorf("expected points bounds of the matching row, got %v-%v", f.Min, f.Max)
+	}
+
+	dt.ToggleFacetValue("status", "open")
+	if dt.GetColumnFilter("status") != nil {
+		t.Error("expected deselecting the last value to remove the filter")
+	}
+
+	dt.GetColumn("status").Hidden = true
+	if got := len(dt.Facets()); got != 3 {
+		t.Errorf("expected hidden columns to have no facet, got %d facets", got)
+	}
+}
+
+func TestFacetRange(t *testing.T) {
+	dt := facetTestTable()
+	dt.Rows[2].Data["due"] = time.Date(2024, 1, 9, 15, 30, 0, 0, time.UTC)
+
+	dt.SetFacetRange("points", 4, 13)
+	if f := dt.GetColumnFilter("points"); f == nil || f.Operator != FilterRange || f.Min != 4.0 || f.Max != nil {
+		t.Fatalf("expected an open-ended range filter, got %+v", f)
+	}
+	if got := rowIDs(dt.GetFilteredRows()); len(got) != 3 {
+		t.Errorf("expected 3 rows with 4+ points, got %v", got)
+	}
+
+	// Bounds cover the rows matching the other filters.
+	if due := dt.facet("due"); due.LowLabel != "2024-01-05" {
+		t.Errorf("expected due dates from 2024-01-05, got %s", due.LowLabel)
+	}
+	dt.RemoveColumnFilter("points")
+
+	due := dt.facet("due")
+	dt.SetFacetRange("due", due.Min+2, due.Min+8)
+	if f := dt.GetColumnFilter("due"); f.Min != "2024-01-03" || f.Max != "2024-01-09T23:59:59.999999999Z" {
+		t.Errorf("expected date bounds covering the last day, got %v-%v", f.Min, f.Max)
+	}
+	// Row 3 is due in the afternoon of the last day.
+	if got := rowIDs(dt.GetFilteredRows()); strings.Join(got, " ") != "2 3 5" {
+		t.Errorf("expected rows 2, 3 and 5, got %v", got)
+	}
+	if f := dt.facet("due"); !f.Active || f.LowLabel != "2024-01-03" || f.HighLabel != "2024-01-09" {
+		t.Errorf("unexpected due facet %+v", f)
+	}
+	if chips := dt.FilterChips(); len(chips) != 1 || chips[0].Text != "2024-01-03–2024-01-09" {
+		t.Errorf("expected a date range chip, got %+v", chips)
+	}
+
+	dt.SetFacetRange("points", 0, 100)
+	if dt.GetColumnFilter("points") != nil {
+		t.Error("expected a full range to remove the filter")
+	}
+}
+
+func TestFilterChips(t *testing.T) {
+	dt := facetTestTable(WithFilter("o"))
+	dt.ToggleFacetValue("priority", "high")
+	dt.ToggleFacetValue("priority", "low")
+	dt.SetFacetRange("points", 4, 10)
+
+	var got []string
+	for _, c := range dt.FilterChips() {
+		got = append(got, c.Column+"|"+c.Label+"|"+c.Text+"|"+c.Value)
+	}
+	want := []string{"|Search|o|", "priority|Priority|High|high", "priority|Priority|Low|low", "points|Points|4–10|"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected chips %v, got %v", want, got)
+	}
+
+	dt.RemoveFilter("priority", "high")
+	if f := dt.GetColumnFilter("priority"); f == nil || len(f.Values) != 1 {
+		t.Errorf("expected one priority value left, got %+v", f)
+	}
+	dt.RemoveFilter("", "")
+	if dt.FilterValue != "" {
+		t.Error("expected the text filter to be removed")
+	}
+	dt.ClearAllFilters()
+	if dt.IsFiltered() || dt.FilterChips() != nil {
+		t.Error("expected every filter to be removed")
+	}
+}
+
+func TestFacetActions(t *testing.T) {
+	dt := facetTestTable(WithPageSize(2))
+	dt.GoToPage(1)
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		if err := actions[name](base.NewActionContext(name, dt.ID(), data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+
+	run("toggle_facet_menu", map[string]string{"column": "status"})
+	if dt.OpenFacet != "status" {
+		t.Errorf("expected the status menu open, got %q", dt.OpenFacet)
+	}
+	run("toggle_facet", map[string]string{"column": "status", "value": "open"})
+	if dt.Page != 0 || dt.TotalRows() != 3 {
+		t.Errorf("expected 3 open rows on page 0, got %d on page %d", dt.TotalRows(), dt.Page)
+	}
+	run("close_facet_menu", nil)
+	if dt.OpenFacet != "" {
+		t.Error("expected the menu closed")
+	}
+
+	run("filter_range", map[string]string{"column": "points", "bound": "min", "value": "2"})
+	run("filter_range", map[string]string{"column": "points", "bound": "max", "value": "4"})
+	if got := rowIDs(dt.GetFilteredRows()); len(got) != 1 || got[0] != "1" {
+		t.Errorf("expected row 1, got %v", got)
+	}
+
+	run("remove_filter", map[string]string{"column": "points"})
+	if dt.GetColumnFilter("points") != nil {
+		t.Error("expected the points filter removed")
+	}
+	run("clear_filters", nil)
+	if dt.IsFiltered() {
+		t.Error("expected no filters")
+	}
+}
+
+func TestFacetsDataSource(t *testing.T) {
+	rows := facetTestTable().Rows
+	columns := facetTestTable().Columns
+
+	dt := New("tasks", WithColumns(columns), WithPageSize(2), WithDataSource(NewMemorySource(rows, columns)))
+	dt.ToggleFacetValue("status", "open")
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := facetValues(dt.Facets()[0]); got != "open=3* blocked=1 closed=1" {
+		t.Errorf("unexpected status facet %q", got)
+	}
+	if got := facetValues(dt.Facets()[1]); got != "High=1 Medium=0 Low=1" {
+		t.Errorf("unexpected priority facet %q", got)
+	}
+
+	// The decoded table keeps the loaded facets.
+	data, err := json.Marshal(dt)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var decoded DataTable
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Facets(), dt.Facets()) {
+		t.Errorf("expected decoded facets %+v, got %+v", dt.Facets(), decoded.Facets())
+	}
+
+	// Sources that are not a FacetSource list the spec's options without counts.
+	dt = New("tasks", WithColumns(columns), WithDataSource(&countingSource{DataSource: NewMemorySource(rows, columns)}))
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	facets := dt.Facets()
+	if facets[0].Values != nil || facets[1].Counted || len(facets[1].Values) != 3 {
+		t.Errorf("expected facets without counts, got %+v", facets[:2])
+	}
+}
+
+func TestTemplateFacets(t *testing.T) {
+	dt := facetTestTable()
+	dt.ToggleFacetValue("status", "open")
+	dt.SetFacetRange("points", 2, 13)
+	dt.OpenFacet = "status"
+
+	for _, r := range renderVariants(t, dt) {
+		chip := "Points:</span> ≥ 2"
+		if !r.styled {
+			chip = "Points: ≥ 2"
+		}
+		r.contains(t,
+			`lvt-click="toggle_facet_menu_tasks" lvt-data-column="status"`,
+			`aria-expanded="true"`,
+			`aria-selected="true" lvt-click="toggle_facet_tasks" lvt-data-column="status" lvt-data-value="open"`,
+			`lvt-data-value="blocked"`,
+			`min="1" max="5" step="1" value="2" lvt-change="filter_range_tasks" lvt-data-column="points" lvt-data-bound="min"`,
+			`value="5" lvt-change="filter_range_tasks" lvt-data-column="points" lvt-data-bound="max"`,
+			"2024-01-01 – 2024-01-09",
+			`lvt-click="remove_filter_tasks" lvt-data-column="status" lvt-data-value="open"`,
+			chip,
+			`lvt-click="clear_filters_tasks"`,
+		)
+		r.excludes(t, `lvt-data-column="priority" lvt-data-value`)
+	}
+}
+
+// rowActionTestTable returns a table whose "delete" row action is disabled for
+// admins, recording the actions run in *ran.
+func rowActionTestTable(ran *[]string) *DataTable {
+	record := func(name string) func(context.Context, Row) error {
+		return func(_ context.Context, row Row) error {
+			*ran = append(*ran, name+":"+row.ID)
+			return nil
+		}
+	}
+	return New("users",
+		WithColumns([]Column{{ID: "name", Label: "Name"}, {ID: "role", Label: "Role"}}),
+		WithRows([]Row{
+			{ID: "1", Data: map[string]any{"name": "Ada", "role": "admin"}},
+			{ID: "2", Data: map[string]any{"name": "Bob", "role": "user"},
+				Children: []Row{{ID: "2a", Data: map[string]any{"name": "Bob Jr", "role": "user"}}}},
+		}),
+		WithRowActions(
+			RowAction{Name: "edit", Label: "Edit", Handler: record("edit")},
+			RowAction{Name: "delete", Label: "Delete", Handler: record("delete"),
+				Enabled: func(row Row) bool { return row.Data["role"] != "admin" }},
+		),
+		WithOnRowClick(record("click")),
+		WithOnRowOpen(record("open")),
+	)
+}
+
+func TestRowActions(t *testing.T) {
+	ctx := context.Background()
+	var ran []string
+	dt := rowActionTestTable(&ran)
+
+	items := dt.RowMenu(dt.Rows[0])
+	if len(items) != 2 || items[0].ID != "edit" || items[0].Disabled || items[1].ID != "delete" || !items[1].Disabled {
+		t.Errorf("expected delete to be disabled for admins, got %+v", items)
+	}
+	if items := dt.RowMenu(dt.Rows[1]); items[1].Disabled {
+		t.Errorf("expected delete to be enabled for users, got %+v", items)
+	}
+
+	dt.ToggleRowMenu("1")
+	if !dt.IsRowMenuOpen("1") || dt.IsRowMenuOpen("2") {
+		t.Errorf("expected only row 1's menu to be open, got %q", dt.OpenRowMenu)
+	}
+	dt.ToggleRowMenu("2")
+	if dt.OpenRowMenu != "2" {
+		t.Errorf("expected opening row 2's menu to close row 1's, got %q", dt.OpenRowMenu)
+	}
+
+	if err := dt.RunRowAction(ctx, "delete", "2a"); err != nil {
+		t.Fatalf("RunRowAction returned error: %v", err)
+	}
+	if dt.OpenRowMenu != "" {
+		t.Errorf("expected running an action to close the menu, got %q", dt.OpenRowMenu)
+	}
+	if err := dt.RunRowAction(ctx, "delete", "1"); err == nil {
+		t.Error("expected a disabled action to fail")
+	}
+	if err := dt.RunRowAction(ctx, "archive", "1"); err == nil {
+		t.Error("expected an unknown action to fail")
+	}
+	if err := dt.RunRowAction(ctx, "edit", "9"); err == nil {
+		t.Error("expected an unknown row to fail")
+	}
+	if want := []string{"delete:2a"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("expected %v to run, got %v", want, ran)
+	}
+
+	if items := New("plain").RowMenu(dt.Rows[0]); items != nil {
+		t.Errorf("expected no menu without row actions, got %+v", items)
+	}
+}
+
+func TestRowActionHandlers(t *testing.T) {
+	var ran []string
+	dt := rowActionTestTable(&ran)
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) error {
+		return actions[name](base.NewActionContext(name, dt.ID(), data))
+	}
+
+	if err := run("toggle_row_menu", map[string]string{"row": "2"}); err != nil || dt.OpenRowMenu != "2" {
+		t.Errorf("expected toggle_row_menu to open row 2's menu, got %q (%v)", dt.OpenRowMenu, err)
+	}
+	if err := run("close_row_menu", nil); err != nil || dt.OpenRowMenu != "" {
+		t.Errorf("expected close_row_menu to close the menu, got %q (%v)", dt.OpenRowMenu, err)
+	}
+	for _, step := range []struct {
+		name string
+		data map[string]string
+	}{
+		{"row_action", map[string]string{"row": "2", "action": "edit"}},
+		{"click", map[string]string{"row": "1"}},
+		{"open", map[string]string{"row": "2a"}},
+	} {
+		if err := run(step.name, step.data); err != nil {
+			t.Fatalf("%s returned error: %v", step.name, err)
+		}
+	}
+	if want := []string{"edit:2", "click:1", "open:2a"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("expected %v to run, got %v", want, ran)
+	}
+	if err := run("click", map[string]string{"row": "9"}); err == nil {
+		t.Error("expected clicking an unknown row to fail")
+	}
+
+	plain := New("plain", WithRows(dt.Rows))
+	for _, name := range []string{"click", "open"} {
+		if err := plain.Actions()[name](base.NewActionContext(name, "plain", map[string]string{"row": "1"})); err == nil {
+			t.Errorf("expected %s to fail without a handler", name)
+		}
+	}
+	if plain.RowClickEnabled || plain.RowOpenEnabled {
+		t.Error("expected row clicks to be disabled without handlers")
+	}
+}
+
+func TestRowActionsJSON(t *testing.T) {
+	var ran []string
+	data, err := json.Marshal(rowActionTestTable(&ran))
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var decoded DataTable
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if items := decoded.RowMenu(decoded.Rows[0]); len(items) != 2 || !items[1].Disabled {
+		t.Errorf("expected the encoded menu with delete disabled, got %+v", items)
+	}
+	again, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatalf("failed to marshal decoded table: %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("JSON differs after a round trip\n got: %s\nwant: %s", again, data)
+	}
+}
+
+func TestTemplateRowActions(t *testing.T) {
+	var ran []string
+	dt := rowActionTestTable(&ran)
+	dt.OpenRowMenu = "1"
+
+	colspan := Templates().Funcs["dtColspan"].(func(interface{}) int)
+	for _, r := range renderVariants(t, dt) {
+		r.contains(t,
+			`lvt-data-row="1" lvt-click="click_users" lvt-dblclick="open_users" lvt-keydown="open_users" lvt-key="Enter" tabindex="0"`,
+			`lvt-click="toggle_row_menu_users" lvt-data-row="1" aria-haspopup="true" aria-expanded="true"`,
+			`lvt-click="toggle_row_menu_users" lvt-data-row="2" aria-haspopup="true" aria-expanded="false"`,
+			`lvt-click-away="close_row_menu_users"`,
+			`lvt-click="row_action_users" lvt-data-row="1" lvt-data-action="edit"`,
+			`Actions</`,
+		)
+		r.excludes(t, `lvt-data-action="delete"`)
+		if n := colspan(r.v); n != 3 {
+			t.Errorf("%s: expected the actions column in the colspan, got %d", r.name, n)
+		}
+		if n := strings.Count(r.html, `role="menu"`); n != 1 {
+			t.Errorf("%s: expected one open menu, got %d", r.name, n)
+		}
+	}
+}
+
+func TestRowLookup(t *testing.T) {
+	dt := pagedTestTable(5, WithSelectable(true), WithMultiSelect(true))
+	if row := dt.rowByID("3"); row != &dt.Rows[2] {
+		t.Errorf("expected row 3 at index 2, got %v", row)
+	}
+	if row := dt.rowByID("9"); row != nil {
+		t.Errorf("expected nil for an unknown row, got %v", row)
+	}
+
+	dt.SetData([]Row{{ID: "b"}, {ID: "a"}, {ID: "a"}})
+	if row := dt.rowByID("a"); row != &dt.Rows[1] {
+		t.Error("expected the first duplicate row after SetData")
+	}
+
+	// Replacing Rows directly or changing an ID in place rebuilds the lookup.
+	dt.Rows = []Row{{ID: "x"}, {ID: "y"}, {ID: "z"}}
+	if row := dt.rowByID("z"); row != &dt.Rows[2] {
+		t.Error("expected row z at index 2 after replacing Rows")
+	}
+	dt.Rows[0].ID = "w"
+	if row := dt.rowByID("x"); row != nil {
+		t.Errorf("expected the renamed row to be gone, got %v", row)
+	}
+	if row := dt.rowByID("w"); row != &dt.Rows[0] {
+		t.Error("expected the renamed row at index 0")
+	}
+	if dt.isTree() {
+		t.Error("expected a flat table")
+	}
+	dt.SetData([]Row{{ID: "p", Children: []Row{{ID: "c", Children: []Row{{ID: "g"}}}}}})
+	if !dt.isTree() {
+		t.Error("expected a tree table after SetData")
+	}
+	if row := dt.rowByID("g"); row != &dt.Rows[0].Children[0].Children[0] {
+		t.Errorf("expected nested rows to be found, got %v", row)
+	}
+}
+
+func TestSelectionKeepsView(t *testing.T) {
+	dt := pagedTestTable(30, WithSelectable(true), WithMultiSelect(true))
+	dt.SetFilter("1")
+	dt.Sort("n")
+	page := rowIDs(dt.GetPageRows())
+	view := dt.view
+
+	dt.SelectRow(page[0])
+	dt.ToggleRowSelection(page[1])
+	if &dt.view[0] != &view[0] {
+		t.Error("expected selecting rows to keep the filtered and sorted view")
+	}
+	if got := dt.GetPageRows(); !got[0].Selected || !got[1].Selected || got[2].Selected {
+		t.Errorf("expected the first two page rows to be selected, got %+v", got[:3])
+	}
+	if dt.SelectedCount() != 2 || dt.AllSelected() {
+		t.Errorf("expected 2 selected and not all, got %d (all=%v)", dt.SelectedCount(), dt.AllSelected())
+	}
+
+	dt.SelectAll()
+	if !dt.AllSelected() {
+		t.Error("expected every page row to be selected")
+	}
+	dt.NextPage()
+	dt.DeselectRow(rowIDs(dt.GetPageRows())[0])
+	if dt.AllSelected() {
+		t.Error("expected the cached AllSelected to follow the page")
+	}
+
+	dt.SelectAllMatching()
+	dt.DeselectRow(page[0])
+	if want := dt.MatchingCount() - 1; dt.SelectedCount() != want {
+		t.Errorf("expected %d selected, got %d", want, dt.SelectedCount())
+	}
+	dt.SetFilter("2")
+	if dt.SelectedCount() != 0 {
+		t.Errorf("expected a filter change to clear the cached count, got %d", dt.SelectedCount())
+	}
+
+	single := pagedTestTable(5, WithSelectable(true))
+	single.SelectRow("2")
+	single.SelectRow("4")
+	if got := single.GetSelectedRows(); len(got) != 1 || got[0].ID != "4" || single.Rows[1].Selected {
+		t.Errorf("expected only row 4 to be selected, got %+v", got)
+	}
+}
+
+// benchSizes are the row counts the benchmarks run at.
+var benchSizes = []int{1_000, 10_000, 100_000}
+
+// benchTable returns a selectable table of n rows with text, number and
+// category columns.
+func benchTable(n int) *DataTable {
+	regions := []string{"north", "south", "east", "west", "central"}
+	rows := make([]Row, n)
+	for i := range rows {
+		rows[i] = Row{ID: fmt.Sprint(i + 1), Data: map[string]any{
+			"name":   fmt.Sprintf("user %06d", i),
+			"score":  (i * 7919) % 1000,
+			"region": regions[i%len(regions)],
+		}}
+	}
+	return New("bench",
+		WithColumns([]Column{
+			{ID: "name", Label: "Name", Sortable: true, Filterable: FilterSpec{Kind: FilterText}},
+			{ID: "score", Label: "Score", Sortable: true},
+			{ID: "region", Label: "Region", Sortable: true, Filterable: FilterSpec{Kind: FilterFacet}},
+		}),
+		WithRows(rows),
+		WithPageSize(25),
+		WithMultiSelect(true),
+	)
+}
+
+func BenchmarkSort(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			dt := benchTable(n)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				dt.Sort("score")
+				dt.GetPageRows()
+			}
+		})
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			dt := benchTable(n)
+			dt.Sort("score")
+			filters := []string{"user 00", "7"}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				dt.SetFilter(filters[i%len(filters)])
+				dt.GetPageRows()
+			}
+		})
+	}
+}
+
+func BenchmarkPaging(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			dt := benchTable(n)
+			dt.Sort("score")
+			dt.SetFilter("user")
+			pages := dt.TotalPages() // filters and sorts once
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				dt.GoToPage(i % pages)
+				dt.GetPageRows()
+				dt.PageInfo()
+			}
+		})
+	}
+}
+
+func BenchmarkSelection(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			dt := benchTable(n)
+			dt.Sort("score")
+			dt.SetFilter("user")
+			dt.GetPageRows() // filter and sort once
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// A click, then the values the template shows.
+				dt.ToggleRowSelection(fmt.Sprint(i%n + 1))
+				dt.SelectedCount()
+				dt.AllSelected()
+				dt.GetPageRows()
+			}
+		})
+	}
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("matching/%d", n), func(b *testing.B) {
+			dt := benchTable(n)
+			dt.SetFilter("user")
+			dt.SelectAllMatching()
+			dt.SelectedCount()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				dt.ToggleRowSelection(fmt.Sprint(i%n + 1))
+				dt.SelectedCount()
+				dt.SelectedCount()
+			}
+		})
+	}
+}
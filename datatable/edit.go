@@ -0,0 +1,247 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EditorKind selects the input used to edit a cell.
+type EditorKind string
+
+const (
+	// EditorText edits the cell as a string (the default).
+	EditorText EditorKind = "text"
+	// EditorNumber edits the cell as a number. Integer cells stay integers.
+	EditorNumber EditorKind = "number"
+	// EditorSelect picks the value from Column.EditOptions.
+	EditorSelect EditorKind = "select"
+	// EditorDate edits the cell as a date (YYYY-MM-DD).
+	EditorDate EditorKind = "date"
+	// EditorToggle edits the cell as a checkbox, committed on click.
+	EditorToggle EditorKind = "toggle"
+)
+
+// editDateLayout is the layout used by HTML date inputs.
+const editDateLayout = "2006-01-02"
+
+// EditOption is a choice for an EditorSelect column.
+type EditOption struct {
+	// Value is the cell value
+	Value string
+	// Label is the display text (defaults to Value)
+	Label string
+}
+
+// Validator checks an edited cell value before it is committed. The returned
+// error's message is shown next to the cell.
+type Validator func(value any) error
+
+// CellEdit is the cell currently being edited.
+type CellEdit struct {
+	// RowID is the edited row
+	RowID string
+	// ColumnID is the edited column
+	ColumnID string
+	// Value is the editor's text
+	Value string
+	// Error is the message from the last rejected commit
+	Error string
+}
+
+// errNoEdit is returned by CommitEdit when no cell is being edited.
+var errNoEdit = errors.New("datatable: no cell is being edited")
+
+// EditorKind returns the column's editor, or "" if the column is not editable.
+func (c Column) EditorKind() EditorKind {
+	if !c.Editable {
+		return ""
+	}
+	if c.Editor == "" {
+		return EditorText
+	}
+	return c.Editor
+}
+
+// BeginEdit starts editing a cell, discarding any other edit in progress.
+// The column must be Editable and the row must be loaded.
+func (dt *DataTable) BeginEdit(rowID, columnID string) error {
+	col := dt.GetColumn(columnID)
+	if col == nil || !col.Editable {
+		return fmt.Errorf("datatable: column %q is not editable", columnID)
+	}
+	row := dt.rowByID(rowID)
+	if row == nil {
+		return fmt.Errorf("datatable: unknown row %q", rowID)
+	}
+
+	dt.Editing = &CellEdit{
+		RowID:    rowID,
+		ColumnID: columnID,
+		Value:    editText(col.EditorKind(), row.GetCellValue(columnID)),
+	}
+	return nil
+}
+
+// CommitEdit parses value with the column's editor, validates it and passes it
+// to the WithOnCellEdit handler, then stores it in the row (and, in a Typed
+// table, in the item's field). If any step fails, the edit stays open with the
+// error in Editing.Error and the error is returned.
+func (dt *DataTable) CommitEdit(value string) error {
+	edit := dt.Editing
+	if edit == nil {
+		return errNoEdit
+	}
+	col := dt.GetColumn(edit.ColumnID)
+	row := dt.rowByID(edit.RowID)
+	if col == nil || row == nil {
+		dt.Editing = nil
+		return fmt.Errorf("datatable: cell %q/%q no longer exists", edit.RowID, edit.ColumnID)
+	}
+
+	edit.Value = value
+	oldValue := row.GetCellValue(col.ID)
+	newValue, err := parseEditValue(*col, oldValue, value)
+	if err == nil && col.Validator != nil {
+		err = col.Validator(newValue)
+	}
+	if err == nil && dt.onCellEdit != nil {
+		err = dt.onCellEdit(edit.RowID, col.ID, oldValue, newValue)
+	}
+	if err != nil {
+		edit.Error = err.Error()
+		return err
+	}
+
+	if row.Data == nil {
+		row.Data = make(map[string]any)
+	}
+	row.Data[col.ID] = newValue
+	if dt.cellEdited != nil {
+		dt.cellEdited(edit.RowID, col.ID, newValue)
+	}
+	dt.Editing = nil
+	dt.resetView()
+	return nil
+}
+
+// CancelEdit discards the edit in progress.
+func (dt *DataTable) CancelEdit() {
+	dt.Editing = nil
+}
+
+// IsEditing returns true if a cell is being edited.
+func (dt *DataTable) IsEditing() bool {
+	return dt.Editing != nil
+}
+
+// IsEditingCell returns true if the given cell is being edited.
+func (dt *DataTable) IsEditingCell(rowID, columnID string) bool {
+	return dt.Editing != nil && dt.Editing.RowID == rowID && dt.Editing.ColumnID == columnID
+}
+
+// editText returns a cell value as editor text.
+func editText(kind EditorKind, v any) string {
+	switch kind {
+	case EditorNumber:
+		if f, ok := toFloat(v); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	case EditorDate:
+		if t, ok := toTime(v, true); ok {
+			return t.Format(editDateLayout)
+		}
+	case EditorToggle:
+		if b, ok := toBool(v); ok {
+			return strconv.FormatBool(b)
+		}
+	}
+	return cellString(v)
+}
+
+// parseEditValue converts editor text to a cell value. Numbers keep the type
+// of an existing integer or float cell; empty number and date inputs clear
+// the cell.
+func parseEditValue(col Column, oldValue any, text string) (any, error) {
+	switch col.EditorKind() {
+	case EditorNumber:
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return nil, nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, errors.New("must be a number")
+		}
+		return convertNumber(f, oldValue)
+	case EditorDate:
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return nil, nil
+		}
+		loc := time.UTC
+		if t, ok := oldValue.(time.Time); ok {
+			loc = t.Location()
+		}
+		t, err := time.ParseInLocation(editDateLayout, text, loc)
+		if err != nil {
+			return nil, errors.New("must be a date (YYYY-MM-DD)")
+		}
+		return t, nil
+	case EditorToggle:
+		b, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return b, nil
+	case EditorSelect:
+		if len(col.EditOptions) == 0 {
+			return text, nil
+		}
+		for _, opt := range col.EditOptions {
+			if opt.Value == text {
+				return text, nil
+			}
+		}
+		return nil, errors.New("is not a valid option")
+	}
+	return text, nil
+}
+
+// convertNumber converts f to the numeric type of oldValue, defaulting to
+// float64.
+func convertNumber(f float64, oldValue any) (any, error) {
+	if oldValue == nil {
+		return f, nil
+	}
+	v := reflect.ValueOf(oldValue)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f != math.Trunc(f) {
+			return nil, errors.New("must be a whole number")
+		}
+		n := reflect.New(v.Type()).Elem()
+		if f < math.MinInt64 || f >= math.MaxInt64 || n.OverflowInt(int64(f)) {
+			return nil, errors.New("is out of range")
+		}
+		n.SetInt(int64(f))
+		return n.Interface(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if f != math.Trunc(f) || f < 0 {
+			return nil, errors.New("must be a whole number of at least 0")
+		}
+		n := reflect.New(v.Type()).Elem()
+		if f >= math.MaxUint64 || n.OverflowUint(uint64(f)) {
+			return nil, errors.New("is out of range")
+		}
+		n.SetUint(uint64(f))
+		return n.Interface(), nil
+	case reflect.Float32:
+		return reflect.ValueOf(f).Convert(v.Type()).Interface(), nil
+	}
+	return f, nil
+}
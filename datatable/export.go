@@ -0,0 +1,285 @@
+package datatable
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/livetemplate/components/base"
+)
+
+// ExportFormat is a file format for Export.
+type ExportFormat string
+
+const (
+	// ExportCSV writes comma-separated values with a header row.
+	ExportCSV ExportFormat = "csv"
+	// ExportTSV writes tab-separated values with a header row.
+	ExportTSV ExportFormat = "tsv"
+	// ExportJSON writes an array of objects keyed by column label.
+	ExportJSON ExportFormat = "json"
+)
+
+// ContentType returns the MIME type for the format.
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportCSV:
+		return "text/csv; charset=utf-8"
+	case ExportTSV:
+		return "text/tab-separated-values; charset=utf-8"
+	case ExportJSON:
+		return "application/json"
+	}
+	return "application/octet-stream"
+}
+
+// ExportScope selects which rows Export writes.
+type ExportScope string
+
+const (
+	// ExportPage exports the rows on the current page.
+	ExportPage ExportScope = "page"
+	// ExportFiltered exports every row matching the current filters.
+	ExportFiltered ExportScope = "filtered"
+	// ExportSelected exports the selected rows.
+	ExportSelected ExportScope = "selected"
+)
+
+// ExportRequest describes an export triggered by the "export" action.
+type ExportRequest struct {
+	// Format is the requested file format
+	Format ExportFormat
+	// Scope is the requested set of rows
+	Scope ExportScope
+	// Filename is a suggested download name, e.g. "users.csv"
+	Filename string
+}
+
+// exportBatchSize is the number of rows fetched per DataSource query when
+// exporting, so large exports are streamed instead of loaded at once.
+const exportBatchSize = 500
+
+// Export writes the table's rows to w in the given format. Columns follow the
+// visible column order, headers use column labels and cells are formatted with
+// Column.Format, so the export matches what is shown. Rows follow the current
+// sort order.
+//
+// Example:
+//
+//	w.Header().Set("Content-Type", datatable.ExportCSV.ContentType())
+//	err := users.Export(w, datatable.ExportCSV, datatable.ExportFiltered)
+func (dt *DataTable) Export(w io.Writer, format ExportFormat, scope ExportScope) error {
+	return dt.ExportContext(context.Background(), w, format, scope)
+}
+
+// ExportContext is like Export but uses ctx for DataSource queries. With a
+// DataSource, the filtered and selected scopes page through the source in
+// batches rather than loading every row.
+func (dt *DataTable) ExportContext(ctx context.Context, w io.Writer, format ExportFormat, scope ExportScope) error {
+	columns := dt.VisibleColumns()
+
+	var enc exportEncoder
+	switch format {
+	case ExportCSV:
+		enc = newDelimitedEncoder(w, ',')
+	case ExportTSV:
+		enc = newDelimitedEncoder(w, '\t')
+	case ExportJSON:
+		enc = &jsonEncoder{w: w}
+	default:
+		return fmt.Errorf("datatable: unsupported export format %q", format)
+	}
+
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.Label
+		if headers[i] == "" {
+			headers[i] = col.ID
+		}
+	}
+	if err := enc.header(headers); err != nil {
+		return err
+	}
+
+	cells := make([]string, len(columns))
+	err := dt.eachExportRow(ctx, scope, func(row Row) error {
+		for i, col := range columns {
+			cells[i] = col.FormatValue(row.GetCellValue(col.ID))
+		}
+		return enc.row(cells)
+	})
+	if err != nil {
+		return err
+	}
+	return enc.close()
+}
+
+// eachExportRow calls fn for every row in scope, in sort order.
+func (dt *DataTable) eachExportRow(ctx context.Context, scope ExportScope, fn func(Row) error) error {
+	switch scope {
+	case ExportPage:
+		return eachRow(dt.GetPageRows(), nil, fn)
+	case ExportFiltered:
+		if dt.source != nil {
+			return dt.eachSourceRow(ctx, dt.query(), nil, fn)
+		}
+		return eachRow(dt.GetFilteredRows(), nil, fn)
+	case ExportSelected:
+		return dt.eachSelectedRow(ctx, fn)
+	}
+	return fmt.Errorf("datatable: unsupported export scope %q", scope)
+}
+
+// eachSourceRow pages through the DataSource, calling fn for each row (only
+// rows in only, when non-nil).
+func (dt *DataTable) eachSourceRow(ctx context.Context, q Query, only map[string]bool, fn func(Row) error) error {
+	q.Limit = exportBatchSize
+	for q.Offset = 0; ; q.Offset += exportBatchSize {
+		page, err := dt.source.Query(ctx, q)
+		if err != nil {
+			return fmt.Errorf("datatable: query %q: %w", dt.ID(), err)
+		}
+		if err := eachRow(page.Rows, only, fn); err != nil {
+			return err
+		}
+		if len(page.Rows) < exportBatchSize || q.Offset+exportBatchSize >= page.Total {
+			return nil
+		}
+	}
+}
+
+// eachRow calls fn for each row (only rows in only, when non-nil).
+func eachRow(rows []Row, only map[string]bool, fn func(Row) error) error {
+	for _, row := range rows {
+		if row.Group != nil || (only != nil && !only[row.ID]) {
+			continue
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportEncoder writes rows in one export format.
+type exportEncoder interface {
+	header(labels []string) error
+	row(cells []string) error
+	close() error
+}
+
+// delimitedEncoder writes CSV or TSV.
+type delimitedEncoder struct {
+	w *csv.Writer
+}
+
+func newDelimitedEncoder(w io.Writer, comma rune) *delimitedEncoder {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return &delimitedEncoder{w: cw}
+}
+
+func (e *delimitedEncoder) header(labels []string) error {
+	return e.w.Write(labels)
+}
+
+func (e *delimitedEncoder) row(cells []string) error {
+	return e.w.Write(cells)
+}
+
+func (e *delimitedEncoder) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonEncoder streams a JSON array of objects whose keys keep column order.
+type jsonEncoder struct {
+	w      io.Writer
+	keys   [][]byte
+	rows   int
+	failed error
+}
+
+func (e *jsonEncoder) header(labels []string) error {
+	e.keys = make([][]byte, len(labels))
+	for i, label := range labels {
+		key, err := json.Marshal(label)
+		if err != nil {
+			return err
+		}
+		e.keys[i] = key
+	}
+	return e.write([]byte("["))
+}
+
+func (e *jsonEncoder) row(cells []string) error {
+	buf := []byte(",\n  {")
+	if e.rows == 0 {
+		buf = buf[1:]
+	}
+	for i, cell := range cells {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		value, err := json.Marshal(cell)
+		if err != nil {
+			return err
+		}
+		buf = append(buf, e.keys[i]...)
+		buf = append(buf, ':')
+		buf = append(buf, value...)
+	}
+	buf = append(buf, '}')
+	e.rows++
+	return e.write(buf)
+}
+
+func (e *jsonEncoder) close() error {
+	if e.rows == 0 {
+		return e.write([]byte("]\n"))
+	}
+	return e.write([]byte("\n]\n"))
+}
+
+func (e *jsonEncoder) write(p []byte) error {
+	if e.failed != nil {
+		return e.failed
+	}
+	_, e.failed = e.w.Write(p)
+	return e.failed
+}
+
+// exportRequestFromAction builds an ExportRequest from "export" action data,
+// defaulting to CSV of the filtered rows.
+func (dt *DataTable) exportRequestFromAction(ctx *base.ActionContext) (ExportRequest, error) {
+	req := ExportRequest{
+		Format: ExportFormat(ctx.Data("format")),
+		Scope:  ExportScope(ctx.Data("scope")),
+	}
+	if req.Format == "" {
+		req.Format = ExportCSV
+	}
+	if req.Scope == "" {
+		req.Scope = ExportFiltered
+	}
+
+	switch req.Format {
+	case ExportCSV, ExportTSV, ExportJSON:
+	default:
+		return req, fmt.Errorf("datatable: unsupported export format %q", req.Format)
+	}
+	switch req.Scope {
+	case ExportPage, ExportFiltered, ExportSelected:
+	default:
+		return req, fmt.Errorf("datatable: unsupported export scope %q", req.Scope)
+	}
+
+	req.Filename = dt.ID() + "." + string(req.Format)
+	return req, nil
+}
+
+// errNoExportHandler is returned by the "export" action without WithOnExport.
+var errNoExportHandler = errors.New("datatable: export requires WithOnExport")
@@ -0,0 +1,593 @@
+package datatable
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// FilterKind selects how users filter a column (see FilterSpec).
+type FilterKind string
+
+const (
+	// FilterText includes the column in the text search.
+	FilterText FilterKind = "text"
+	// FilterFacet offers a multi-select list of the column's distinct values
+	// with counts. The column is included in the text search too.
+	FilterFacet FilterKind = "facet"
+	// FilterNumber offers a range slider over the column's numbers.
+	FilterNumber FilterKind = "number"
+	// FilterDate offers a range slider over the column's dates, in whole days.
+	FilterDate FilterKind = "date"
+)
+
+// FilterSpec describes how a column can be filtered. The zero value is not
+// filterable.
+//
+// Example:
+//
+//	{ID: "status", Label: "Status", Filterable: datatable.FilterSpec{Kind: datatable.FilterFacet}},
+//	{ID: "price", Label: "Price", Filterable: datatable.FilterSpec{Kind: datatable.FilterNumber, Step: 0.5}},
+type FilterSpec struct {
+	// Kind selects the filter ("" for none)
+	Kind FilterKind
+	// Options fixes the facet values and their order. When nil, the distinct
+	// values of the column are listed, most frequent first.
+	Options []FilterOption
+	// Min is the lower bound of the range slider (nil for the smallest value)
+	Min any
+	// Max is the upper bound of the range slider (nil for the largest value)
+	Max any
+	// Step is the range slider step (defaults to 1)
+	Step float64
+}
+
+// FilterOption is a facet value offered by a FilterSpec.
+type FilterOption struct {
+	// Value is the cell value
+	Value string
+	// Label is the display text (defaults to Value)
+	Label string
+}
+
+// searchable reports whether the text filter searches columns with the spec.
+func (s FilterSpec) searchable() bool {
+	return s.Kind == FilterText || s.Kind == FilterFacet
+}
+
+// faceted reports whether the spec has a facet or range filter.
+func (s FilterSpec) faceted() bool {
+	return s.Kind == FilterFacet || s.Kind == FilterNumber || s.Kind == FilterDate
+}
+
+// Facet is the state of a column's facet or range filter. Counts and bounds
+// cover the rows matching every other active filter, so they show what
+// changing this filter would find.
+type Facet struct {
+	// Column is the column ID
+	Column string
+	// Label is the column label
+	Label string
+	// Kind is FilterFacet, FilterNumber or FilterDate
+	Kind FilterKind
+	// Active is set when the column is filtered
+	Active bool
+
+	// Values are the facet values (FilterFacet)
+	Values []FacetValue
+	// SelectedCount is the number of selected Values
+	SelectedCount int
+	// Counted is set when Values carry counts. A DataSource that is not a
+	// FacetSource lists FilterSpec.Options without counts.
+	Counted bool
+
+	// Min and Max are the range slider bounds (FilterNumber, FilterDate).
+	// Dates are in days since 1970-01-01.
+	Min, Max float64
+	// Step is the range slider step
+	Step float64
+	// Low and High are the selected range
+	Low, High float64
+	// LowLabel and HighLabel are the formatted Low and High
+	LowLabel, HighLabel string
+}
+
+// FacetValue is a value listed by a facet filter.
+type FacetValue struct {
+	// Value is the cell value, as sent by the "toggle_facet" action
+	Value string
+	// Label is the display text
+	Label string
+	// Count is the number of matching rows with the value
+	Count int
+	// Selected is set when the filter includes the value
+	Selected bool
+}
+
+// FacetCount is the number of rows with a value.
+type FacetCount struct {
+	Value any
+	Count int
+}
+
+// FacetSource is a DataSource that can compute facets, so that tables
+// loading from it show facet counts and range bounds. MemorySource and
+// SQLSource implement it.
+type FacetSource interface {
+	DataSource
+	// FacetCounts returns the distinct non-nil values of column among the
+	// rows matching q, with their counts.
+	FacetCounts(ctx context.Context, q Query, column string) ([]FacetCount, error)
+	// FacetRange returns the smallest and largest values of column among the
+	// rows matching q (nil if there are none).
+	FacetRange(ctx context.Context, q Query, column string) (min, max any, err error)
+}
+
+// FilterChip is an active filter, shown above the table and removed with the
+// "remove_filter" action.
+type FilterChip struct {
+	// Column is the filtered column ID ("" for the text filter)
+	Column string
+	// Label is the column label
+	Label string
+	// Text describes the filter value
+	Text string
+	// Value is the facet value the chip removes ("" removes the whole filter)
+	Value string
+}
+
+// FacetCounts counts the distinct values of a column in memory.
+func (s *MemorySource) FacetCounts(ctx context.Context, q Query, column string) ([]FacetCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var counts []FacetCount
+	index := make(map[string]int)
+	for _, i := range filterIndexes(s.Rows, &q) {
+		v := s.Rows[i].GetCellValue(column)
+		if v == nil {
+			continue
+		}
+		key := foldCase(cellString(v))
+		if j, ok := index[key]; ok {
+			counts[j].Count++
+			continue
+		}
+		index[key] = len(counts)
+		counts = append(counts, FacetCount{Value: v, Count: 1})
+	}
+	return counts, nil
+}
+
+// FacetRange finds the smallest and largest values of a column in memory.
+func (s *MemorySource) FacetRange(ctx context.Context, q Query, column string) (min, max any, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	for _, i := range filterIndexes(s.Rows, &q) {
+		v := s.Rows[i].GetCellValue(column)
+		if v == nil {
+			continue
+		}
+		if c, ok := compareValues(v, min); min == nil || (ok && c < 0) {
+			min = v
+		}
+		if c, ok := compareValues(v, max); max == nil || (ok && c > 0) {
+			max = v
+		}
+	}
+	return min, max, nil
+}
+
+// Facets returns the facet and range filters of the visible columns with a
+// FilterFacet, FilterNumber or FilterDate spec. With a DataSource they are
+// queried by Load, and have counts and bounds only if the source is a
+// FacetSource.
+func (dt *DataTable) Facets() []Facet {
+	if dt.facets == nil && !dt.pagedRows() {
+		source := &MemorySource{Rows: dt.Rows, Columns: dt.Columns}
+		dt.facets, _ = dt.queryFacets(context.Background(), source)
+	}
+
+	var facets []Facet
+	for _, f := range dt.facets {
+		if col := dt.GetColumn(f.Column); col != nil && !col.Hidden {
+			facets = append(facets, f)
+		}
+	}
+	return facets
+}
+
+// loadFacets queries the facets from the DataSource.
+func (dt *DataTable) loadFacets(ctx context.Context) error {
+	source, _ := dt.source.(FacetSource)
+	facets, err := dt.queryFacets(ctx, source)
+	if err != nil {
+		return err
+	}
+	dt.facets = facets
+	return nil
+}
+
+// queryFacets builds the facets of every column with a faceted spec. A nil
+// source gives facets without counts or data bounds.
+func (dt *DataTable) queryFacets(ctx context.Context, source FacetSource) ([]Facet, error) {
+	facets := make([]Facet, 0)
+	for _, col := range dt.Columns {
+		if !col.Filterable.faceted() {
+			continue
+		}
+
+		var counts []FacetCount
+		var min, max any
+		if source != nil {
+			q := dt.facetQuery(col.ID)
+			var err error
+			if col.Filterable.Kind == FilterFacet {
+				counts, err = source.FacetCounts(ctx, q, col.ID)
+			} else {
+				min, max, err = source.FacetRange(ctx, q, col.ID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("facet %q: %w", col.ID, err)
+			}
+		}
+
+		if col.Filterable.Kind == FilterFacet {
+			facets = append(facets, dt.valueFacet(col, counts, source != nil))
+		} else {
+			facets = append(facets, dt.rangeFacet(col, min, max))
+		}
+	}
+	return facets, nil
+}
+
+// facetQuery returns the table's query without the filter on column.
+func (dt *DataTable) facetQuery(column string) Query {
+	q := dt.query()
+	q.Filters = nil
+	for _, f := range dt.ColumnFilters {
+		if f.Column != column {
+			q.Filters = append(q.Filters, f)
+		}
+	}
+	return q
+}
+
+// valueFacet builds a FilterFacet facet from value counts.
+func (dt *DataTable) valueFacet(col Column, counts []FacetCount, counted bool) Facet {
+	f := Facet{Column: col.ID, Label: col.Label, Kind: FilterFacet, Counted: counted}
+
+	byKey := make(map[string]int)
+	var found []FacetCount
+	for _, c := range counts {
+		key := foldCase(cellString(c.Value))
+		if i, ok := byKey[key]; ok {
+			found[i].Count += c.Count
+			continue
+		}
+		byKey[key] = len(found)
+		found = append(found, c)
+	}
+
+	if col.Filterable.Options != nil {
+		for _, opt := range col.Filterable.Options {
+			label := opt.Label
+			if label == "" {
+				label = opt.Value
+			}
+			count := 0
+			if i, ok := byKey[foldCase(opt.Value)]; ok {
+				count = found[i].Count
+			}
+			f.Values = append(f.Values, FacetValue{Value: opt.Value, Label: label, Count: count})
+		}
+	} else {
+		sort.SliceStable(found, func(i, j int) bool {
+			if found[i].Count != found[j].Count {
+				return found[i].Count > found[j].Count
+			}
+			c, _ := compareValues(found[i].Value, found[j].Value)
+			return c < 0
+		})
+		for _, c := range found {
+			f.Values = append(f.Values, FacetValue{
+				Value: cellString(c.Value),
+				Label: col.FormatValue(c.Value),
+				Count: c.Count,
+			})
+		}
+	}
+
+	// Keep selected values listed when nothing else matches them, so they
+	// can be deselected.
+	if filter := dt.GetColumnFilter(col.ID); filter != nil && filter.Operator == FilterIn {
+		for _, v := range filter.Values {
+			value := cellString(v)
+			i := facetValueIndex(f.Values, value)
+			if i < 0 {
+				i = len(f.Values)
+				f.Values = append(f.Values, FacetValue{Value: value, Label: dt.facetLabel(col, v)})
+			}
+			if !f.Values[i].Selected {
+				f.Values[i].Selected = true
+				f.SelectedCount++
+			}
+		}
+	}
+	f.Active = f.SelectedCount > 0
+	return f
+}
+
+// facetValueIndex returns the index of value in values, or -1.
+func facetValueIndex(values []FacetValue, value string) int {
+	key := foldCase(value)
+	for i := range values {
+		if foldCase(values[i].Value) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// facetLabel returns the display text of a facet value.
+func (dt *DataTable) facetLabel(col Column, v any) string {
+	value := cellString(v)
+	for _, opt := range col.Filterable.Options {
+		if foldCase(opt.Value) == foldCase(value) && opt.Label != "" {
+			return opt.Label
+		}
+	}
+	return col.FormatValue(v)
+}
+
+// rangeFacet builds a FilterNumber or FilterDate facet from the data bounds.
+func (dt *DataTable) rangeFacet(col Column, min, max any) Facet {
+	kind := col.Filterable.Kind
+	f := Facet{Column: col.ID, Label: col.Label, Kind: kind, Step: col.Filterable.Step}
+	if f.Step <= 0 {
+		f.Step = 1
+	}
+
+	if col.Filterable.Min != nil {
+		min = col.Filterable.Min
+	}
+	if col.Filterable.Max != nil {
+		max = col.Filterable.Max
+	}
+	f.Min, _ = rangePosition(kind, min)
+	f.Max, _ = rangePosition(kind, max)
+	f.Max = math.Max(f.Max, f.Min)
+	f.Low, f.High = f.Min, f.Max
+
+	if filter := dt.GetColumnFilter(col.ID); filter != nil && filter.Operator == FilterRange {
+		if low, ok := rangePosition(kind, filter.Min); ok {
+			f.Low = math.Max(low, f.Min)
+			f.Active = true
+		}
+		if high, ok := rangePosition(kind, filter.Max); ok {
+			f.High = math.Min(high, f.Max)
+			f.Active = true
+		}
+	}
+
+	f.LowLabel = rangeLabel(col, f.Low)
+	f.HighLabel = rangeLabel(col, f.High)
+	return f
+}
+
+// rangePosition converts a number or date to its range slider position.
+// Dates are counted in days since 1970-01-01.
+func rangePosition(kind FilterKind, v any) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	if kind == FilterDate {
+		t, ok := toTime(v, true)
+		if !ok {
+			return 0, false
+		}
+		return math.Floor(float64(t.Unix()) / 86400), true
+	}
+	if n, ok := toFloat(v); ok {
+		return n, true
+	}
+	if s, ok := v.(string); ok {
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// rangeValue converts a range slider position to a filter bound. Dates
+// become "2006-01-02" strings, which compare with time values and, in SQL,
+// with ISO 8601 text. Upper date bounds are the last instant of the day, so
+// values with a time of day on that day match.
+func rangeValue(kind FilterKind, pos float64, upper bool) any {
+	if kind != FilterDate {
+		return pos
+	}
+	day := time.Unix(int64(pos)*86400, 0).UTC()
+	if upper {
+		return day.Add(24*time.Hour - time.Nanosecond).Format(time.RFC3339Nano)
+	}
+	return day.Format("2006-01-02")
+}
+
+// rangeLabel formats a range slider position with the column's Format.
+func rangeLabel(col Column, pos float64) string {
+	if col.Filterable.Kind == FilterDate {
+		t := time.Unix(int64(pos)*86400, 0).UTC()
+		if col.Format == "" {
+			return t.Format("2006-01-02")
+		}
+		return col.FormatValue(t)
+	}
+	if col.Format == "" {
+		return strconv.FormatFloat(pos, 'f', -1, 64)
+	}
+	return col.FormatValue(pos)
+}
+
+// rangeBoundLabel formats a range filter bound with the column's Format.
+// Date bounds are shown as days, like the range slider labels.
+func rangeBoundLabel(col Column, v any) string {
+	if col.Filterable.Kind == FilterDate {
+		if pos, ok := rangePosition(FilterDate, v); ok {
+			return rangeLabel(col, pos)
+		}
+	}
+	return col.FormatValue(v)
+}
+
+// facet returns the facet of a column, or nil if it has none.
+func (dt *DataTable) facet(columnID string) *Facet {
+	facets := dt.Facets()
+	for i := range facets {
+		if facets[i].Column == columnID {
+			return &facets[i]
+		}
+	}
+	return nil
+}
+
+// ToggleFacetValue adds value to or removes it from the FilterIn filter on a
+// column, and resets to the first page.
+func (dt *DataTable) ToggleFacetValue(columnID, value string) {
+	var values []any
+	if f := dt.GetColumnFilter(columnID); f != nil && f.Operator == FilterIn {
+		values = append(values, f.Values...)
+	}
+
+	removed := false
+	for i, v := range values {
+		if foldCase(cellString(v)) == foldCase(value) {
+			values = append(values[:i], values[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	if !removed {
+		values = append(values, value)
+	}
+
+	if len(values) == 0 {
+		dt.RemoveColumnFilter(columnID)
+		return
+	}
+	dt.SetColumnFilter(ColumnFilter{Column: columnID, Operator: FilterIn, Values: values})
+}
+
+// SetFacetRange filters a FilterNumber or FilterDate column to the range
+// slider positions low to high (see Facet). Bounds at the ends of the
+// slider are left open; the filter is removed when both are.
+func (dt *DataTable) SetFacetRange(columnID string, low, high float64) {
+	f := dt.facet(columnID)
+	if f == nil || f.Kind == FilterFacet {
+		return
+	}
+	if low > high {
+		low, high = high, low
+	}
+
+	filter := ColumnFilter{Column: columnID, Operator: FilterRange}
+	if low > f.Min {
+		filter.Min = rangeValue(f.Kind, low, false)
+	}
+	if high < f.Max {
+		filter.Max = rangeValue(f.Kind, high, true)
+	}
+	if filter.Min == nil && filter.Max == nil {
+		dt.RemoveColumnFilter(columnID)
+		return
+	}
+	dt.SetColumnFilter(filter)
+}
+
+// RemoveFilter removes an active filter as described by a FilterChip: the
+// text filter when columnID is "", one facet value when value is set, or the
+// column's filter.
+func (dt *DataTable) RemoveFilter(columnID, value string) {
+	if columnID == "" {
+		dt.ClearFilter()
+		return
+	}
+	f := dt.GetColumnFilter(columnID)
+	if f == nil {
+		return
+	}
+	if value != "" && f.Operator == FilterIn {
+		for _, v := range f.Values {
+			if foldCase(cellString(v)) == foldCase(value) {
+				dt.ToggleFacetValue(columnID, value)
+				return
+			}
+		}
+		return
+	}
+	dt.RemoveColumnFilter(columnID)
+}
+
+// ClearAllFilters removes the text filter and all column filters.
+func (dt *DataTable) ClearAllFilters() {
+	dt.FilterValue = ""
+	dt.ColumnFilters = nil
+	dt.filtersChanged()
+}
+
+// ToggleFacetMenu opens or closes the value list of a facet.
+func (dt *DataTable) ToggleFacetMenu(columnID string) {
+	if dt.OpenFacet == columnID {
+		dt.OpenFacet = ""
+		return
+	}
+	dt.OpenFacet = columnID
+}
+
+// FilterChips returns the active filters: the text filter, then one chip
+// per column filter, or per value of a FilterIn filter.
+func (dt *DataTable) FilterChips() []FilterChip {
+	var chips []FilterChip
+	if dt.FilterValue != "" {
+		chips = append(chips, FilterChip{Label: "Search", Text: dt.FilterValue})
+	}
+
+	for _, f := range dt.ColumnFilters {
+		col := Column{ID: f.Column, Label: f.Column}
+		if c := dt.GetColumn(f.Column); c != nil {
+			col = *c
+		}
+		chip := FilterChip{Column: f.Column, Label: col.Label}
+
+		switch f.Operator {
+		case FilterIn:
+			for _, v := range f.Values {
+				chip.Text = dt.facetLabel(col, v)
+				chip.Value = cellString(v)
+				chips = append(chips, chip)
+			}
+			continue
+		case FilterRange:
+			switch {
+			case f.Min != nil && f.Max != nil:
+				chip.Text = rangeBoundLabel(col, f.Min) + "–" + rangeBoundLabel(col, f.Max)
+			case f.Min != nil:
+				chip.Text = "≥ " + rangeBoundLabel(col, f.Min)
+			default:
+				chip.Text = "≤ " + rangeBoundLabel(col, f.Max)
+			}
+		case FilterEquals:
+			chip.Text = "= " + col.FormatValue(f.Value)
+		default:
+			chip.Text = cellString(f.Value)
+		}
+		chips = append(chips, chip)
+	}
+	return chips
+}
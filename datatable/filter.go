@@ -0,0 +1,226 @@
+package datatable
+
+import (
+	"strings"
+
+	"github.com/livetemplate/components/base"
+)
+
+// FilterOperator selects how a ColumnFilter matches cell values.
+type FilterOperator string
+
+const (
+	// FilterContains matches cells whose text contains Value (case-insensitive).
+	FilterContains FilterOperator = "contains"
+	// FilterEquals matches cells equal to Value (strings compare case-insensitively).
+	FilterEquals FilterOperator = "equals"
+	// FilterRange matches cells between Min and Max, inclusive. A nil bound is open.
+	FilterRange FilterOperator = "range"
+	// FilterIn matches cells equal to any of Values.
+	FilterIn FilterOperator = "in"
+)
+
+// ColumnFilter restricts rows by the value of a single column.
+type ColumnFilter struct {
+	// Column is the column ID to filter on
+	Column string
+	// Operator selects the match rule (defaults to FilterContains)
+	Operator FilterOperator
+	// Value is the operand for FilterContains and FilterEquals
+	Value any
+	// Min is the lower bound for FilterRange (nil for none)
+	Min any
+	// Max is the upper bound for FilterRange (nil for none)
+	Max any
+	// Values is the set for FilterIn
+	Values []any
+}
+
+// Matches reports whether a cell value satisfies the filter.
+func (f ColumnFilter) Matches(cell any) bool {
+	switch f.Operator {
+	case FilterEquals:
+		c, ok := compareValues(cell, f.Value)
+		return ok && c == 0
+	case FilterRange:
+		if cell == nil {
+			return false
+		}
+		if f.Min != nil {
+			if c, ok := compareValues(cell, f.Min); !ok || c < 0 {
+				return false
+			}
+		}
+		if f.Max != nil {
+			if c, ok := compareValues(cell, f.Max); !ok || c > 0 {
+				return false
+			}
+		}
+		return true
+	case FilterIn:
+		for _, v := range f.Values {
+			if c, ok := compareValues(cell, v); ok && c == 0 {
+				return true
+			}
+		}
+		return false
+	default:
+		needle := foldCase(cellString(f.Value))
+		return strings.Contains(foldCase(cellString(cell)), needle)
+	}
+}
+
+// SetColumnFilter adds a column filter, replacing any existing filter on the
+// same column, and resets to the first page.
+func (dt *DataTable) SetColumnFilter(filter ColumnFilter) {
+	if filter.Operator == "" {
+		filter.Operator = FilterContains
+	}
+	for i := range dt.ColumnFilters {
+		if dt.ColumnFilters[i].Column == filter.Column {
+			dt.ColumnFilters[i] = filter
+			dt.filtersChanged()
+			return
+		}
+	}
+	dt.ColumnFilters = append(dt.ColumnFilters, filter)
+	dt.filtersChanged()
+}
+
+// RemoveColumnFilter removes the filter on a column.
+func (dt *DataTable) RemoveColumnFilter(columnID string) {
+	for i := range dt.ColumnFilters {
+		if dt.ColumnFilters[i].Column == columnID {
+			dt.ColumnFilters = append(dt.ColumnFilters[:i], dt.ColumnFilters[i+1:]...)
+			dt.filtersChanged()
+			return
+		}
+	}
+}
+
+// ClearColumnFilters removes all column filters.
+func (dt *DataTable) ClearColumnFilters() {
+	dt.ColumnFilters = nil
+	dt.filtersChanged()
+}
+
+// GetColumnFilter returns the filter on a column, or nil if none.
+func (dt *DataTable) GetColumnFilter(columnID string) *ColumnFilter {
+	for i := range dt.ColumnFilters {
+		if dt.ColumnFilters[i].Column == columnID {
+			return &dt.ColumnFilters[i]
+		}
+	}
+	return nil
+}
+
+// IsFiltered returns true if a text filter or any column filter is active.
+func (dt *DataTable) IsFiltered() bool {
+	return dt.FilterValue != "" || len(dt.ColumnFilters) > 0
+}
+
+// searchColumns returns the column IDs the text filter searches. FilterColumn
+// wins when set; otherwise visible columns with a FilterText or FilterFacet
+// spec are searched, or every visible column when none is marked. A nil
+// result means the table has no columns and every cell is searched.
+func (dt *DataTable) searchColumns() []string {
+	if dt.FilterColumn != "" {
+		return []string{dt.FilterColumn}
+	}
+	if len(dt.Columns) == 0 {
+		return nil
+	}
+
+	var all, filterable []string
+	for _, col := range dt.Columns {
+		if col.Hidden {
+			continue
+		}
+		all = append(all, col.ID)
+		if col.Filterable.searchable() {
+			filterable = append(filterable, col.ID)
+		}
+	}
+	if len(filterable) > 0 {
+		return filterable
+	}
+	if all == nil {
+		return []string{}
+	}
+	return all
+}
+
+// matchesQuery reports whether a row passes the query's text search (needle is
+// the case-folded search text) and all of its column filters.
+func matchesQuery(row Row, q *Query, needle string) bool {
+	for _, f := range q.Filters {
+		if !f.Matches(row.GetCellValue(f.Column)) {
+			return false
+		}
+	}
+
+	if needle == "" {
+		return true
+	}
+	if q.SearchColumns == nil {
+		for _, v := range row.Data {
+			if strings.Contains(foldCase(cellString(v)), needle) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, id := range q.SearchColumns {
+		if strings.Contains(foldCase(cellString(row.GetCellValue(id))), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterIndexes returns the indexes of the rows that pass the query's filters.
+func filterIndexes(rows []Row, q *Query) []int {
+	needle := foldCase(strings.TrimSpace(q.Search))
+
+	indexes := make([]int, 0, len(rows))
+	for i, row := range rows {
+		if matchesQuery(row, q, needle) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// columnFilterFromAction builds a ColumnFilter from "filter_column" action
+// data. It returns false when the filter has no operand and should be removed.
+func columnFilterFromAction(ctx *base.ActionContext) (ColumnFilter, bool) {
+	filter := ColumnFilter{
+		Column:   ctx.Data("column"),
+		Operator: FilterOperator(ctx.Data("op")),
+	}
+
+	switch filter.Operator {
+	case FilterRange:
+		if min := ctx.Data("min"); min != "" {
+			filter.Min = min
+		}
+		if max := ctx.Data("max"); max != "" {
+			filter.Max = max
+		}
+		return filter, filter.Min != nil || filter.Max != nil
+	case FilterIn:
+		for _, v := range strings.Split(ctx.Data("values"), ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				filter.Values = append(filter.Values, v)
+			}
+		}
+		return filter, len(filter.Values) > 0
+	case FilterEquals:
+	default:
+		filter.Operator = FilterContains
+	}
+
+	value := ctx.Data("value")
+	filter.Value = value
+	return filter, value != ""
+}
@@ -0,0 +1,274 @@
+package datatable
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Formatter renders a cell value as display text. arg is the part of
+// Column.Format after the first ":" (empty if none), so for "currency:EUR:0"
+// the "currency" formatter receives "EUR:0".
+//
+// Formatters must accept both typed values and their JSON-decoded forms
+// (float64 for numbers, RFC 3339 strings for times), since templates may
+// render a table from its JSON representation.
+type Formatter func(value any, arg string) string
+
+var (
+	formattersMu sync.RWMutex
+
+	// formatters holds the registered formatters by name.
+	formatters = map[string]Formatter{
+		"date":     formatDate("2006-01-02"),
+		"datetime": formatDate("2006-01-02 15:04"),
+		"currency": formatCurrency,
+		"number":   formatNumberValue,
+		"percent":  formatPercent,
+		"bool":     formatBool,
+		"relative": formatRelative,
+	}
+
+	// timeNow is the clock used by the "relative" formatter.
+	timeNow = time.Now
+)
+
+// RegisterFormatter adds or replaces a formatter for use in Column.Format.
+//
+// Built-in formatters:
+//   - "date" and "datetime": time values, with an optional Go layout
+//     ("date:Jan 2, 2006"); defaults are "2006-01-02" and "2006-01-02 15:04"
+//   - "currency": optional ISO code and precision ("currency:EUR",
+//     "currency:JPY:0"); defaults to USD with 2 decimals
+//   - "number": thousands separators, optional precision ("number:2")
+//   - "percent": fractions as percentages, optional precision ("percent:1")
+//   - "bool": "✓"/"✗", or custom text ("bool:Yes/No")
+//   - "relative": times relative to now ("3 days ago", "in 2 hours")
+//
+// Example:
+//
+//	datatable.RegisterFormatter("upper", func(v any, _ string) string {
+//	    return strings.ToUpper(fmt.Sprint(v))
+//	})
+func RegisterFormatter(name string, f Formatter) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+	formatters[name] = f
+}
+
+// FormatValue formats a value with a format string such as "date" or
+// "currency:EUR". Nil values render as "". Values with an empty or unknown
+// format render as they would without formatting.
+func FormatValue(format string, value any) string {
+	if value == nil {
+		return ""
+	}
+
+	name, arg, _ := strings.Cut(format, ":")
+	formattersMu.RLock()
+	f, ok := formatters[name]
+	formattersMu.RUnlock()
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	return f(value, arg)
+}
+
+// FormatValue formats a value using the column's Format.
+func (c Column) FormatValue(value any) string {
+	return FormatValue(c.Format, value)
+}
+
+// FormatCell returns the formatted value of a row's cell.
+func (dt *DataTable) FormatCell(row Row, columnID string) string {
+	format := ""
+	if col := dt.GetColumn(columnID); col != nil {
+		format = col.Format
+	}
+	return FormatValue(format, row.GetCellValue(columnID))
+}
+
+// formatDate returns a formatter for time values with a default layout.
+func formatDate(layout string) Formatter {
+	return func(v any, arg string) string {
+		t, ok := toTime(v, true)
+		if !ok {
+			return cellString(v)
+		}
+		if arg != "" {
+			return t.Format(arg)
+		}
+		return t.Format(layout)
+	}
+}
+
+// currencySymbols maps ISO 4217 codes to symbols placed before the amount.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+}
+
+func formatCurrency(v any, arg string) string {
+	n, ok := toNumber(v)
+	if !ok {
+		return cellString(v)
+	}
+
+	code, precisionArg, _ := strings.Cut(arg, ":")
+	code = strings.ToUpper(code)
+	if code == "" {
+		code = "USD"
+	}
+	precision := 2
+	if code == "JPY" || code == "KRW" {
+		precision = 0
+	}
+	if p, err := strconv.Atoi(precisionArg); err == nil {
+		precision = p
+	}
+
+	amount := formatNumber(n, precision)
+	sign := ""
+	if rest, negative := strings.CutPrefix(amount, "-"); negative {
+		sign, amount = "-", rest
+	}
+	if symbol, ok := currencySymbols[code]; ok {
+		return sign + symbol + amount
+	}
+	return sign + code + " " + amount
+}
+
+func formatNumberValue(v any, arg string) string {
+	n, ok := toNumber(v)
+	if !ok {
+		return cellString(v)
+	}
+
+	precision := 0
+	if n != math.Trunc(n) {
+		precision = 2
+	}
+	if p, err := strconv.Atoi(arg); err == nil {
+		precision = p
+	}
+	return formatNumber(n, precision)
+}
+
+func formatPercent(v any, arg string) string {
+	n, ok := toNumber(v)
+	if !ok {
+		return cellString(v)
+	}
+
+	precision := 0
+	if p, err := strconv.Atoi(arg); err == nil {
+		precision = p
+	}
+	return formatNumber(n*100, precision) + "%"
+}
+
+func formatBool(v any, arg string) string {
+	b, ok := toBool(v)
+	if !ok {
+		return cellString(v)
+	}
+
+	yes, no := "✓", "✗"
+	if y, n, found := strings.Cut(arg, "/"); found {
+		yes, no = y, n
+	}
+	if b {
+		return yes
+	}
+	return no
+}
+
+// relativeUnits are the units used by the "relative" formatter, largest first.
+var relativeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+}
+
+func formatRelative(v any, _ string) string {
+	t, ok := toTime(v, true)
+	if !ok {
+		return cellString(v)
+	}
+
+	d := timeNow().Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	for _, unit := range relativeUnits {
+		if d < unit.size {
+			continue
+		}
+		n := int(d / unit.size)
+		text := strconv.Itoa(n) + " " + unit.name
+		if n != 1 {
+			text += "s"
+		}
+		if future {
+			return "in " + text
+		}
+		return text + " ago"
+	}
+	return "just now"
+}
+
+// toNumber converts numeric values and numeric strings to float64.
+func toNumber(v any) (float64, bool) {
+	if f, ok := toFloat(v); ok {
+		return f, true
+	}
+	if s, ok := v.(string); ok {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// formatNumber formats n with thousands separators and fixed precision.
+func formatNumber(n float64, precision int) string {
+	s := groupThousands(math.Abs(n), precision)
+	if n < 0 && s != groupThousands(0, precision) {
+		return "-" + s
+	}
+	return s
+}
+
+// groupThousands formats a non-negative number with comma separators.
+func groupThousands(n float64, precision int) string {
+	s := strconv.FormatFloat(n, 'f', max(precision, 0), 64)
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(frac)
+	}
+	return b.String()
+}
@@ -0,0 +1,311 @@
+package datatable
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Aggregate is a summary computed over a column's values.
+type Aggregate string
+
+const (
+	// AggregateCount counts the non-empty values.
+	AggregateCount Aggregate = "count"
+	// AggregateSum adds the numeric values.
+	AggregateSum Aggregate = "sum"
+	// AggregateAvg averages the numeric values.
+	AggregateAvg Aggregate = "avg"
+	// AggregateMin is the smallest value (numbers, times or text).
+	AggregateMin Aggregate = "min"
+	// AggregateMax is the largest value (numbers, times or text).
+	AggregateMax Aggregate = "max"
+)
+
+// RowGroup describes a group header row produced by GroupBy.
+type RowGroup struct {
+	// Key identifies the group, including its parent groups
+	Key string
+	// Column is the grouped column ID
+	Column string
+	// Label is the grouped column's label
+	Label string
+	// Value is the group's formatted value
+	Value string
+	// Count is the number of rows in the group
+	Count int
+	// Aggregates are the group's formatted aggregates by column ID
+	Aggregates map[string]string
+	// Collapsed hides the group's rows
+	Collapsed bool
+}
+
+// SetGroupBy groups rows by the given column IDs, outermost first. Call it
+// with no columns to remove grouping.
+func (dt *DataTable) SetGroupBy(columns ...string) {
+	dt.GroupBy = columns
+	dt.Page = 0
+	dt.resetView()
+}
+
+// IsGrouped returns true if rows are grouped. Tree tables are not grouped.
+func (dt *DataTable) IsGrouped() bool {
+	return len(dt.GroupBy) > 0 && !dt.isTree()
+}
+
+// ToggleGroup collapses an expanded group or expands a collapsed one.
+func (dt *DataTable) ToggleGroup(key string) {
+	if dt.CollapsedGroups == nil {
+		dt.CollapsedGroups = make(map[string]bool)
+	}
+	if dt.CollapsedGroups[key] {
+		delete(dt.CollapsedGroups, key)
+	} else {
+		dt.CollapsedGroups[key] = true
+	}
+	dt.resetView()
+}
+
+// IsGroupCollapsed returns true if a group's rows are hidden.
+func (dt *DataTable) IsGroupCollapsed(key string) bool {
+	return dt.CollapsedGroups[key]
+}
+
+// HasAggregates returns true if any visible column has an Aggregate.
+func (dt *DataTable) HasAggregates() bool {
+	for _, col := range dt.VisibleColumns() {
+		if col.Aggregate != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Totals returns the formatted aggregates of all filtered rows by column ID,
+// for the grand-total footer. With a DataSource only the loaded rows are
+// aggregated.
+func (dt *DataTable) Totals() map[string]string {
+	if !dt.HasAggregates() {
+		return nil
+	}
+	return dt.aggregates(dt.GetFilteredRows())
+}
+
+// aggregates computes the formatted aggregates of rows for every visible
+// column with an Aggregate.
+func (dt *DataTable) aggregates(rows []Row) map[string]string {
+	result := make(map[string]string)
+	for _, col := range dt.VisibleColumns() {
+		if col.Aggregate == "" {
+			continue
+		}
+		value := aggregate(col.Aggregate, rows, col.ID)
+		if col.Aggregate == AggregateCount {
+			result[col.ID] = strconv.Itoa(value.(int))
+		} else {
+			result[col.ID] = col.FormatValue(value)
+		}
+	}
+	return result
+}
+
+// aggregate computes an aggregate of a column over rows. Sum and avg ignore
+// non-numeric values; avg, min and max return nil when there are no values.
+func aggregate(kind Aggregate, rows []Row, columnID string) any {
+	var (
+		count int
+		sum   float64
+		nums  int
+		best  any
+	)
+	for _, row := range rows {
+		v := row.GetCellValue(columnID)
+		if v == nil || v == "" {
+			continue
+		}
+		count++
+		if f, ok := toNumber(v); ok {
+			sum += f
+			nums++
+		}
+		if best == nil {
+			best = v
+			continue
+		}
+		if c, ok := compareValues(v, best); ok && ((kind == AggregateMin && c < 0) || (kind == AggregateMax && c > 0)) {
+			best = v
+		}
+	}
+
+	switch kind {
+	case AggregateCount:
+		return count
+	case AggregateSum:
+		return sum
+	case AggregateAvg:
+		if nums == 0 {
+			return nil
+		}
+		return sum / float64(nums)
+	case AggregateMin, AggregateMax:
+		return best
+	}
+	return nil
+}
+
+// groupSortKeys prefixes sort keys with the group columns, so rows of a
+// group are adjacent. A group column keeps its sort direction if it is
+// sorted; otherwise groups are ascending.
+func (dt *DataTable) groupSortKeys(sortKeys []SortKey) []SortKey {
+	keys := make([]SortKey, 0, len(dt.GroupBy)+len(sortKeys))
+	for _, id := range dt.GroupBy {
+		dir := dt.sortDirectionOf(id)
+		if dir == SortNone {
+			dir = SortAsc
+		}
+		keys = append(keys, SortKey{Column: id, Direction: dir})
+	}
+	for _, k := range sortKeys {
+		if !containsString(dt.GroupBy, k.Column) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// groupedRows returns the rows of a grouped table in display order, with a
+// header row (Row.Group set) before each group and the rows of collapsed
+// groups left out (cached until the view is reset).
+func (dt *DataTable) groupedRows() []Row {
+	if dt.lookupRows(); dt.grouped != nil {
+		return dt.grouped
+	}
+
+	var rows []Row
+	if dt.pagedRows() {
+		rows = dt.rowsAt(queryIndexes(dt.Rows, dt.Columns, Query{Sort: dt.groupSortKeys(nil)}))
+	} else {
+		rows = dt.rowsAt(dt.viewIndexes())
+	}
+	dt.grouped = dt.appendGroups(make([]Row, 0, len(rows)), rows, 0, "")
+	dt.groupedCount = 0
+	for _, row := range dt.grouped {
+		if row.Group == nil {
+			dt.groupedCount++
+		}
+	}
+	return dt.grouped
+}
+
+// pageOfGroups returns the current page's slice of grouped rows. A page holds
+// PageSize rows plus the headers of the groups starting on it; headers after
+// the last row (of collapsed groups) go on the last page.
+func (dt *DataTable) pageOfGroups(rows []Row) []Row {
+	if dt.PageSize <= 0 {
+		return rows
+	}
+
+	start := dt.Page * dt.PageSize
+	end := start + dt.PageSize
+	if dt.Infinite {
+		start = 0
+	}
+	if end >= dt.groupedCount {
+		end = dt.groupedCount + 1
+	}
+
+	lo, hi := len(rows), len(rows)
+	n := 0 // rows before rows[i], without headers
+	for i, row := range rows {
+		if n >= start && lo == len(rows) {
+			lo = i
+		}
+		if n >= end {
+			hi = i
+			break
+		}
+		if row.Group == nil {
+			n++
+		}
+	}
+	return rows[lo:hi]
+}
+
+// appendGroups appends the groups of rows (sorted by the group columns) at
+// one grouping level, with their headers and visible rows.
+func (dt *DataTable) appendGroups(out, rows []Row, level int, parent string) []Row {
+	columnID := dt.GroupBy[level]
+	col := dt.GetColumn(columnID)
+	label := columnID
+	if col != nil && col.Label != "" {
+		label = col.Label
+	}
+
+	for start := 0; start < len(rows); {
+		value := rows[start].GetCellValue(columnID)
+		end := start + 1
+		for end < len(rows) && sameGroup(value, rows[end].GetCellValue(columnID)) {
+			end++
+		}
+		members := rows[start:end]
+		start = end
+
+		key := columnID + ":" + cellString(value)
+		if parent != "" {
+			key = parent + "/" + key
+		}
+		group := &RowGroup{
+			Key:        key,
+			Column:     columnID,
+			Label:      label,
+			Value:      dt.FormatCell(members[0], columnID),
+			Count:      len(members),
+			Aggregates: dt.aggregates(members),
+			Collapsed:  dt.CollapsedGroups[key],
+		}
+		out = append(out, Row{ID: "group:" + key, Group: group, Depth: level})
+		if group.Collapsed {
+			continue
+		}
+
+		if level+1 < len(dt.GroupBy) {
+			out = dt.appendGroups(out, members, level+1, key)
+			continue
+		}
+		for _, row := range members {
+			row.Depth = len(dt.GroupBy)
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// sameGroup reports whether two group column values belong to the same group.
+func sameGroup(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	c, ok := compareValues(a, b)
+	return ok && c == 0
+}
+
+// containsString reports whether s is in list.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// groupByFromAction parses the comma-separated lvt-data-columns of a
+// "group_by" action.
+func groupByFromAction(columns string) []string {
+	var ids []string
+	for _, id := range strings.Split(columns, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,174 @@
+package datatable
+
+// rowLookup maps row IDs to their position in Rows, so that selecting,
+// editing and expanding a row don't scan every row. It is rebuilt by
+// SetData, WithRows and Load, and whenever Rows is found to have been
+// replaced.
+type rowLookup struct {
+	// rows is the Rows slice the lookup was built for
+	rows []Row
+	// ids maps each top-level row ID to the index of its first row
+	ids map[string]int
+	// paths maps the IDs of nested rows to their index in Rows followed by
+	// their index in each ancestor's Children
+	paths map[string][]int
+	// tree is set if any row has children
+	tree bool
+}
+
+// rowCounts caches values derived from every row, which are shown on every
+// render, until the rows or filters change.
+type rowCounts struct {
+	// enabled is the number of enabled rows matching the filters (-1 until
+	// computed), from which SelectedCount subtracts ExcludedIDs
+	enabled int
+	// matching is the number of rows matching the filters, which tree tables
+	// count at any depth (computed with enabled)
+	matching int
+	// allSelected is AllSelected for page and pageSize (-1 until computed,
+	// and after a selection change)
+	allSelected    int
+	page, pageSize int
+}
+
+// indexRows rebuilds the row lookup and clears the cached counts.
+func (dt *DataTable) indexRows() *rowLookup {
+	lookup := &rowLookup{rows: dt.Rows, ids: make(map[string]int, len(dt.Rows))}
+	for i, row := range dt.Rows {
+		if _, ok := lookup.ids[row.ID]; !ok {
+			lookup.ids[row.ID] = i
+		}
+		if len(row.Children) > 0 {
+			lookup.tree = true
+			lookup.indexChildren(row.Children, []int{i})
+		}
+	}
+	dt.lookup = lookup
+	dt.counts = nil
+	return lookup
+}
+
+// indexChildren adds the paths of nested rows below the row at path.
+func (l *rowLookup) indexChildren(rows []Row, path []int) {
+	if l.paths == nil {
+		l.paths = make(map[string][]int)
+	}
+	for i, row := range rows {
+		p := append(path[:len(path):len(path)], i)
+		if _, ok := l.paths[row.ID]; !ok {
+			l.paths[row.ID] = p
+		}
+		if len(row.Children) > 0 {
+			l.indexChildren(row.Children, p)
+		}
+	}
+}
+
+// find returns the row with the given ID in rows, or nil.
+func (l *rowLookup) find(rows []Row, id string) *Row {
+	if i, ok := l.ids[id]; ok {
+		return &rows[i]
+	}
+	var row *Row
+	for _, i := range l.paths[id] {
+		if i >= len(rows) {
+			return nil
+		}
+		row = &rows[i]
+		rows = row.Children
+	}
+	return row
+}
+
+// lookupRows returns the row lookup, rebuilding it if Rows has been replaced.
+// The cached views index or copy the replaced rows, so they are reset too.
+func (dt *DataTable) lookupRows() *rowLookup {
+	l := dt.lookup
+	if l != nil && len(l.rows) == len(dt.Rows) &&
+		(len(l.rows) == 0 || &l.rows[0] == &dt.Rows[0]) {
+		return l
+	}
+	l = dt.indexRows()
+	if dt.view != nil || dt.tree != nil || dt.grouped != nil {
+		dt.resetView()
+	}
+	return l
+}
+
+// rowByID returns the row with the given ID, at any depth, or nil.
+func (dt *DataTable) rowByID(id string) *Row {
+	row := dt.lookupRows().find(dt.Rows, id)
+	if row != nil && row.ID != id {
+		// A row ID was changed in place.
+		row = dt.indexRows().find(dt.Rows, id)
+	}
+	return row
+}
+
+// cachedCounts returns the cached counts.
+func (dt *DataTable) cachedCounts() *rowCounts {
+	dt.lookupRows()
+	if dt.counts == nil {
+		dt.counts = &rowCounts{enabled: -1, allSelected: -1}
+	}
+	return dt.counts
+}
+
+// selectionChanged clears the values cached from the rows' selection. The
+// filtered and sorted row indexes are kept; tree and grouped rows are copies
+// of the loaded rows, so they are rebuilt.
+func (dt *DataTable) selectionChanged() {
+	dt.tree = nil
+	dt.grouped = nil
+	if dt.counts != nil {
+		dt.counts.allSelected = -1
+	}
+}
+
+// enabledMatchingCount returns the number of enabled rows matching the
+// filters.
+func (dt *DataTable) enabledMatchingCount() int {
+	counts := dt.cachedCounts()
+	if counts.enabled >= 0 {
+		return counts.enabled
+	}
+	if dt.isTree() {
+		return dt.countTree().enabled
+	}
+	counts.enabled = 0
+	if !dt.IsFiltered() {
+		for _, row := range dt.Rows {
+			if !row.Disabled {
+				counts.enabled++
+			}
+		}
+		return counts.enabled
+	}
+	for _, i := range dt.viewIndexes() {
+		if !dt.Rows[i].Disabled {
+			counts.enabled++
+		}
+	}
+	return counts.enabled
+}
+
+// countTree counts the rows of a tree table matching the filters, at any
+// depth.
+func (dt *DataTable) countTree() *rowCounts {
+	counts := dt.cachedCounts()
+	if counts.enabled >= 0 {
+		return counts
+	}
+	counts.enabled, counts.matching = 0, 0
+	matches := dt.filterMatcher()
+	walkRows(dt.Rows, func(row *Row) {
+		if !matches(*row) {
+			return
+		}
+		counts.matching++
+		if !row.Disabled {
+			counts.enabled++
+		}
+	})
+	return counts
+}
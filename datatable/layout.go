@@ -0,0 +1,255 @@
+package datatable
+
+import (
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+)
+
+// Pin fixes a column to one side of the table while scrolling horizontally.
+type Pin string
+
+const (
+	// PinLeft keeps the column at the left edge.
+	PinLeft Pin = "left"
+	// PinRight keeps the column at the right edge.
+	PinRight Pin = "right"
+)
+
+// minColumnWidth is the narrowest width, in pixels, ResizeColumn allows.
+const minColumnWidth = 40
+
+// defaultPinnedWidth is the width, in pixels, of pinned columns without a
+// pixel Width, so the sticky offsets of the columns beside them are known.
+const defaultPinnedWidth = 160
+
+// Layout is the column arrangement of a table: order, visibility, widths and
+// pinning. It is JSON-serialisable so it can be stored per user and restored
+// with ApplyLayout or WithLayout.
+type Layout struct {
+	// Columns lists the columns in display order
+	Columns []ColumnLayout `json:"columns"`
+	// StickyHeader keeps the header visible while scrolling
+	StickyHeader bool `json:"stickyHeader,omitempty"`
+}
+
+// ColumnLayout is the layout of one column.
+type ColumnLayout struct {
+	// ID is the column ID
+	ID string `json:"id"`
+	// Hidden hides the column
+	Hidden bool `json:"hidden,omitempty"`
+	// Width is the column width (e.g. "240px")
+	Width string `json:"width,omitempty"`
+	// Pinned fixes the column to the left or right edge
+	Pinned Pin `json:"pinned,omitempty"`
+}
+
+// Layout returns the current column layout.
+func (dt *DataTable) Layout() Layout {
+	l := Layout{
+		Columns:      make([]ColumnLayout, len(dt.Columns)),
+		StickyHeader: dt.StickyHeader,
+	}
+	for i, col := range dt.Columns {
+		l.Columns[i] = ColumnLayout{ID: col.ID, Hidden: col.Hidden, Width: col.Width, Pinned: col.Pinned}
+	}
+	return l
+}
+
+// ApplyLayout restores a layout. Columns are reordered to follow the layout;
+// unknown column IDs are ignored and columns missing from the layout (e.g.
+// added since it was saved) keep their settings and follow in their current
+// order.
+func (dt *DataTable) ApplyLayout(l Layout) {
+	columns := make([]Column, 0, len(dt.Columns))
+	used := make(map[string]bool, len(l.Columns))
+	for _, cl := range l.Columns {
+		col := dt.GetColumn(cl.ID)
+		if col == nil || used[cl.ID] {
+			continue
+		}
+		used[cl.ID] = true
+		c := *col
+		c.Hidden = cl.Hidden
+		c.Width = cl.Width
+		c.Pinned = cl.Pinned
+		columns = append(columns, c)
+	}
+	for _, col := range dt.Columns {
+		if !used[col.ID] {
+			columns = append(columns, col)
+		}
+	}
+	dt.Columns = columns
+	dt.StickyHeader = l.StickyHeader
+	dt.resetView()
+}
+
+// ToggleColumn shows a hidden column or hides a visible one. The last
+// visible column cannot be hidden.
+func (dt *DataTable) ToggleColumn(id string) {
+	col := dt.GetColumn(id)
+	if col == nil {
+		return
+	}
+	if !col.Hidden && len(dt.VisibleColumns()) <= 1 {
+		return
+	}
+	col.Hidden = !col.Hidden
+	dt.resetView()
+}
+
+// MoveColumn moves a column to index in Columns, clamped to the valid range.
+func (dt *DataTable) MoveColumn(id string, index int) {
+	from := dt.columnIndex(id)
+	if from < 0 {
+		return
+	}
+	index = min(max(index, 0), len(dt.Columns)-1)
+	col := dt.Columns[from]
+	dt.Columns = append(dt.Columns[:from], dt.Columns[from+1:]...)
+	dt.Columns = append(dt.Columns[:index], append([]Column{col}, dt.Columns[index:]...)...)
+}
+
+// MoveColumnLeft swaps a column with the one before it.
+func (dt *DataTable) MoveColumnLeft(id string) {
+	if i := dt.columnIndex(id); i > 0 {
+		dt.Columns[i-1], dt.Columns[i] = dt.Columns[i], dt.Columns[i-1]
+	}
+}
+
+// MoveColumnRight swaps a column with the one after it.
+func (dt *DataTable) MoveColumnRight(id string) {
+	if i := dt.columnIndex(id); i >= 0 && i < len(dt.Columns)-1 {
+		dt.Columns[i], dt.Columns[i+1] = dt.Columns[i+1], dt.Columns[i]
+	}
+}
+
+// ResizeColumn sets a column's width in pixels (at least 40). A width of 0
+// restores automatic sizing.
+func (dt *DataTable) ResizeColumn(id string, width int) {
+	col := dt.GetColumn(id)
+	if col == nil {
+		return
+	}
+	if width <= 0 {
+		col.Width = ""
+		return
+	}
+	col.Width = strconv.Itoa(max(width, minColumnWidth)) + "px"
+}
+
+// PinColumn pins a column to the left or right edge, or unpins it with "".
+func (dt *DataTable) PinColumn(id string, pin Pin) {
+	col := dt.GetColumn(id)
+	if col == nil {
+		return
+	}
+	switch pin {
+	case PinLeft, PinRight:
+		col.Pinned = pin
+	default:
+		col.Pinned = ""
+	}
+}
+
+// ToggleColumnChooser opens or closes the column chooser.
+func (dt *DataTable) ToggleColumnChooser() {
+	dt.ColumnChooserOpen = !dt.ColumnChooserOpen
+}
+
+// columnIndex returns the index in Columns of a column ID, or -1.
+func (dt *DataTable) columnIndex(id string) int {
+	for i := range dt.Columns {
+		if dt.Columns[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// pinnedColumns orders columns left-pinned first and right-pinned last,
+// keeping the order within each group.
+func pinnedColumns(columns []Column) []Column {
+	var left, middle, right []Column
+	for _, col := range columns {
+		switch col.Pinned {
+		case PinLeft:
+			left = append(left, col)
+		case PinRight:
+			right = append(right, col)
+		default:
+			middle = append(middle, col)
+		}
+	}
+	return append(append(left, middle...), right...)
+}
+
+// pixelWidth returns a column's width in pixels, or defaultPinnedWidth if its
+// Width is not in pixels.
+func pixelWidth(col Column) int {
+	if px, ok := strings.CutSuffix(strings.TrimSpace(col.Width), "px"); ok {
+		if n, err := strconv.Atoi(strings.TrimSpace(px)); err == nil && n > 0 {
+			return n
+		}
+	}
+	return defaultPinnedWidth
+}
+
+// columnStyle returns the inline style of a visible column's cells: pinned
+// columns are sticky at the combined width of the pinned columns before them
+// (after them, for right-pinned columns). Header cells also get the column
+// width and, with a sticky header, stick to the top. Sticky cells are opaque;
+// set the --lvt-pinned-bg CSS variable to change their background.
+func columnStyle(columns []Column, id string, header, stickyHeader bool) template.CSS {
+	var parts []string
+	idx := -1
+	for i, col := range columns {
+		if col.ID == id {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return ""
+	}
+	col := columns[idx]
+
+	pinned := col.Pinned == PinLeft || col.Pinned == PinRight
+	if header && pinned {
+		width := pixelWidth(col)
+		parts = append(parts, fmt.Sprintf("width: %dpx", width), fmt.Sprintf("min-width: %dpx", width))
+	} else if header && col.Width != "" {
+		parts = append(parts, "width: "+col.Width)
+	}
+
+	switch {
+	case pinned:
+		side, offset := "left", 0
+		if col.Pinned == PinLeft {
+			for _, c := range columns[:idx] {
+				if c.Pinned == PinLeft {
+					offset += pixelWidth(c)
+				}
+			}
+		} else {
+			side = "right"
+			for _, c := range columns[idx+1:] {
+				if c.Pinned == PinRight {
+					offset += pixelWidth(c)
+				}
+			}
+		}
+		parts = append(parts, "position: sticky", fmt.Sprintf("%s: %dpx", side, offset), "background-color: var(--lvt-pinned-bg, #fff)")
+		if header && stickyHeader {
+			parts = append(parts, "top: 0", "z-index: 3")
+		} else {
+			parts = append(parts, "z-index: 1")
+		}
+	case header && stickyHeader:
+		parts = append(parts, "position: sticky", "top: 0", "z-index: 2", "background-color: var(--lvt-pinned-bg, #fff)")
+	}
+	return template.CSS(strings.Join(parts, "; "))
+}
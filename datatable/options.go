@@ -1,5 +1,12 @@
 package datatable
 
+import (
+	"context"
+	"html/template"
+
+	"github.com/livetemplate/components/base"
+)
+
 // Option is a functional option for configuring data tables.
 type Option func(*DataTable)
 
@@ -14,6 +21,7 @@ func WithColumns(columns []Column) Option {
 func WithRows(rows []Row) Option {
 	return func(dt *DataTable) {
 		dt.Rows = rows
+		dt.indexRows()
 	}
 }
 
@@ -24,6 +32,54 @@ func WithPageSize(size int) Option {
 	}
 }
 
+// WithPageSizeOptions sets the page sizes offered by the page size selector.
+// The selector is hidden when no options are set.
+func WithPageSizeOptions(sizes ...int) Option {
+	return func(dt *DataTable) {
+		dt.PageSizeOptions = sizes
+	}
+}
+
+// WithPageWindow sets how many page links are shown either side of the
+// current page (default 2).
+func WithPageWindow(window int) Option {
+	return func(dt *DataTable) {
+		dt.PageWindow = window
+	}
+}
+
+// WithPageInfo localises the page range text. format receives the 1-based
+// start row, end row and total as %[1]d, %[2]d and %[3]d; noResults is shown
+// when nothing matches.
+//
+// Example:
+//
+//	datatable.WithPageInfo("%[1]d–%[2]d von %[3]d", "Keine Ergebnisse")
+func WithPageInfo(format, noResults string) Option {
+	return func(dt *DataTable) {
+		dt.PageInfoFormat = format
+		dt.NoResultsMessage = noResults
+	}
+}
+
+// WithVirtualRows renders only the page rows in view, viewportRows rows of
+// rowHeight pixels, with spacers for the rest. The table scrolls within a
+// viewport and sends "scroll" actions as it does. Use it with a PageSize of
+// 0 or WithInfiniteScroll to show thousands of rows.
+func WithVirtualRows(viewportRows, rowHeight int) Option {
+	return func(dt *DataTable) {
+		dt.Window = base.NewWindow(viewportRows, rowHeight)
+	}
+}
+
+// WithInfiniteScroll appends the next page when the table is scrolled to the
+// bottom (or "Load more" is clicked) instead of paginating.
+func WithInfiniteScroll(infinite bool) Option {
+	return func(dt *DataTable) {
+		dt.Infinite = infinite
+	}
+}
+
 // WithSelectable enables row selection.
 func WithSelectable(selectable bool) Option {
 	return func(dt *DataTable) {
@@ -76,6 +132,46 @@ func WithEmptyMessage(message string) Option {
 	}
 }
 
+// WithStickyHeader keeps the header row visible while the table scrolls. The
+// styled template limits the table's height so it scrolls within the page.
+func WithStickyHeader(sticky bool) Option {
+	return func(dt *DataTable) {
+		dt.StickyHeader = sticky
+	}
+}
+
+// WithColumnChooser shows a button that opens the column chooser, where
+// users can show, hide, reorder, resize and pin columns.
+func WithColumnChooser(enabled bool) Option {
+	return func(dt *DataTable) {
+		dt.ColumnChooser = enabled
+	}
+}
+
+// WithLayout restores a saved Layout. It must follow WithColumns.
+func WithLayout(layout Layout) Option {
+	return func(dt *DataTable) {
+		dt.ApplyLayout(layout)
+	}
+}
+
+// WithViewStore enables saved views, stored in store under the table ID.
+// Call LoadViews to list the existing views.
+//
+// Example:
+//
+//	dt := datatable.New("users",
+//	    datatable.WithColumns(columns),
+//	    datatable.WithViewStore(datatable.NewFileViewStore("views.json")),
+//	)
+//	err := dt.LoadViews(ctx)
+func WithViewStore(store ViewStore) Option {
+	return func(dt *DataTable) {
+		dt.views = store
+		dt.ViewsEnabled = store != nil
+	}
+}
+
 // WithStyled enables Tailwind CSS styling for the component.
 func WithStyled(styled bool) Option {
 	return func(dt *DataTable) {
@@ -86,8 +182,14 @@ func WithStyled(styled bool) Option {
 // WithSort sets initial sorting.
 func WithSort(columnID string, direction SortDirection) Option {
 	return func(dt *DataTable) {
-		dt.SortColumn = columnID
-		dt.SortDirection = direction
+		dt.SetSortKeys(SortKey{Column: columnID, Direction: direction})
+	}
+}
+
+// WithSortKeys sets the initial multi-column sort.
+func WithSortKeys(keys ...SortKey) Option {
+	return func(dt *DataTable) {
+		dt.SetSortKeys(keys...)
 	}
 }
 
@@ -98,6 +200,158 @@ func WithFilter(value string) Option {
 	}
 }
 
+// WithFilterColumn limits the text filter to a single column.
+func WithFilterColumn(columnID string) Option {
+	return func(dt *DataTable) {
+		dt.FilterColumn = columnID
+	}
+}
+
+// WithColumnFilters sets initial per-column filters.
+func WithColumnFilters(filters ...ColumnFilter) Option {
+	return func(dt *DataTable) {
+		for _, f := range filters {
+			dt.SetColumnFilter(f)
+		}
+	}
+}
+
+// WithDataSource loads rows from a DataSource instead of Rows.
+// Call Load to fetch the first page.
+func WithDataSource(source DataSource) Option {
+	return func(dt *DataTable) {
+		dt.source = source
+	}
+}
+
+// WithExportFormats shows export buttons for the given formats.
+// The buttons trigger the "export" action, handled by WithOnExport.
+func WithExportFormats(formats ...ExportFormat) Option {
+	return func(dt *DataTable) {
+		dt.ExportFormats = formats
+	}
+}
+
+// WithBulkAction adds a button to the selection toolbar that runs handler on
+// the selected rows.
+//
+// Example:
+//
+//	datatable.WithBulkAction("archive", "Archive", func(ctx context.Context, sel datatable.Selection) error {
+//	    return sel.EachID(ctx, store.Archive)
+//	})
+func WithBulkAction(name, label string, handler func(ctx context.Context, sel Selection) error) Option {
+	return func(dt *DataTable) {
+		dt.BulkActions = append(dt.BulkActions, BulkAction{Name: name, Label: label, Handler: handler})
+	}
+}
+
+// WithRowActions adds entries to the actions menu shown at the end of each
+// row. An action's Enabled predicate can disable it for some rows.
+//
+// Example:
+//
+//	datatable.WithRowActions(
+//	    datatable.RowAction{Name: "edit", Label: "Edit", Handler: editUser},
+//	    datatable.RowAction{Name: "delete", Label: "Delete", Handler: deleteUser,
+//	        Enabled: func(row datatable.Row) bool { return row.Data["role"] != "admin" }},
+//	)
+func WithRowActions(actions ...RowAction) Option {
+	return func(dt *DataTable) {
+		dt.RowActions = append(dt.RowActions, actions...)
+	}
+}
+
+// WithOnRowClick sets the handler for the "click" action, sent when a row is
+// clicked. Clicks on checkboxes, editable cells, buttons and the cells of a
+// single-select table trigger their own actions instead.
+func WithOnRowClick(fn func(ctx context.Context, row Row) error) Option {
+	return func(dt *DataTable) {
+		dt.onRowClick = fn
+		dt.RowClickEnabled = fn != nil
+	}
+}
+
+// WithOnRowOpen sets the handler for the "open" action, sent when a row is
+// double-clicked or Enter is pressed on it, e.g. to navigate to a detail page.
+//
+// Example:
+//
+//	datatable.WithOnRowOpen(func(ctx context.Context, row datatable.Row) error {
+//	    state.Redirect = "/users/" + row.ID
+//	    return nil
+//	})
+func WithOnRowOpen(fn func(ctx context.Context, row Row) error) Option {
+	return func(dt *DataTable) {
+		dt.onRowOpen = fn
+		dt.RowOpenEnabled = fn != nil
+	}
+}
+
+// WithOnExport sets the handler for the "export" action. A typical handler
+// stores the request and points the browser at a download endpoint that
+// calls Export.
+//
+// Example:
+//
+//	datatable.WithOnExport(func(ctx context.Context, req datatable.ExportRequest) error {
+//	    state.PendingExport = req
+//	    state.DownloadURL = "/export/users?format=" + string(req.Format) + "&scope=" + string(req.Scope)
+//	    return nil
+//	})
+func WithOnExport(fn func(ctx context.Context, req ExportRequest) error) Option {
+	return func(dt *DataTable) {
+		dt.onExport = fn
+	}
+}
+
+// WithOnCellEdit sets a handler called before an edited cell value is
+// stored, after the column's Validator. Returning an error rejects the edit
+// and shows the error next to the cell; use it to persist the change.
+//
+// Example:
+//
+//	datatable.WithOnCellEdit(func(rowID, columnID string, oldValue, newValue any) error {
+//	    return db.UpdateUser(rowID, columnID, newValue)
+//	})
+func WithOnCellEdit(fn func(rowID, columnID string, oldValue, newValue any) error) Option {
+	return func(dt *DataTable) {
+		dt.onCellEdit = fn
+	}
+}
+
+// WithDetailTemplate renders the named template from tmpl below expanded rows.
+// The template receives the Row. Rows are expanded with the "toggle_expand"
+// action.
+//
+// Example:
+//
+//	tmpl := template.Must(template.New("").Parse(
+//	    `{{define "user-detail"}}<p>{{index .Data "bio"}}</p>{{end}}`))
+//	datatable.WithDetailTemplate(tmpl, "user-detail")
+func WithDetailTemplate(tmpl *template.Template, name string) Option {
+	return func(dt *DataTable) {
+		dt.detailTmpl = tmpl
+		dt.DetailTemplate = name
+	}
+}
+
+// WithGroupBy groups rows by the given column IDs, outermost first. Group
+// headers show the aggregates of columns with an Aggregate.
+//
+// Example:
+//
+//	datatable.WithColumns([]datatable.Column{
+//	    {ID: "region", Label: "Region"},
+//	    {ID: "revenue", Label: "Revenue", Format: "currency", Aggregate: datatable.AggregateSum},
+//	}),
+//	datatable.WithGroupBy("region"),
+func WithGroupBy(columns ...string) Option {
+	return func(dt *DataTable) {
+		dt.GroupBy = columns
+	}
+}
+
 // WithLoading sets initial loading state.
 func WithLoading(loading bool) Option {
 	return func(dt *DataTable) {
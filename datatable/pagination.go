@@ -0,0 +1,128 @@
+package datatable
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DefaultPageInfoFormat is the default PageInfoFormat, e.g. "Showing 11–20 of 345".
+const DefaultPageInfoFormat = "Showing %[1]d–%[2]d of %[3]d"
+
+// DefaultNoResultsMessage is the default NoResultsMessage.
+const DefaultNoResultsMessage = "No results"
+
+// defaultPageWindow is the default PageWindow.
+const defaultPageWindow = 2
+
+// PageLink is an entry in the windowed page number list.
+type PageLink struct {
+	// Page is the 0-indexed page (-1 for an ellipsis)
+	Page int
+	// Label is the 1-indexed page number, or "…" for an ellipsis
+	Label string
+	// Current marks the current page
+	Current bool
+	// Ellipsis marks a gap of skipped pages
+	Ellipsis bool
+}
+
+// PageLinks returns the page numbers to show: the first and last pages and
+// PageWindow pages either side of the current page, with an ellipsis for each
+// gap. A gap of a single page shows that page instead of an ellipsis.
+//
+// For page 6 of 20 with a window of 2 the labels are:
+//
+//	1 … 4 5 6 7 8 … 20
+func (dt *DataTable) PageLinks() []PageLink {
+	pages := dt.TotalPages()
+	if pages <= 1 {
+		return nil
+	}
+	window := dt.PageWindow
+	if window < 0 {
+		window = 0
+	}
+	lo := max(dt.Page-window, 1)
+	hi := min(dt.Page+window, pages-2)
+
+	links := make([]PageLink, 0, 2*window+5)
+	add := func(page int) {
+		links = append(links, PageLink{Page: page, Label: strconv.Itoa(page + 1), Current: page == dt.Page})
+	}
+	gap := func() {
+		links = append(links, PageLink{Page: -1, Label: "…", Ellipsis: true})
+	}
+
+	add(0)
+	if lo > 2 {
+		gap()
+	} else if lo == 2 {
+		add(1)
+	}
+	for page := lo; page <= hi; page++ {
+		add(page)
+	}
+	if hi < pages-3 {
+		gap()
+	} else if hi == pages-3 {
+		add(pages - 2)
+	}
+	add(pages - 1)
+	return links
+}
+
+// SetPageSize changes the number of rows per page (0 for all), staying on the
+// page that contains the first row currently shown.
+func (dt *DataTable) SetPageSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	first := 0
+	if dt.PageSize > 0 {
+		first = dt.Page * dt.PageSize
+	}
+	dt.PageSize = size
+	dt.Page = 0
+	if size > 0 {
+		dt.Page = first / size
+	}
+	dt.clampPage()
+}
+
+// JumpToPage goes to a 1-indexed page, as typed by a user. Numbers outside
+// the valid range go to the first or last page.
+func (dt *DataTable) JumpToPage(page int) {
+	dt.Page = page - 1
+	if dt.Page < 0 {
+		dt.Page = 0
+	}
+	dt.clampPage()
+}
+
+// clampPage moves past-the-end pages back to the last page, e.g. when
+// filtering, collapsing or replacing data shrinks the result set.
+func (dt *DataTable) clampPage() {
+	if dt.Page <= 0 {
+		dt.Page = 0
+		return
+	}
+	if last := dt.TotalPages() - 1; dt.Page > last {
+		dt.Page = last
+	}
+}
+
+// formatPageInfo formats PageInfoFormat, or NoResultsMessage when there are
+// no rows.
+func (dt *DataTable) formatPageInfo(start, end, total int) string {
+	if total == 0 {
+		if dt.NoResultsMessage == "" {
+			return DefaultNoResultsMessage
+		}
+		return dt.NoResultsMessage
+	}
+	format := dt.PageInfoFormat
+	if format == "" {
+		format = DefaultPageInfoFormat
+	}
+	return fmt.Sprintf(format, start, end, total)
+}
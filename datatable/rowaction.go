@@ -0,0 +1,150 @@
+package datatable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/livetemplate/components/menu"
+)
+
+// RowAction is an entry in the actions menu shown at the end of each row.
+type RowAction struct {
+	// Name identifies the action in "row_action" requests
+	Name string
+	// Label is the menu item text
+	Label string
+	// Icon is an optional icon class/name
+	Icon string
+	// Enabled reports whether the action applies to a row (nil for every
+	// row); disabled actions are shown greyed out
+	Enabled func(row Row) bool `json:"-"`
+	// Handler runs the action on the row
+	Handler func(ctx context.Context, row Row) error `json:"-"`
+}
+
+// enabled reports whether the action applies to row.
+func (a RowAction) enabled(row Row) bool {
+	return a.Enabled == nil || a.Enabled(row)
+}
+
+// Errors returned by the "click" and "open" actions without a handler.
+var (
+	errNoRowClickHandler = errors.New("datatable: click requires WithOnRowClick")
+	errNoRowOpenHandler  = errors.New("datatable: open requires WithOnRowOpen")
+)
+
+// HasRowActions returns true if rows have an actions menu.
+func (dt *DataTable) HasRowActions() bool {
+	return len(dt.RowActions) > 0
+}
+
+// RowMenu returns the items of a row's actions menu, rendered like a
+// menu.Menu. Item IDs are the action names; actions whose Enabled predicate
+// rejects the row are disabled.
+func (dt *DataTable) RowMenu(row Row) []menu.Item {
+	if !dt.HasRowActions() {
+		return nil
+	}
+	// Predicates are not encoded, so a decoded table uses the encoded menus.
+	if items, ok := dt.rowMenus[row.ID]; ok && !dt.hasRowActionPredicates() {
+		return items
+	}
+	items := make([]menu.Item, len(dt.RowActions))
+	for i, action := range dt.RowActions {
+		items[i] = menu.Item{
+			ID:       action.Name,
+			Label:    action.Label,
+			Icon:     action.Icon,
+			Disabled: !action.enabled(row),
+		}
+	}
+	return items
+}
+
+// hasRowActionPredicates returns true if any row action has an Enabled
+// predicate.
+func (dt *DataTable) hasRowActionPredicates() bool {
+	for _, action := range dt.RowActions {
+		if action.Enabled != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// rowMenusOf returns the actions menus of rows by row ID, without group
+// headers, for MarshalJSON.
+func (dt *DataTable) rowMenusOf(rows []Row) map[string][]menu.Item {
+	if !dt.HasRowActions() {
+		return nil
+	}
+	menus := make(map[string][]menu.Item, len(rows))
+	for _, row := range rows {
+		if row.Group == nil {
+			menus[row.ID] = dt.RowMenu(row)
+		}
+	}
+	return menus
+}
+
+// ToggleRowMenu opens the actions menu of a row, closing any other, or closes
+// it if it is open.
+func (dt *DataTable) ToggleRowMenu(id string) {
+	if dt.OpenRowMenu == id {
+		dt.OpenRowMenu = ""
+		return
+	}
+	dt.OpenRowMenu = id
+}
+
+// IsRowMenuOpen checks if the actions menu of a row is open.
+func (dt *DataTable) IsRowMenuOpen(id string) bool {
+	return id != "" && dt.OpenRowMenu == id
+}
+
+// RunRowAction closes the row's actions menu and runs the named action on a
+// loaded row. It fails if the row or action is unknown or the action is not
+// enabled for the row.
+func (dt *DataTable) RunRowAction(ctx context.Context, name, rowID string) error {
+	dt.OpenRowMenu = ""
+	row := dt.rowByID(rowID)
+	if row == nil {
+		return fmt.Errorf("datatable: unknown row %q", rowID)
+	}
+	for _, action := range dt.RowActions {
+		if action.Name != name || action.Handler == nil {
+			continue
+		}
+		if !action.enabled(*row) {
+			return fmt.Errorf("datatable: row action %q is not enabled for row %q", name, rowID)
+		}
+		return action.Handler(ctx, *row)
+	}
+	return fmt.Errorf("datatable: unknown row action %q", name)
+}
+
+// ClickRow passes a loaded row to the WithOnRowClick handler.
+func (dt *DataTable) ClickRow(ctx context.Context, rowID string) error {
+	if dt.onRowClick == nil {
+		return errNoRowClickHandler
+	}
+	return dt.runRowHandler(ctx, dt.onRowClick, rowID)
+}
+
+// OpenRow passes a loaded row to the WithOnRowOpen handler.
+func (dt *DataTable) OpenRow(ctx context.Context, rowID string) error {
+	if dt.onRowOpen == nil {
+		return errNoRowOpenHandler
+	}
+	return dt.runRowHandler(ctx, dt.onRowOpen, rowID)
+}
+
+// runRowHandler calls fn with the loaded row rowID.
+func (dt *DataTable) runRowHandler(ctx context.Context, fn func(ctx context.Context, row Row) error, rowID string) error {
+	row := dt.rowByID(rowID)
+	if row == nil {
+		return fmt.Errorf("datatable: unknown row %q", rowID)
+	}
+	return fn(ctx, *row)
+}
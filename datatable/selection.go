@@ -0,0 +1,219 @@
+package datatable
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SelectionMode selects how SelectedIDs and ExcludedIDs are interpreted.
+type SelectionMode string
+
+const (
+	// SelectionInclude selects exactly the rows in SelectedIDs (the default).
+	SelectionInclude SelectionMode = "include"
+	// SelectionExclude selects every enabled row matching the current filters
+	// except those in ExcludedIDs, including rows that are not loaded.
+	SelectionExclude SelectionMode = "exclude"
+)
+
+// BulkAction is an action applied to the selected rows from the selection
+// toolbar.
+type BulkAction struct {
+	// Name identifies the action in "bulk_action" requests
+	Name string
+	// Label is the button text
+	Label string
+	// Handler applies the action to the selection
+	Handler func(ctx context.Context, sel Selection) error `json:"-"`
+}
+
+// Selection is a snapshot of the selected rows, passed to bulk actions.
+//
+// In SelectionExclude mode the selection is every row matching Query except
+// ExcludedIDs. Handlers backed by a database can apply it directly (e.g.
+// "UPDATE ... WHERE <Query> AND id NOT IN (<ExcludedIDs>)"), or stream the IDs
+// with EachID.
+type Selection struct {
+	// Mode is SelectionInclude or SelectionExclude
+	Mode SelectionMode
+	// IDs are the selected row IDs in SelectionInclude mode, sorted
+	IDs []string
+	// ExcludedIDs are the deselected row IDs in SelectionExclude mode, sorted
+	ExcludedIDs []string
+	// Query holds the filters the selection applies to in SelectionExclude
+	// mode (Offset and Limit are zero)
+	Query Query
+	// Count is the number of selected rows (see DataTable.SelectedCount)
+	Count int
+
+	dt *DataTable
+}
+
+// EachID calls fn with each selected row ID (see DataTable.EachSelectedID).
+func (s Selection) EachID(ctx context.Context, fn func(id string) error) error {
+	return s.dt.EachSelectedID(ctx, fn)
+}
+
+// SelectAllMatching selects every row matching the current filters, across
+// all pages and including rows not loaded from the DataSource. Rows can then
+// be deselected individually; the selection is kept as an exclusion set and is
+// cleared when the filters change.
+func (dt *DataTable) SelectAllMatching() {
+	if !dt.Selectable || !dt.MultiSelect {
+		return
+	}
+	dt.SelectionMode = SelectionExclude
+	dt.SelectedIDs = make(map[string]bool)
+	dt.ExcludedIDs = make(map[string]bool)
+	walkRows(dt.Rows, func(row *Row) {
+		row.Selected = dt.selected(*row)
+	})
+	dt.selectionChanged()
+}
+
+// IsSelectingAllMatching returns true if the selection is every row matching
+// the filters (SelectionExclude mode).
+func (dt *DataTable) IsSelectingAllMatching() bool {
+	return dt.SelectionMode == SelectionExclude
+}
+
+// MatchingCount returns the number of rows matching the filters, without
+// group headers. Tree tables count matching rows at any depth. With a
+// DataSource it is the total reported by the source.
+func (dt *DataTable) MatchingCount() int {
+	if dt.pagedRows() {
+		return dt.total
+	}
+	if dt.isTree() {
+		return dt.countTree().matching
+	}
+	if !dt.IsFiltered() {
+		return len(dt.Rows)
+	}
+	return len(dt.viewIndexes())
+}
+
+// Selection returns a snapshot of the selection.
+func (dt *DataTable) Selection() Selection {
+	sel := Selection{Mode: SelectionInclude, Count: dt.SelectedCount(), dt: dt}
+	if dt.IsSelectingAllMatching() {
+		sel.Mode = SelectionExclude
+		sel.ExcludedIDs = sortedKeys(dt.ExcludedIDs)
+		sel.Query = dt.query()
+		return sel
+	}
+	sel.IDs = sortedKeys(dt.SelectedIDs)
+	return sel
+}
+
+// EachSelectedID calls fn with each selected row ID, stopping at the first
+// error. In SelectionInclude mode the IDs are sorted; in SelectionExclude mode
+// they follow the current sort order and are streamed from the DataSource in
+// batches, so large selections are never held in memory.
+func (dt *DataTable) EachSelectedID(ctx context.Context, fn func(id string) error) error {
+	if !dt.IsSelectingAllMatching() {
+		for _, id := range sortedKeys(dt.SelectedIDs) {
+			if err := fn(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return dt.eachSelectedRow(ctx, func(row Row) error {
+		return fn(row.ID)
+	})
+}
+
+// eachSelectedRow calls fn for every selected row, in sort order. The rows of
+// a tree table are visited depth first.
+func (dt *DataTable) eachSelectedRow(ctx context.Context, fn func(Row) error) error {
+	if !dt.IsSelectingAllMatching() {
+		if dt.source != nil {
+			return dt.eachSourceRow(ctx, Query{Sort: dt.activeSortKeys()}, dt.SelectedIDs, fn)
+		}
+		if dt.isTree() {
+			return eachRow(flattenTree(nil, dt.Rows, dt.Columns, dt.activeSortKeys()), dt.SelectedIDs, fn)
+		}
+		indexes := queryIndexes(dt.Rows, dt.Columns, Query{Sort: dt.activeSortKeys()})
+		return eachRow(dt.rowsAt(indexes), dt.SelectedIDs, fn)
+	}
+
+	selected := func(row Row) error {
+		if !dt.selected(row) {
+			return nil
+		}
+		return fn(row)
+	}
+	if dt.source != nil {
+		return dt.eachSourceRow(ctx, dt.query(), nil, selected)
+	}
+	if dt.isTree() {
+		matches := dt.filterMatcher()
+		return eachRow(flattenTree(nil, dt.Rows, dt.Columns, dt.activeSortKeys()), nil, func(row Row) error {
+			if !matches(row) {
+				return nil
+			}
+			return selected(row)
+		})
+	}
+	return eachRow(dt.rowsAt(queryIndexes(dt.Rows, dt.Columns, dt.query())), nil, selected)
+}
+
+// selected reports whether a row matching the filters is selected.
+func (dt *DataTable) selected(row Row) bool {
+	if dt.IsSelectingAllMatching() {
+		return !row.Disabled && !dt.ExcludedIDs[row.ID]
+	}
+	return dt.SelectedIDs[row.ID]
+}
+
+// filterMatcher returns a test for whether a loaded row passes the current
+// filters. Rows loaded from a DataSource always do.
+func (dt *DataTable) filterMatcher() func(Row) bool {
+	if dt.pagedRows() || !dt.IsFiltered() {
+		return func(Row) bool { return true }
+	}
+	q := dt.query()
+	needle := foldCase(strings.TrimSpace(q.Search))
+	return func(row Row) bool {
+		return matchesQuery(row, &q, needle)
+	}
+}
+
+// filtersChanged resets to the first page (and row) after a filter change. A
+// select-all-matching selection is cleared, since its exclusion set only
+// applies to the filters it was made under.
+func (dt *DataTable) filtersChanged() {
+	dt.Page = 0
+	if dt.Window != nil {
+		dt.Window.Offset = 0
+	}
+	if dt.IsSelectingAllMatching() {
+		dt.DeselectAll()
+	}
+	dt.resetView()
+}
+
+// runBulkAction runs the named bulk action on the selection.
+func (dt *DataTable) runBulkAction(ctx context.Context, name string) error {
+	for _, action := range dt.BulkActions {
+		if action.Name == name && action.Handler != nil {
+			return action.Handler(ctx, dt.Selection())
+		}
+	}
+	return fmt.Errorf("datatable: unknown bulk action %q", name)
+}
+
+// sortedKeys returns the keys of a set whose value is true, sorted.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k, v := range set {
+		if v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,234 @@
+package datatable
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SortKey is one column of a multi-column sort.
+type SortKey struct {
+	// Column is the column ID to sort by
+	Column string
+	// Direction is SortAsc or SortDesc
+	Direction SortDirection
+}
+
+// Comparator compares two cell values, returning a negative number when a
+// sorts before b, zero when they are equal and a positive number otherwise.
+// It always compares in ascending order; the table reverses it for SortDesc.
+type Comparator func(a, b any) int
+
+// AddSort adds a column as the lowest-priority sort key (the "+" button in a
+// sorted table's headers). Adding a column that is already a key cycles it
+// ascending, descending and then removes it.
+func (dt *DataTable) AddSort(columnID string) {
+	keys := append([]SortKey(nil), dt.activeSortKeys()...)
+	for i := range keys {
+		if keys[i].Column != columnID {
+			continue
+		}
+		if keys[i].Direction == SortDesc {
+			keys = append(keys[:i], keys[i+1:]...)
+		} else {
+			keys[i].Direction = SortDesc
+		}
+		dt.setSortKeys(keys)
+		return
+	}
+	dt.setSortKeys(append(keys, SortKey{Column: columnID, Direction: SortAsc}))
+}
+
+// SetSortKeys replaces all sort keys. Keys with SortNone are dropped.
+func (dt *DataTable) SetSortKeys(keys ...SortKey) {
+	active := make([]SortKey, 0, len(keys))
+	for _, k := range keys {
+		if k.Column != "" && k.Direction != SortNone {
+			active = append(active, k)
+		}
+	}
+	dt.setSortKeys(active)
+}
+
+// IsSorted returns true if any sort key is active.
+func (dt *DataTable) IsSorted() bool {
+	return len(dt.activeSortKeys()) > 0
+}
+
+// SortPriority returns the 1-based position of a column in the sort keys,
+// or 0 if the table is not sorted by it.
+func (dt *DataTable) SortPriority(columnID string) int {
+	for i, k := range dt.activeSortKeys() {
+		if k.Column == columnID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// sortDirectionOf returns the direction a column is sorted in (SortNone if unsorted).
+func (dt *DataTable) sortDirectionOf(columnID string) SortDirection {
+	for _, k := range dt.activeSortKeys() {
+		if k.Column == columnID {
+			return k.Direction
+		}
+	}
+	return SortNone
+}
+
+// activeSortKeys returns SortKeys, falling back to SortColumn/SortDirection
+// for tables configured through those fields directly.
+func (dt *DataTable) activeSortKeys() []SortKey {
+	if len(dt.SortKeys) > 0 {
+		return dt.SortKeys
+	}
+	if dt.SortColumn != "" && dt.SortDirection != SortNone {
+		return []SortKey{{Column: dt.SortColumn, Direction: dt.SortDirection}}
+	}
+	return nil
+}
+
+// setSortKeys stores the keys, mirrors the primary key into SortColumn and
+// SortDirection, and clears the row cache.
+func (dt *DataTable) setSortKeys(keys []SortKey) {
+	if len(keys) == 0 {
+		dt.SortKeys = nil
+		dt.SortColumn = ""
+		dt.SortDirection = SortNone
+	} else {
+		dt.SortKeys = keys
+		dt.SortColumn = keys[0].Column
+		dt.SortDirection = keys[0].Direction
+	}
+	dt.resetView()
+}
+
+// sortIndexes stably sorts row indexes by the given sort keys, using the
+// Comparator of matching columns where set.
+func sortIndexes(rows []Row, columns []Column, keys []SortKey, indexes []int) {
+	if len(keys) == 0 {
+		return
+	}
+
+	comparators := make([]Comparator, len(keys))
+	for i, k := range keys {
+		for _, col := range columns {
+			if col.ID == k.Column {
+				comparators[i] = col.Comparator
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := rows[indexes[i]], rows[indexes[j]]
+		for n, k := range keys {
+			av, bv := a.GetCellValue(k.Column), b.GetCellValue(k.Column)
+
+			var c int
+			if comparators[n] != nil {
+				c = comparators[n](av, bv)
+			} else {
+				// nil values sort last in either direction
+				switch {
+				case av == nil && bv == nil:
+					continue
+				case av == nil:
+					return false
+				case bv == nil:
+					return true
+				}
+				c = sortCompare(av, bv)
+			}
+
+			if c == 0 {
+				continue
+			}
+			if k.Direction == SortDesc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+// sortCompare orders two non-nil cell values. Values that compareValues
+// cannot compare are ordered by kind (numbers, times, bools, then text) so
+// that columns with mixed types still sort deterministically.
+func sortCompare(a, b any) int {
+	if c, ok := compareValues(a, b); ok {
+		return c
+	}
+	if ra, rb := kindRank(a), kindRank(b); ra != rb {
+		return ra - rb
+	}
+	return naturalCompare(foldCase(cellString(a)), foldCase(cellString(b)))
+}
+
+// kindRank groups values for sorting mixed-type columns.
+func kindRank(v any) int {
+	if _, ok := toFloat(v); ok {
+		return 0
+	}
+	if _, ok := toTime(v, false); ok {
+		return 1
+	}
+	if _, ok := v.(bool); ok {
+		return 2
+	}
+	return 3
+}
+
+// naturalCompare compares strings treating runs of digits as numbers, so
+// "file2" sorts before "file10".
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+
+		if isDigit(ra) && isDigit(rb) {
+			da, db := digitRun(a), digitRun(b)
+			if c := compareDigits(da, db); c != 0 {
+				return c
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+
+		if ra != rb {
+			if ra < rb {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[sa:], b[sb:]
+	}
+	return len(a) - len(b)
+}
+
+// compareDigits compares two runs of ASCII digits numerically. Equal values
+// with more leading zeros sort first.
+func compareDigits(a, b string) int {
+	ta, tb := strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(ta) != len(tb) {
+		return len(ta) - len(tb)
+	}
+	if c := strings.Compare(ta, tb); c != 0 {
+		return c
+	}
+	return len(b) - len(a)
+}
+
+func digitRun(s string) string {
+	i := 0
+	for i < len(s) && isDigit(rune(s[i])) {
+		i++
+	}
+	return s[:i]
+}
+
+func isDigit(r rune) bool {
+	return r < utf8.RuneSelf && unicode.IsDigit(r)
+}
@@ -0,0 +1,181 @@
+package datatable
+
+import (
+	"context"
+	"fmt"
+)
+
+// Query describes the rows a DataTable needs from a DataSource.
+type Query struct {
+	// Sort lists the sort keys in priority order
+	Sort []SortKey
+	// Search is the free-text filter (case-insensitive substring match)
+	Search string
+	// SearchColumns limits Search to these column IDs. A nil slice means every
+	// column; an empty slice means no column matches.
+	SearchColumns []string
+	// Filters are per-column filters, all of which must match
+	Filters []ColumnFilter
+	// Offset is the number of matching rows to skip
+	Offset int
+	// Limit is the maximum number of rows to return (0 for all)
+	Limit int
+}
+
+// Page is a slice of query results.
+type Page struct {
+	// Rows are the rows for the requested offset and limit
+	Rows []Row
+	// Total is the number of rows matching the query, ignoring Offset and Limit
+	Total int
+}
+
+// DataSource supplies rows to a DataTable, so large data sets can be sorted,
+// filtered and paged where they live (e.g. in a database) instead of being
+// loaded into DataTable.Rows.
+//
+// Example:
+//
+//	dt := datatable.New("users",
+//	    datatable.WithColumns(columns),
+//	    datatable.WithPageSize(25),
+//	    datatable.WithDataSource(datatable.NewSQLSource(db, "users", []string{"name", "email"})),
+//	)
+//	if err := dt.Load(ctx); err != nil {
+//	    return err
+//	}
+type DataSource interface {
+	// Query returns the rows matching q.
+	Query(ctx context.Context, q Query) (Page, error)
+}
+
+// MemorySource is a DataSource over a slice of rows. Tables without a
+// DataSource use the same filtering and sorting on their own Rows.
+type MemorySource struct {
+	// Rows is the full data set
+	Rows []Row
+	// Columns supplies per-column Comparators for sorting (optional)
+	Columns []Column
+}
+
+// NewMemorySource creates a DataSource over rows.
+func NewMemorySource(rows []Row, columns []Column) *MemorySource {
+	return &MemorySource{Rows: rows, Columns: columns}
+}
+
+// Query filters and sorts the rows in memory and returns the requested page.
+func (s *MemorySource) Query(ctx context.Context, q Query) (Page, error) {
+	if err := ctx.Err(); err != nil {
+		return Page{}, err
+	}
+
+	indexes := queryIndexes(s.Rows, s.Columns, q)
+	page := Page{Total: len(indexes)}
+
+	start := min(max(q.Offset, 0), len(indexes))
+	end := len(indexes)
+	if q.Limit > 0 {
+		end = min(start+q.Limit, end)
+	}
+
+	page.Rows = make([]Row, 0, end-start)
+	for _, idx := range indexes[start:end] {
+		page.Rows = append(page.Rows, s.Rows[idx])
+	}
+	return page, nil
+}
+
+// queryIndexes returns the indexes of the rows matching q, in sort order.
+// Offset and Limit are ignored.
+func queryIndexes(rows []Row, columns []Column, q Query) []int {
+	indexes := filterIndexes(rows, &q)
+	sortIndexes(rows, columns, q.Sort, indexes)
+	return indexes
+}
+
+// query builds a Query from the table's sort and filter state.
+func (dt *DataTable) query() Query {
+	q := Query{
+		Sort:    dt.activeSortKeys(),
+		Filters: dt.ColumnFilters,
+	}
+	if dt.FilterValue != "" {
+		q.Search = dt.FilterValue
+		q.SearchColumns = dt.searchColumns()
+	}
+	return q
+}
+
+// HasDataSource returns true if rows come from a DataSource.
+func (dt *DataTable) HasDataSource() bool {
+	return dt.source != nil
+}
+
+// pagedRows reports whether Rows holds only the current page, already
+// filtered and sorted: with a DataSource, or when decoded from a table that
+// had one.
+func (dt *DataTable) pagedRows() bool {
+	return dt.source != nil || dt.paged
+}
+
+// Load queries the DataSource for the current page and caches it in Rows.
+// Row selection and expansion are restored from the selection and ExpandedIDs.
+// If the current page is past the end of the results (e.g. rows were deleted),
+// the last page is loaded instead. In infinite mode every page up to the
+// current one is loaded, and LoadMore appends just the next page. Facets are
+// queried too. Load does nothing for tables without a DataSource.
+//
+// Actions reload automatically; call Load after New and after changing sort,
+// filter or page state directly.
+func (dt *DataTable) Load(ctx context.Context) error {
+	if dt.source == nil {
+		return nil
+	}
+
+	q := dt.query()
+	appending := false
+	if dt.PageSize > 0 {
+		q.Offset = dt.Page * dt.PageSize
+		q.Limit = dt.PageSize
+		if dt.Infinite {
+			// Append the next page to the loaded ones, or reload them all
+			// after a sort or filter change.
+			appending = dt.loadingMore && len(dt.Rows) == q.Offset
+			dt.loadingMore = false
+			if !appending {
+				q.Offset = 0
+				q.Limit = (dt.Page + 1) * dt.PageSize
+			}
+		}
+	}
+
+	page, err := dt.source.Query(ctx, q)
+	if err != nil {
+		return fmt.Errorf("datatable: query %q: %w", dt.ID(), err)
+	}
+	if len(page.Rows) == 0 && q.Offset > 0 && page.Total > 0 {
+		dt.Page = (page.Total - 1) / dt.PageSize
+		if !appending {
+			q.Offset = dt.Page * dt.PageSize
+			if page, err = dt.source.Query(ctx, q); err != nil {
+				return fmt.Errorf("datatable: query %q: %w", dt.ID(), err)
+			}
+		}
+	}
+
+	for i := range page.Rows {
+		page.Rows[i].Selected = dt.selected(page.Rows[i])
+	}
+	dt.restoreExpanded(page.Rows)
+	if appending {
+		page.Rows = append(dt.Rows, page.Rows...)
+	}
+	dt.Rows = page.Rows
+	dt.indexRows()
+	dt.total = page.Total
+	dt.resetView()
+	if err := dt.loadFacets(ctx); err != nil {
+		return fmt.Errorf("datatable: query %q: %w", dt.ID(), err)
+	}
+	return nil
+}
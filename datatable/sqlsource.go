@@ -0,0 +1,425 @@
+package datatable
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownColumn is returned (wrapped) by SQLSource when a query refers to
+// a column that was not configured. Column IDs arrive from the browser, so
+// they are never interpolated into SQL unless configured.
+var ErrUnknownColumn = errors.New("unknown column")
+
+// SQLQueryer is satisfied by *sql.DB, *sql.Tx and *sql.Conn.
+type SQLQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// SQLSource is a DataSource backed by a database/sql table or view.
+// Sorting, filtering and paging run in the database; only the requested page
+// is loaded.
+//
+// Text search and "contains" filters use LOWER(CAST(column AS TEXT)) LIKE, so
+// case-insensitivity of non-ASCII text depends on the database. Column
+// Comparators are not applied. Rows are ordered by the key column after the
+// sort keys so paging is deterministic.
+//
+// The defaults suit SQLite. PostgreSQL needs WithSQLPlaceholder, and MySQL
+// needs WithSQLTextType("CHAR").
+//
+// Example:
+//
+//	source := datatable.NewSQLSource(db, "users", []string{"name", "email", "created_at"},
+//	    datatable.WithSQLKey("user_id"),
+//	    datatable.WithSQLPlaceholder(datatable.DollarPlaceholder), // PostgreSQL
+//	)
+type SQLSource struct {
+	db          SQLQueryer
+	table       string
+	key         string
+	columns     []string
+	exprs       map[string]string
+	placeholder func(n int) string
+	textType    string
+}
+
+// SQLOption is a functional option for configuring SQL sources.
+type SQLOption func(*SQLSource)
+
+// WithSQLKey sets the column used as Row.ID (default "id").
+func WithSQLKey(column string) SQLOption {
+	return func(s *SQLSource) {
+		s.key = column
+	}
+}
+
+// WithSQLColumnExpr maps a column ID to an SQL expression, e.g.
+// WithSQLColumnExpr("name", "first_name || ' ' || last_name").
+// The expression is trusted and inserted into queries verbatim.
+func WithSQLColumnExpr(columnID, expr string) SQLOption {
+	return func(s *SQLSource) {
+		s.exprs[columnID] = expr
+	}
+}
+
+// WithSQLPlaceholder sets the bind parameter style. The function receives the
+// 1-based parameter position. The default is "?" (SQLite, MySQL).
+func WithSQLPlaceholder(placeholder func(n int) string) SQLOption {
+	return func(s *SQLSource) {
+		s.placeholder = placeholder
+	}
+}
+
+// WithSQLTextType sets the type columns are cast to for text search and
+// "contains" filters. The default is "TEXT" (SQLite, PostgreSQL); MySQL
+// needs "CHAR".
+func WithSQLTextType(typ string) SQLOption {
+	return func(s *SQLSource) {
+		s.textType = typ
+	}
+}
+
+// DollarPlaceholder formats PostgreSQL-style bind parameters ($1, $2, ...).
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// NewSQLSource creates a DataSource that reads columns from table.
+// Column IDs are used as SQL column names unless mapped with WithSQLColumnExpr.
+// The table name and column names are trusted and inserted verbatim.
+func NewSQLSource(db SQLQueryer, table string, columns []string, opts ...SQLOption) *SQLSource {
+	s := &SQLSource{
+		db:          db,
+		table:       table,
+		key:         "id",
+		columns:     columns,
+		exprs:       make(map[string]string),
+		placeholder: func(int) string { return "?" },
+		textType:    "TEXT",
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Query counts the matching rows and selects the requested page.
+// Offset is applied only together with a Limit.
+func (s *SQLSource) Query(ctx context.Context, q Query) (Page, error) {
+	b := &sqlBuilder{source: s}
+	where, err := b.where(q)
+	if err != nil {
+		return Page{}, err
+	}
+	orderBy, err := b.orderBy(q.Sort)
+	if err != nil {
+		return Page{}, err
+	}
+
+	var page Page
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM "+s.table+where, b.args, &page.Total); err != nil {
+		return Page{}, err
+	}
+
+	var stmt strings.Builder
+	stmt.WriteString("SELECT ")
+	stmt.WriteString(s.key)
+	for _, id := range s.columns {
+		stmt.WriteString(", ")
+		stmt.WriteString(s.expr(id))
+	}
+	stmt.WriteString(" FROM ")
+	stmt.WriteString(s.table)
+	stmt.WriteString(where)
+	stmt.WriteString(orderBy)
+	if q.Limit > 0 {
+		stmt.WriteString(" LIMIT " + b.bind(q.Limit) + " OFFSET " + b.bind(max(q.Offset, 0)))
+	}
+
+	rows, err := s.db.QueryContext(ctx, stmt.String(), b.args...)
+	if err != nil {
+		return Page{}, err
+	}
+	defer rows.Close()
+
+	values := make([]any, len(s.columns)+1)
+	dest := make([]any, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return Page{}, err
+		}
+		row := Row{
+			ID:   cellString(sqlCell(values[0])),
+			Data: make(map[string]any, len(s.columns)),
+		}
+		for i, id := range s.columns {
+			row.Data[id] = sqlCell(values[i+1])
+		}
+		page.Rows = append(page.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return Page{}, err
+	}
+
+	return page, nil
+}
+
+// FacetCounts counts the distinct non-NULL values of a column among the rows
+// matching q.
+func (s *SQLSource) FacetCounts(ctx context.Context, q Query, column string) ([]FacetCount, error) {
+	expr, where, args, err := s.facetWhere(q, column)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+expr+", COUNT(*) FROM "+s.table+where+" GROUP BY "+expr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []FacetCount
+	for rows.Next() {
+		var c FacetCount
+		if err := rows.Scan(&c.Value, &c.Count); err != nil {
+			return nil, err
+		}
+		c.Value = sqlCell(c.Value)
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// FacetRange returns the smallest and largest values of a column among the
+// rows matching q.
+func (s *SQLSource) FacetRange(ctx context.Context, q Query, column string) (min, max any, err error) {
+	expr, where, args, err := s.facetWhere(q, column)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.queryRow(ctx, "SELECT MIN("+expr+"), MAX("+expr+") FROM "+s.table+where, args, &min, &max); err != nil {
+		return nil, nil, err
+	}
+	return sqlCell(min), sqlCell(max), nil
+}
+
+// facetWhere returns the expression of a facet column and a WHERE clause
+// matching q and non-NULL values of the column.
+func (s *SQLSource) facetWhere(q Query, column string) (expr, where string, args []any, err error) {
+	expr, err = s.lookup(column)
+	if err != nil {
+		return "", "", nil, err
+	}
+	b := &sqlBuilder{source: s}
+	if where, err = b.where(q); err != nil {
+		return "", "", nil, err
+	}
+	if where == "" {
+		where = " WHERE " + expr + " IS NOT NULL"
+	} else {
+		where += " AND " + expr + " IS NOT NULL"
+	}
+	return expr, where, b.args, nil
+}
+
+// queryRow runs a single-row query.
+func (s *SQLSource) queryRow(ctx context.Context, query string, args []any, dest ...any) error {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// expr returns the SQL expression for a column ID.
+func (s *SQLSource) expr(columnID string) string {
+	if e, ok := s.exprs[columnID]; ok {
+		return e
+	}
+	return columnID
+}
+
+// lookup returns the SQL expression for a configured column ID.
+func (s *SQLSource) lookup(columnID string) (string, error) {
+	if columnID == s.key {
+		return s.key, nil
+	}
+	for _, id := range s.columns {
+		if id == columnID {
+			return s.expr(id), nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownColumn, columnID)
+}
+
+// sqlBuilder accumulates bind arguments while building a statement.
+type sqlBuilder struct {
+	source *SQLSource
+	args   []any
+}
+
+// bind adds an argument and returns its placeholder.
+func (b *sqlBuilder) bind(v any) string {
+	b.args = append(b.args, v)
+	return b.source.placeholder(len(b.args))
+}
+
+// where builds the WHERE clause (with a leading space) for a query.
+func (b *sqlBuilder) where(q Query) (string, error) {
+	var conds []string
+
+	if search := strings.TrimSpace(q.Search); search != "" {
+		columns := q.SearchColumns
+		if columns == nil {
+			columns = b.source.columns
+		}
+		var ors []string
+		for _, id := range columns {
+			expr, err := b.source.lookup(id)
+			if err != nil {
+				return "", err
+			}
+			ors = append(ors, b.like(expr, search))
+		}
+		if len(ors) == 0 {
+			conds = append(conds, "1 = 0")
+		} else {
+			conds = append(conds, "("+strings.Join(ors, " OR ")+")")
+		}
+	}
+
+	for _, f := range q.Filters {
+		expr, err := b.source.lookup(f.Column)
+		if err != nil {
+			return "", err
+		}
+		conds = append(conds, b.filter(expr, f))
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), nil
+}
+
+// filter builds the condition for one column filter.
+func (b *sqlBuilder) filter(expr string, f ColumnFilter) string {
+	switch f.Operator {
+	case FilterEquals:
+		if v, ok := sqlValue(f.Value).(string); ok {
+			return b.lowerText(expr) + " = " + b.bind(foldCase(v))
+		}
+		return expr + " = " + b.bind(sqlValue(f.Value))
+	case FilterRange:
+		var conds []string
+		if f.Min != nil {
+			conds = append(conds, expr+" >= "+b.bind(sqlValue(f.Min)))
+		}
+		if f.Max != nil {
+			conds = append(conds, expr+" <= "+b.bind(sqlValue(f.Max)))
+		}
+		if len(conds) == 0 {
+			return expr + " IS NOT NULL"
+		}
+		return strings.Join(conds, " AND ")
+	case FilterIn:
+		if len(f.Values) == 0 {
+			return "1 = 0"
+		}
+		text := false
+		for _, v := range f.Values {
+			if _, ok := sqlValue(v).(string); ok {
+				text = true
+			}
+		}
+		placeholders := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			if text {
+				placeholders[i] = b.bind(foldCase(cellString(v)))
+			} else {
+				placeholders[i] = b.bind(sqlValue(v))
+			}
+		}
+		if text {
+			expr = b.lowerText(expr)
+		}
+		return expr + " IN (" + strings.Join(placeholders, ", ") + ")"
+	default:
+		return b.like(expr, cellString(f.Value))
+	}
+}
+
+// like builds a case-insensitive substring match.
+func (b *sqlBuilder) like(expr, text string) string {
+	return b.lowerText(expr) + " LIKE " + b.bind("%"+escapeLike(foldCase(text))+"%") + " ESCAPE '!'"
+}
+
+// orderBy builds the ORDER BY clause (with a leading space). NULLs sort last
+// and the key column breaks ties.
+func (b *sqlBuilder) orderBy(keys []SortKey) (string, error) {
+	var terms []string
+	for _, k := range keys {
+		expr, err := b.source.lookup(k.Column)
+		if err != nil {
+			return "", err
+		}
+		dir := "ASC"
+		if k.Direction == SortDesc {
+			dir = "DESC"
+		}
+		terms = append(terms, "CASE WHEN "+expr+" IS NULL THEN 1 ELSE 0 END", expr+" "+dir)
+	}
+	terms = append(terms, b.source.key+" ASC")
+	return " ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// lowerText returns an expression for the lower-cased text form of expr.
+func (b *sqlBuilder) lowerText(expr string) string {
+	return "LOWER(CAST(" + expr + " AS " + b.source.textType + "))"
+}
+
+// escapeLike escapes LIKE wildcards using "!", which needs no quoting in
+// any SQL dialect.
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
+// sqlValue converts numeric strings (as sent in lvt-data-* attributes) to
+// float64 so they compare numerically in the database.
+func sqlValue(v any) any {
+	if s, ok := v.(string); ok {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+// sqlCell converts scanned driver values to cell values.
+func sqlCell(v any) any {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
@@ -0,0 +1,233 @@
+//go:build cgo
+
+package datatable
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/livetemplate/components/base"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openTestDB returns an in-memory SQLite database with a people table.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	stmts := []string{
+		`CREATE TABLE people (id INTEGER PRIMARY KEY, name TEXT, country TEXT, age INTEGER)`,
+		`INSERT INTO people (id, name, country, age) VALUES
+			(1, 'Alice', 'Austria', 31),
+			(2, 'Bob', 'Germany', 45),
+			(3, 'Carol', 'Austria', 27),
+			(4, 'Dave', 'France', NULL),
+			(5, 'Eve_1', 'Germany', 38)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("failed to set up database: %v", err)
+		}
+	}
+	return db
+}
+
+func TestSQLSourceQuery(t *testing.T) {
+	source := NewSQLSource(openTestDB(t), "people", []string{"name", "country", "age"})
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+		total int
+	}{
+		{"all", Query{}, []string{"1", "2", "3", "4", "5"}, 5},
+		{"page", Query{Offset: 2, Limit: 2}, []string{"3", "4"}, 5},
+		{"sort desc with nulls last", Query{Sort: []SortKey{{"age", SortDesc}}}, []string{"2", "5", "1", "3", "4"}, 5},
+		{"multi-column sort", Query{Sort: []SortKey{{"country", SortAsc}, {"age", SortDesc}}}, []string{"1", "3", "4", "2", "5"}, 5},
+		{"search", Query{Search: "AUSTRIA"}, []string{"1", "3"}, 2},
+		{"search columns", Query{Search: "a", SearchColumns: []string{"name"}}, []string{"1", "3", "4"}, 3},
+		{"search escapes wildcards", Query{Search: "_"}, []string{"5"}, 1},
+		{"equals", Query{Filters: []ColumnFilter{{Column: "country", Operator: FilterEquals, Value: "germany"}}}, []string{"2", "5"}, 2},
+		{"equals number from string", Query{Filters: []ColumnFilter{{Column: "age", Operator: FilterEquals, Value: "45"}}}, []string{"2"}, 1},
+		{"range", Query{Filters: []ColumnFilter{{Column: "age", Operator: FilterRange, Min: "30", Max: 40}}}, []string{"1", "5"}, 2},
+		{"in", Query{Filters: []ColumnFilter{{Column: "name", Operator: FilterIn, Values: []any{"alice", "Dave"}}}}, []string{"1", "4"}, 2},
+		{"filtered page", Query{Filters: []ColumnFilter{{Column: "country", Value: "AN"}}, Sort: []SortKey{{"name", SortDesc}}, Limit: 2}, []string{"5", "4"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := source.Query(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("Query returned error: %v", err)
+			}
+			if page.Total != tt.total {
+				t.Errorf("expected Total %d, got %d", tt.total, page.Total)
+			}
+			got := rowIDs(page.Rows)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected rows %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected rows %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSQLSourceRowData(t *testing.T) {
+	source := NewSQLSource(openTestDB(t), "people", []string{"name", "age"},
+		WithSQLColumnExpr("name", "UPPER(name)"),
+	)
+
+	page, err := source.Query(context.Background(), Query{Limit: 1})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	row := page.Rows[0]
+	if row.ID != "1" || row.GetCellValue("name") != "ALICE" || row.GetCellValue("age") != int64(31) {
+		t.Errorf("unexpected row %+v", row)
+	}
+}
+
+func TestSQLSourceUnknownColumn(t *testing.T) {
+	source := NewSQLSource(openTestDB(t), "people", []string{"name"})
+
+	queries := []Query{
+		{Sort: []SortKey{{"password", SortAsc}}},
+		{Filters: []ColumnFilter{{Column: "1=1; DROP TABLE people", Value: "x"}}},
+		{Search: "x", SearchColumns: []string{"secret"}},
+	}
+	for _, q := range queries {
+		if _, err := source.Query(context.Background(), q); !errors.Is(err, ErrUnknownColumn) {
+			t.Errorf("expected ErrUnknownColumn for %+v, got %v", q, err)
+		}
+	}
+}
+
+func TestSQLSourceDialect(t *testing.T) {
+	q := Query{Search: "ann", Filters: []ColumnFilter{{Column: "country", Value: "aus"}}}
+	tests := []struct {
+		name string
+		opts []SQLOption
+		want string
+	}{
+		{"default", nil, " WHERE (LOWER(CAST(name AS TEXT)) LIKE ? ESCAPE '!' OR LOWER(CAST(country AS TEXT)) LIKE ? ESCAPE '!') AND LOWER(CAST(country AS TEXT)) LIKE ? ESCAPE '!'"},
+		{"postgres", []SQLOption{WithSQLPlaceholder(DollarPlaceholder)}, " WHERE (LOWER(CAST(name AS TEXT)) LIKE $1 ESCAPE '!' OR LOWER(CAST(country AS TEXT)) LIKE $2 ESCAPE '!') AND LOWER(CAST(country AS TEXT)) LIKE $3 ESCAPE '!'"},
+		{"mysql", []SQLOption{WithSQLTextType("CHAR")}, " WHERE (LOWER(CAST(name AS CHAR)) LIKE ? ESCAPE '!' OR LOWER(CAST(country AS CHAR)) LIKE ? ESCAPE '!') AND LOWER(CAST(country AS CHAR)) LIKE ? ESCAPE '!'"},
+	}
+	for _, tt := range tests {
+		b := &sqlBuilder{source: NewSQLSource(nil, "people", []string{"name", "country"}, tt.opts...)}
+		where, err := b.where(q)
+		if err != nil {
+			t.Fatalf("%s: where returned error: %v", tt.name, err)
+		}
+		if where != tt.want {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tt.name, tt.want, where)
+		}
+	}
+}
+
+func TestDataTableWithSQLSource(t *testing.T) {
+	dt := New("people",
+		WithColumns([]Column{{ID: "name", Sortable: true}, {ID: "country"}, {ID: "age"}}),
+		WithPageSize(2),
+		WithSelectable(true),
+		WithDataSource(NewSQLSource(openTestDB(t), "people", []string{"name", "country", "age"})),
+	)
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if dt.TotalRows() != 5 || dt.TotalPages() != 3 {
+		t.Errorf("expected 5 rows on 3 pages, got %d rows on %d pages", dt.TotalRows(), dt.TotalPages())
+	}
+
+	actions := dt.Actions()
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		if err := actions[name](base.NewActionContext(name, dt.ID(), data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+
+	dt.SelectRow("3")
+	run("next_page", nil)
+	if got := rowIDs(dt.GetPageRows()); len(got) != 2 || got[0] != "3" || got[1] != "4" {
+		t.Errorf("expected page 1 rows [3 4], got %v", got)
+	}
+	if !dt.GetPageRows()[0].Selected {
+		t.Error("expected selection to be restored on load")
+	}
+
+	run("filter", map[string]string{"value": "germany"})
+	if dt.Page != 0 || dt.TotalRows() != 2 {
+		t.Errorf("expected 2 filtered rows on page 0, got %d on page %d", dt.TotalRows(), dt.Page)
+	}
+
+	run("sort", map[string]string{"column": "name"})
+	run("sort", map[string]string{"column": "name"})
+	if got := rowIDs(dt.GetPageRows()); len(got) != 2 || got[0] != "5" {
+		t.Errorf("expected rows sorted by name descending, got %v", got)
+	}
+
+	err := actions["sort"](base.NewActionContext("sort", dt.ID(), map[string]string{"column": "missing"}))
+	if !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("expected ErrUnknownColumn from sort action, got %v", err)
+	}
+}
+
+func TestSQLSourceFacets(t *testing.T) {
+	dt := New("people",
+		WithColumns([]Column{
+			{ID: "name"},
+			{ID: "country", Label: "Country", Filterable: FilterSpec{Kind: FilterFacet}},
+			{ID: "age", Label: "Age", Filterable: FilterSpec{Kind: FilterNumber}},
+		}),
+		WithPageSize(2),
+		WithDataSource(NewSQLSource(openTestDB(t), "people", []string{"name", "country", "age"})),
+	)
+	if err := dt.Load(context.Background()); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	facets := dt.Facets()
+	if len(facets) != 2 {
+		t.Fatalf("expected 2 facets, got %d", len(facets))
+	}
+	if values := facets[0].Values; len(values) != 3 || values[0].Count != 2 || values[2].Value != "France" {
+		t.Errorf("unexpected country values %+v", values)
+	}
+	if facets[1].Min != 27 || facets[1].Max != 45 {
+		t.Errorf("expected ages 27-45, got %v-%v", facets[1].Min, facets[1].Max)
+	}
+
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		if err := dt.Actions()[name](base.NewActionContext(name, dt.ID(), data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+	run("filter_range", map[string]string{"column": "age", "bound": "max", "value": "35"})
+	run("toggle_facet", map[string]string{"column": "country", "value": "Austria"})
+	if dt.TotalRows() != 2 {
+		t.Errorf("expected 2 Austrians up to 35, got %d", dt.TotalRows())
+	}
+
+	facets = dt.Facets()
+	if values := facets[0].Values; len(values) != 1 || values[0].Value != "Austria" || !values[0].Selected {
+		t.Errorf("expected country counts over the age filter, got %+v", values)
+	}
+	if facets[1].Min != 27 || facets[1].Max != 31 || facets[1].High != 31 {
+		t.Errorf("expected Austrian ages 27-31, got %+v", facets[1])
+	}
+}
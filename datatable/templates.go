@@ -2,10 +2,15 @@ package datatable
 
 import (
 	"embed"
+	"encoding/json"
 	"fmt"
 	"html/template"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/livetemplate/components/base"
+	"github.com/livetemplate/components/menu"
 )
 
 // templateFS contains all datatable template files embedded at compile time.
@@ -34,284 +39,325 @@ func Templates() *base.TemplateSet {
 				return a % b
 			},
 			// isSortedAsc checks if datatable is sorted ascending by column.
-			// Works with both *DataTable and map representations.
 			"isSortedAsc": func(dt interface{}, columnID string) bool {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.IsSortedAsc(columnID)
-				}
-				// Handle map representation (from JSON)
-				if m, ok := dt.(map[string]interface{}); ok {
-					sortColumn, _ := m["SortColumn"].(string)
-					sortDir, _ := m["SortDirection"].(float64) // JSON numbers are float64
-					return sortColumn == columnID && int(sortDir) == int(SortAsc)
-				}
-				return false
+				_, dir := sortKeyOf(dt, columnID)
+				return dir == SortAsc
 			},
 			// isSortedDesc checks if datatable is sorted descending by column.
-			// Works with both *DataTable and map representations.
 			"isSortedDesc": func(dt interface{}, columnID string) bool {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.IsSortedDesc(columnID)
-				}
-				// Handle map representation (from JSON)
-				if m, ok := dt.(map[string]interface{}); ok {
-					sortColumn, _ := m["SortColumn"].(string)
-					sortDir, _ := m["SortDirection"].(float64)
-					return sortColumn == columnID && int(sortDir) == int(SortDesc)
-				}
-				return false
+				_, dir := sortKeyOf(dt, columnID)
+				return dir == SortDesc
+			},
+			// sortPriority returns the 1-based sort priority of a column when the
+			// table is sorted by more than one column, or 0 otherwise.
+			"sortPriority": func(dt interface{}, columnID string) int {
+				if len(sortKeysOf(dt)) < 2 {
+					return 0
+				}
+				priority, _ := sortKeyOf(dt, columnID)
+				return priority
+			},
+			// canAddSort returns true if the table is sorted by another column,
+			// so a column can be added as a further sort key (lvt-data-multi).
+			"canAddSort": func(dt interface{}, columnID string) bool {
+				keys := sortKeysOf(dt)
+				return len(keys) > 1 || (len(keys) == 1 && keys[0].Column != columnID)
 			},
 			// getCellValue gets a cell value from a row.
-			// Works with both Row and map representations.
 			"getCellValue": func(row interface{}, columnID string) interface{} {
-				if r, ok := row.(Row); ok {
-					return r.GetCellValue(columnID)
-				}
-				// Handle map representation
-				if m, ok := row.(map[string]interface{}); ok {
-					if data, ok := m["Data"].(map[string]interface{}); ok {
-						return data[columnID]
-					}
-				}
-				return nil
+				return rowData(row)[columnID]
+			},
+			// formatCell gets a cell value from a row and formats it with the
+			// column's Format (see RegisterFormatter).
+			"formatCell": func(row interface{}, col interface{}) string {
+				format := field(col, "Format", func(c Column) string { return c.Format })
+				return FormatValue(format, rowData(row)[colIDOf(col)])
+			},
+			// exportLabel returns the button label for an export format.
+			"exportLabel": func(format interface{}) string {
+				return strings.ToUpper(fmt.Sprint(format))
 			},
 			// getRowID gets the ID from a row.
-			// Works with both Row and map representations.
 			"getRowID": func(row interface{}) string {
-				if r, ok := row.(Row); ok {
-					return r.ID
-				}
-				if m, ok := row.(map[string]interface{}); ok {
-					if id, ok := m["ID"].(string); ok {
-						return id
-					}
-				}
-				return ""
+				return field(row, "ID", func(r Row) string { return r.ID })
 			},
 			// isRowSelected checks if a row is selected.
-			// Works with both Row and map representations.
 			"isRowSelected": func(row interface{}) bool {
-				if r, ok := row.(Row); ok {
-					return r.Selected
-				}
-				if m, ok := row.(map[string]interface{}); ok {
-					if selected, ok := m["Selected"].(bool); ok {
-						return selected
-					}
-				}
-				return false
+				return field(row, "Selected", func(r Row) bool { return r.Selected })
 			},
 			// isRowDisabled checks if a row is disabled.
-			// Works with both Row and map representations.
 			"isRowDisabled": func(row interface{}) bool {
-				if r, ok := row.(Row); ok {
-					return r.Disabled
-				}
-				if m, ok := row.(map[string]interface{}); ok {
-					if disabled, ok := m["Disabled"].(bool); ok {
-						return disabled
-					}
-				}
-				return false
+				return field(row, "Disabled", func(r Row) bool { return r.Disabled })
 			},
 			// dtID gets the datatable ID.
-			// Works with both *DataTable and map representations.
 			"dtID": func(dt interface{}) string {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.ID()
-				}
-				if m, ok := dt.(map[string]interface{}); ok {
-					if id, ok := m["Id"].(string); ok {
-						return id
-					}
-				}
-				return ""
+				return tableField(dt, "id", (*DataTable).ID, "")
+			},
+			// dtStyled checks if the datatable uses Tailwind CSS styling.
+			"dtStyled": func(dt interface{}) bool {
+				return tableField(dt, "styled", (*DataTable).IsStyled, false)
 			},
 			// dtPageSize gets the page size from datatable.
 			"dtPageSize": func(dt interface{}) int {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.PageSize
-				}
-				if m, ok := dt.(map[string]interface{}); ok {
-					if ps, ok := m["PageSize"].(float64); ok {
-						return int(ps)
-					}
-				}
-				return 0
+				return tableField(dt, "PageSize", func(t *DataTable) int { return t.PageSize }, 0)
 			},
 			// dtStartIndex gets the start index.
 			"dtStartIndex": func(dt interface{}) int {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.StartIndex()
-				}
-				if m, ok := dt.(map[string]interface{}); ok {
-					if si, ok := m["StartIndex"].(float64); ok {
-						return int(si)
-					}
-				}
-				return 1
+				return tableField(dt, "StartIndex", (*DataTable).StartIndex, 1)
 			},
 			// dtEndIndex gets the end index.
 			"dtEndIndex": func(dt interface{}) int {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.EndIndex()
-				}
-				if m, ok := dt.(map[string]interface{}); ok {
-					if ei, ok := m["EndIndex"].(float64); ok {
-						return int(ei)
-					}
-				}
-				return 0
+				return tableField(dt, "EndIndex", (*DataTable).EndIndex, 0)
 			},
 			// dtTotalRows gets the total row count.
 			"dtTotalRows": func(dt interface{}) int {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.TotalRows()
-				}
-				if m, ok := dt.(map[string]interface{}); ok {
-					if tr, ok := m["TotalRows"].(float64); ok {
-						return int(tr)
-					}
-				}
-				return 0
+				return tableField(dt, "TotalRows", (*DataTable).TotalRows, 0)
 			},
 			// dtHasPrev checks if there's a previous page.
 			"dtHasPrev": func(dt interface{}) bool {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.HasPreviousPage()
-				}
-				if m, ok := dt.(map[string]interface{}); ok {
-					if hp, ok := m["HasPreviousPage"].(bool); ok {
-						return hp
-					}
-				}
-				return false
+				return tableField(dt, "HasPreviousPage", (*DataTable).HasPreviousPage, false)
 			},
 			// dtHasNext checks if there's a next page.
 			"dtHasNext": func(dt interface{}) bool {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.HasNextPage()
-				}
-				if m, ok := dt.(map[string]interface{}); ok {
-					if hn, ok := m["HasNextPage"].(bool); ok {
-						return hn
-					}
-				}
-				return false
+				return tableField(dt, "HasNextPage", (*DataTable).HasNextPage, false)
+			},
+			// dtPage gets the 1-indexed current page.
+			"dtPage": func(dt interface{}) int {
+				return tableField(dt, "Page", func(t *DataTable) int { return t.Page }, 0) + 1
+			},
+			// dtTotalPages gets the page count.
+			"dtTotalPages": func(dt interface{}) int {
+				return tableField(dt, "TotalPages", (*DataTable).TotalPages, 1)
+			},
+			// dtPageInfo gets the localised page range text.
+			"dtPageInfo": func(dt interface{}) string {
+				return tableField(dt, "PageInfo", (*DataTable).PageInfo, "")
+			},
+			// dtPageLinks gets the windowed page number list.
+			"dtPageLinks": func(dt interface{}) []PageLink {
+				return tableField(dt, "PageLinks", (*DataTable).PageLinks, nil)
+			},
+			// dtPageSizeOptions gets the sizes offered by the page size selector.
+			"dtPageSizeOptions": func(dt interface{}) []int {
+				return tableField(dt, "PageSizeOptions", func(t *DataTable) []int { return t.PageSizeOptions }, nil)
+			},
+			// dtSelectedCount gets the number of selected rows.
+			"dtSelectedCount": func(dt interface{}) int {
+				return tableField(dt, "SelectedCount", (*DataTable).SelectedCount, 0)
+			},
+			// dtMatchingCount gets the number of rows matching the filters.
+			"dtMatchingCount": func(dt interface{}) int {
+				return tableField(dt, "MatchingCount", (*DataTable).MatchingCount, 0)
+			},
+			// dtSelectingAll checks if every matching row is selected.
+			"dtSelectingAll": func(dt interface{}) bool {
+				mode := tableField(dt, "SelectionMode", func(t *DataTable) SelectionMode { return t.SelectionMode }, "")
+				return mode == SelectionExclude
+			},
+			// dtBulkActions gets the selection toolbar actions.
+			"dtBulkActions": func(dt interface{}) []BulkAction {
+				return tableField(dt, "BulkActions", func(t *DataTable) []BulkAction { return t.BulkActions }, nil)
+			},
+			// dtRowActions checks if rows have an actions menu.
+			"dtRowActions": dtRowActions,
+			// rowMenu gets the items of a row's actions menu.
+			"rowMenu": func(dt interface{}, row interface{}) []menu.Item {
+				if datatable, ok := asDataTable(dt); ok {
+					return datatable.RowMenu(valueOf[Row](row))
+				}
+				// For the JSON representation, use the menus encoded by MarshalJSON
+				menus := tableField(dt, "RowMenus", func(*DataTable) map[string][]menu.Item { return nil }, nil)
+				return menus[field(row, "ID", func(r Row) string { return r.ID })]
+			},
+			// isRowMenuOpen checks if a row's actions menu is open.
+			"isRowMenuOpen": func(dt interface{}, row interface{}) bool {
+				id := field(row, "ID", func(r Row) string { return r.ID })
+				return id != "" && tableField(dt, "OpenRowMenu", func(t *DataTable) string { return t.OpenRowMenu }, "") == id
+			},
+			// dtRowClick checks if clicking a row sends a "click" action.
+			"dtRowClick": func(dt interface{}) bool {
+				return tableField(dt, "RowClickEnabled", func(t *DataTable) bool { return t.RowClickEnabled }, false)
+			},
+			// dtRowOpen checks if double-clicking a row sends an "open" action.
+			"dtRowOpen": func(dt interface{}) bool {
+				return tableField(dt, "RowOpenEnabled", func(t *DataTable) bool { return t.RowOpenEnabled }, false)
 			},
 			// dtVisibleColumns gets visible columns.
-			"dtVisibleColumns": func(dt interface{}) []Column {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.VisibleColumns()
-				}
-				// For map representation, try to extract from computed field
-				if m, ok := dt.(map[string]interface{}); ok {
-					if vc, ok := m["VisibleColumns"].([]interface{}); ok {
-						cols := make([]Column, 0, len(vc))
-						for _, c := range vc {
-							if cm, ok := c.(map[string]interface{}); ok {
-								col := Column{
-									ID:       getMapString(cm, "ID"),
-									Label:    getMapString(cm, "Label"),
-									Sortable: getMapBool(cm, "Sortable"),
-									Width:    getMapString(cm, "Width"),
-									Align:    getMapString(cm, "Align"),
-								}
-								cols = append(cols, col)
-							}
-						}
-						return cols
-					}
-				}
-				return nil
+			"dtVisibleColumns": dtVisibleColumns,
+			// dtColumns gets all columns in layout order, for the column chooser.
+			"dtColumns": func(dt interface{}) []Column {
+				return tableField(dt, "Columns", func(t *DataTable) []Column { return t.Columns }, nil)
 			},
-			// dtPageRows gets current page rows.
-			"dtPageRows": func(dt interface{}) interface{} {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.GetPageRows()
-				}
-				// For map representation, return the PageRows field
-				if m, ok := dt.(map[string]interface{}); ok {
-					if pr, ok := m["PageRows"]; ok {
-						return pr
-					}
+			// colHeaderStyle gets the inline style of a column's header cell.
+			"colHeaderStyle": func(dt interface{}, col interface{}) template.CSS {
+				sticky := tableField(dt, "StickyHeader", func(t *DataTable) bool { return t.StickyHeader }, false)
+				return columnStyle(dtVisibleColumns(dt), colIDOf(col), true, sticky)
+			},
+			// colCellStyle gets the inline style of a column's body cells.
+			"colCellStyle": func(dt interface{}, col interface{}) template.CSS {
+				return columnStyle(dtVisibleColumns(dt), colIDOf(col), false, false)
+			},
+			// dtPageRows gets the current page rows to render (see WindowRows).
+			"dtPageRows": func(dt interface{}) []Row {
+				return tableField(dt, "PageRows", (*DataTable).WindowRows, nil)
+			},
+			// dtFacets gets the facet and range filters.
+			"dtFacets": func(dt interface{}) []Facet {
+				return tableField(dt, "Facets", (*DataTable).Facets, nil)
+			},
+			// dtFilterChips gets the active filters.
+			"dtFilterChips": func(dt interface{}) []FilterChip {
+				return tableField(dt, "FilterChips", (*DataTable).FilterChips, nil)
+			},
+			// facetNumber formats a range slider position for an input attribute.
+			"facetNumber": func(n float64) string {
+				return strconv.FormatFloat(n, 'f', -1, 64)
+			},
+			// dtWindow gets the rendered range of a virtualised table, or nil.
+			"dtWindow": func(dt interface{}) *base.Window {
+				return tableField(dt, "Window", func(t *DataTable) *base.Window { return t.Window }, nil)
+			},
+			// dtScrollable checks if scrolling the table sends "scroll" actions.
+			"dtScrollable": func(dt interface{}) bool {
+				return tableField(dt, "Window", func(t *DataTable) *base.Window { return t.Window }, nil) != nil ||
+					tableField(dt, "Infinite", func(t *DataTable) bool { return t.Infinite }, false)
+			},
+			// rowNumber returns the index of a rendered row in the page, counting
+			// the rows before the Window, e.g. for zebra striping.
+			"rowNumber": func(dt interface{}, index int) int {
+				if w := tableField(dt, "Window", func(t *DataTable) *base.Window { return t.Window }, nil); w != nil {
+					return w.Start() + index
 				}
-				return nil
+				return index
 			},
 			// dtIsEmpty checks if datatable is empty.
 			"dtIsEmpty": func(dt interface{}) bool {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.IsEmpty()
-				}
-				if m, ok := dt.(map[string]interface{}); ok {
-					if ie, ok := m["IsEmpty"].(bool); ok {
-						return ie
-					}
-				}
-				return true
+				return tableField(dt, "IsEmpty", (*DataTable).IsEmpty, true)
 			},
 			// dtAllSelected checks if all rows are selected.
 			"dtAllSelected": func(dt interface{}) bool {
-				if datatable, ok := dt.(*DataTable); ok {
-					return datatable.AllSelected()
-				}
-				if m, ok := dt.(map[string]interface{}); ok {
-					if as, ok := m["AllSelected"].(bool); ok {
-						return as
-					}
-				}
-				return false
+				return tableField(dt, "AllSelected", (*DataTable).AllSelected, false)
 			},
 			// colID extracts column ID from Column or map.
-			"colID": func(col interface{}) string {
-				if c, ok := col.(Column); ok {
-					return c.ID
-				}
-				if m, ok := col.(map[string]interface{}); ok {
-					return getMapString(m, "ID")
-				}
-				return ""
-			},
+			"colID": colIDOf,
 			// colLabel extracts column label.
 			"colLabel": func(col interface{}) string {
-				if c, ok := col.(Column); ok {
-					return c.Label
-				}
-				if m, ok := col.(map[string]interface{}); ok {
-					return getMapString(m, "Label")
-				}
-				return ""
+				return field(col, "Label", func(c Column) string { return c.Label })
 			},
 			// colSortable checks if column is sortable.
 			"colSortable": func(col interface{}) bool {
-				if c, ok := col.(Column); ok {
-					return c.Sortable
-				}
-				if m, ok := col.(map[string]interface{}); ok {
-					return getMapBool(m, "Sortable")
-				}
-				return false
+				return field(col, "Sortable", func(c Column) bool { return c.Sortable })
 			},
 			// colWidth gets column width.
 			"colWidth": func(col interface{}) string {
-				if c, ok := col.(Column); ok {
-					return c.Width
-				}
-				if m, ok := col.(map[string]interface{}); ok {
-					return getMapString(m, "Width")
+				return field(col, "Width", func(c Column) string { return c.Width })
+			},
+			// colHidden checks if a column is hidden.
+			"colHidden": func(col interface{}) bool {
+				return field(col, "Hidden", func(c Column) bool { return c.Hidden })
+			},
+			// colPinned gets the side a column is pinned to ("" if not pinned).
+			"colPinned": func(col interface{}) string {
+				return string(field(col, "Pinned", func(c Column) Pin { return c.Pinned }))
+			},
+			// colWidthPx gets a column's width in pixels (0 if not set in pixels).
+			"colWidthPx": func(col interface{}) int {
+				width := field(col, "Width", func(c Column) string { return c.Width })
+				if px, ok := strings.CutSuffix(strings.TrimSpace(width), "px"); ok {
+					n, _ := strconv.Atoi(strings.TrimSpace(px))
+					return n
 				}
-				return ""
+				return 0
 			},
 			// colAlign gets column alignment.
 			"colAlign": func(col interface{}) string {
-				if c, ok := col.(Column); ok {
-					return c.Align
+				return field(col, "Align", func(c Column) string { return c.Align })
+			},
+			// colEditor returns the column's editor kind ("" if not editable).
+			"colEditor": func(col interface{}) string {
+				c := Column{
+					Editable: field(col, "Editable", func(c Column) bool { return c.Editable }),
+					Editor:   field(col, "Editor", func(c Column) EditorKind { return c.Editor }),
 				}
-				if m, ok := col.(map[string]interface{}); ok {
-					return getMapString(m, "Align")
+				return string(c.EditorKind())
+			},
+			// colEditOptions returns the choices for a select editor.
+			"colEditOptions": func(col interface{}) []EditOption {
+				return valueOf[Column](col).EditOptions
+			},
+			// isEditingCell checks if a cell is being edited.
+			"isEditingCell": func(dt interface{}, rowID, columnID string) bool {
+				edit := dtEditing(dt)
+				return edit != nil && edit.RowID == rowID && edit.ColumnID == columnID
+			},
+			// editValue returns the text of the cell being edited.
+			"editValue": func(dt interface{}) string {
+				if edit := dtEditing(dt); edit != nil {
+					return edit.Value
+				}
+				return ""
+			},
+			// editError returns the error from the last rejected edit.
+			"editError": func(dt interface{}) string {
+				if edit := dtEditing(dt); edit != nil {
+					return edit.Error
 				}
 				return ""
 			},
+			// cellChecked reports whether a toggle cell is on.
+			"cellChecked": func(row interface{}, columnID string) bool {
+				b, _ := toBool(rowData(row)[columnID])
+				return b
+			},
+			// isRowExpanded checks if a row's children or detail panel are shown.
+			"isRowExpanded": func(row interface{}) bool {
+				return field(row, "Expanded", func(r Row) bool { return r.Expanded })
+			},
+			// rowExpandable checks if a row has children or a detail panel.
+			"rowExpandable": func(dt interface{}, row interface{}) bool {
+				children := field(row, "Children", func(r Row) []Row { return r.Children })
+				return len(children) > 0 ||
+					tableField(dt, "DetailTemplate", func(t *DataTable) string { return t.DetailTemplate }, "") != ""
+			},
+			// rowIndent returns the left indentation for a tree row's depth
+			// (e.g. "3rem"), or "" at the top level.
+			"rowIndent": func(row interface{}) string {
+				depth := field(row, "Depth", func(r Row) int { return r.Depth })
+				if depth <= 0 {
+					return ""
+				}
+				return strconv.FormatFloat(float64(depth)*1.5, 'f', -1, 64) + "rem"
+			},
+			// rowDetail renders the detail panel of an expanded row.
+			"rowDetail": func(dt interface{}, row interface{}) (template.HTML, error) {
+				if datatable, ok := asDataTable(dt); ok {
+					return datatable.RenderDetail(valueOf[Row](row))
+				}
+				// For the JSON representation, use the panels rendered by MarshalJSON
+				details := tableField(dt, "Details", func(*DataTable) map[string]template.HTML { return nil }, nil)
+				return details[field(row, "ID", func(r Row) string { return r.ID })], nil
+			},
+			// dtColspan returns the number of table columns, including the
+			// selection and row actions columns.
+			"dtColspan": func(dt interface{}) int {
+				n := len(dtVisibleColumns(dt))
+				if tableField(dt, "Selectable", func(t *DataTable) bool { return t.Selectable }, false) &&
+					tableField(dt, "MultiSelect", func(t *DataTable) bool { return t.MultiSelect }, false) {
+					n++
+				}
+				if dtRowActions(dt) {
+					n++
+				}
+				return n
+			},
+			// rowGroup returns the group of a group header row, or nil.
+			"rowGroup": func(row interface{}) *RowGroup {
+				return field(row, "Group", func(r Row) *RowGroup { return r.Group })
+			},
+			// dtTotals returns the totals footer aggregates by column ID.
+			"dtTotals": func(dt interface{}) map[string]string {
+				return tableField(dt, "Totals", (*DataTable).Totals, nil)
+			},
 			// Print for debugging
 			"debugType": func(v interface{}) string {
 				return fmt.Sprintf("%T", v)
@@ -319,17 +365,143 @@ func Templates() *base.TemplateSet {
 		})
 }
 
-// Helper functions for map access
-func getMapString(m map[string]interface{}, key string) string {
-	if v, ok := m[key].(string); ok {
-		return v
+// The template functions accept a *DataTable (or a type embedding one, see
+// NewTyped), Row and Column, or their JSON representation as decoded into
+// maps, e.g. when state is rendered after an RPC round trip. The helpers
+// below read a field from either form, decoding JSON values with the same
+// rules as UnmarshalJSON.
+
+// tableField returns get(dt) for a datatable, or the key decoded from its
+// JSON representation, or fallback if the key is missing.
+func tableField[T any](dt interface{}, key string, get func(*DataTable) T, fallback T) T {
+	if datatable, ok := asDataTable(dt); ok {
+		return get(datatable)
+	}
+	if m, ok := dt.(map[string]interface{}); ok {
+		if v, ok := convert[T](m[key]); ok {
+			return v
+		}
 	}
-	return ""
+	return fallback
 }
 
-func getMapBool(m map[string]interface{}, key string) bool {
-	if v, ok := m[key].(bool); ok {
-		return v
+// field returns get(v) for an S (e.g. a Row), or the key decoded from its
+// JSON representation.
+func field[S, T any](v interface{}, key string, get func(S) T) T {
+	if s, ok := v.(S); ok {
+		return get(s)
 	}
-	return false
+	var t T
+	if m, ok := v.(map[string]interface{}); ok {
+		t, _ = convert[T](m[key])
+	}
+	return t
+}
+
+// valueOf returns v as a T, decoding it from its JSON representation if
+// needed.
+func valueOf[T any](v interface{}) T {
+	t, _ := convert[T](v)
+	return t
+}
+
+// convert returns v as a T. Scalars decoded from JSON are converted directly
+// (float64 to int, string to a named string type such as Pin); other values
+// (e.g. maps for structs) are converted through JSON. It returns false for
+// nil and unconvertible values.
+func convert[T any](v interface{}) (T, bool) {
+	if t, ok := v.(T); ok {
+		return t, true
+	}
+	var t T
+	switch v.(type) {
+	case nil:
+		return t, false
+	case float64, string, bool:
+		if t, ok := convertScalar[T](v); ok {
+			return t, true
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return t, false
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return t, false
+	}
+	return t, true
+}
+
+// convertScalar converts a number, string or bool decoded from JSON to a T
+// of the same kind. Numbers are only converted if no precision is lost.
+func convertScalar[T any](v any) (T, bool) {
+	var t T
+	rt := reflect.TypeFor[T]()
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Float64 && isNumberKind(rt.Kind()) {
+		converted := rv.Convert(rt)
+		if converted.Convert(rv.Type()).Float() != rv.Float() {
+			return t, false
+		}
+		return converted.Interface().(T), true
+	}
+	if rv.Kind() != rt.Kind() {
+		return t, false
+	}
+	return rv.Convert(rt).Interface().(T), true
+}
+
+// isNumberKind returns true for integer and floating-point kinds.
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+// rowData returns the cell values of a row or its JSON representation.
+func rowData(row interface{}) map[string]any {
+	return field(row, "Data", func(r Row) map[string]any { return r.Data })
+}
+
+// sortKeysOf returns the sort keys of a datatable or its JSON representation,
+// falling back to SortColumn and SortDirection when SortKeys is empty.
+func sortKeysOf(dt interface{}) []SortKey {
+	sorted := DataTable{
+		SortKeys:      tableField(dt, "SortKeys", func(t *DataTable) []SortKey { return t.SortKeys }, nil),
+		SortColumn:    tableField(dt, "SortColumn", func(t *DataTable) string { return t.SortColumn }, ""),
+		SortDirection: tableField(dt, "SortDirection", func(t *DataTable) SortDirection { return t.SortDirection }, SortNone),
+	}
+	return sorted.activeSortKeys()
+}
+
+// sortKeyOf returns the 1-based priority and direction of a column in the
+// sort keys of a datatable or its JSON representation.
+func sortKeyOf(dt interface{}, columnID string) (int, SortDirection) {
+	for i, k := range sortKeysOf(dt) {
+		if k.Column == columnID {
+			return i + 1, k.Direction
+		}
+	}
+	return 0, SortNone
+}
+
+// dtVisibleColumns returns the visible columns of a datatable or its JSON
+// representation.
+func dtVisibleColumns(dt interface{}) []Column {
+	return tableField(dt, "VisibleColumns", (*DataTable).VisibleColumns, nil)
+}
+
+// dtRowActions returns true if the rows of a datatable or its JSON
+// representation have an actions menu.
+func dtRowActions(dt interface{}) bool {
+	return len(tableField(dt, "RowActions", func(t *DataTable) []RowAction { return t.RowActions }, nil)) > 0
+}
+
+// colIDOf returns the ID of a column or its JSON representation.
+func colIDOf(col interface{}) string {
+	return field(col, "ID", func(c Column) string { return c.ID })
+}
+
+// dtEditing returns the cell being edited from a datatable or its JSON
+// representation.
+func dtEditing(dt interface{}) *CellEdit {
+	return tableField(dt, "Editing", func(t *DataTable) *CellEdit { return t.Editing }, nil)
 }
@@ -0,0 +1,233 @@
+package datatable
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// ExpandRow expands a row, showing its children or detail panel.
+func (dt *DataTable) ExpandRow(id string) {
+	dt.setExpanded(id, true)
+}
+
+// CollapseRow collapses a row.
+func (dt *DataTable) CollapseRow(id string) {
+	dt.setExpanded(id, false)
+}
+
+// ToggleRowExpansion expands a collapsed row or collapses an expanded one.
+func (dt *DataTable) ToggleRowExpansion(id string) {
+	dt.setExpanded(id, !dt.isShownExpanded(id))
+}
+
+// IsRowExpanded returns true if a row has been expanded (or was created with
+// Row.Expanded). While filtering, the ancestors of matching rows are also
+// shown expanded unless collapsed.
+func (dt *DataTable) IsRowExpanded(id string) bool {
+	if expanded, ok := dt.ExpandedIDs[id]; ok {
+		return expanded
+	}
+	row := dt.rowByID(id)
+	return row != nil && row.Expanded
+}
+
+// ExpandAll expands every row with children, or every row if a detail
+// template is set.
+func (dt *DataTable) ExpandAll() {
+	dt.setAllExpanded(true)
+}
+
+// CollapseAll collapses every row.
+func (dt *DataTable) CollapseAll() {
+	dt.setAllExpanded(false)
+}
+
+// IsRowExpandable returns true if a row has children or the table has a
+// detail template.
+func (dt *DataTable) IsRowExpandable(row Row) bool {
+	return len(row.Children) > 0 || dt.DetailTemplate != ""
+}
+
+// setExpanded records a row's expansion state and mirrors it on the row.
+func (dt *DataTable) setExpanded(id string, expanded bool) {
+	if dt.ExpandedIDs == nil {
+		dt.ExpandedIDs = make(map[string]bool)
+	}
+	dt.ExpandedIDs[id] = expanded
+	if row := dt.rowByID(id); row != nil {
+		row.Expanded = expanded
+	}
+	dt.resetView()
+}
+
+// setAllExpanded sets the expansion state of every expandable row.
+func (dt *DataTable) setAllExpanded(expanded bool) {
+	if dt.ExpandedIDs == nil {
+		dt.ExpandedIDs = make(map[string]bool)
+	}
+	walkRows(dt.Rows, func(row *Row) {
+		if dt.IsRowExpandable(*row) {
+			dt.ExpandedIDs[row.ID] = expanded
+			row.Expanded = expanded
+		}
+	})
+	dt.resetView()
+}
+
+// isShownExpanded reports whether a row is displayed expanded, including
+// ancestors expanded to reveal filter matches. Only those need the visible
+// rows to be searched.
+func (dt *DataTable) isShownExpanded(id string) bool {
+	if _, set := dt.ExpandedIDs[id]; !set && dt.isTree() && !dt.pagedRows() && dt.IsFiltered() {
+		for _, row := range dt.treeRows() {
+			if row.ID == id {
+				return row.Expanded
+			}
+		}
+	}
+	return dt.IsRowExpanded(id)
+}
+
+// restoreExpanded applies ExpandedIDs to rows.
+func (dt *DataTable) restoreExpanded(rows []Row) {
+	if len(dt.ExpandedIDs) == 0 {
+		return
+	}
+	walkRows(rows, func(row *Row) {
+		if expanded, ok := dt.ExpandedIDs[row.ID]; ok {
+			row.Expanded = expanded
+		}
+	})
+}
+
+// walkRows calls fn for each row and its descendants, depth first.
+func walkRows(rows []Row, fn func(*Row)) {
+	for i := range rows {
+		fn(&rows[i])
+		walkRows(rows[i].Children, fn)
+	}
+}
+
+// flattenTree appends rows and all their descendants to out, depth first,
+// with each level sorted by keys.
+func flattenTree(out, rows []Row, columns []Column, keys []SortKey) []Row {
+	indexes := make([]int, len(rows))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sortIndexes(rows, columns, keys, indexes)
+	for _, i := range indexes {
+		out = append(out, rows[i])
+		out = flattenTree(out, rows[i].Children, columns, keys)
+	}
+	return out
+}
+
+// isTree returns true if any row has children.
+func (dt *DataTable) isTree() bool {
+	return dt.lookupRows().tree
+}
+
+// treeRows returns the visible rows of a tree table in display order: each
+// level is filtered and sorted, and the children of expanded rows follow
+// their parent with Depth set (cached until the view is reset).
+//
+// A row is visible if it matches the filters or has a matching descendant.
+// Every descendant of a matching row is kept, and ancestors of matches are
+// expanded unless explicitly collapsed. With a DataSource the loaded rows are
+// already filtered and sorted and are only flattened.
+func (dt *DataTable) treeRows() []Row {
+	if dt.lookupRows(); dt.tree != nil {
+		return dt.tree
+	}
+
+	var q Query
+	if !dt.pagedRows() {
+		q = dt.query()
+	}
+	needle := foldCase(strings.TrimSpace(q.Search))
+	filtering := needle != "" || len(q.Filters) > 0
+
+	dt.tree, _ = dt.appendTree(make([]Row, 0, len(dt.Rows)), dt.Rows, 0, &q, needle, filtering)
+	return dt.tree
+}
+
+// appendTree appends the visible rows of one tree level (and their expanded
+// descendants) to out, reporting whether any row was visible.
+func (dt *DataTable) appendTree(out, rows []Row, depth int, q *Query, needle string, filtering bool) ([]Row, bool) {
+	indexes := make([]int, len(rows))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sortIndexes(rows, dt.Columns, q.Sort, indexes)
+
+	visible := false
+	for _, i := range indexes {
+		row := rows[i]
+		matched := !filtering || matchesQuery(row, q, needle)
+		expanded, set := dt.ExpandedIDs[row.ID]
+		if !set {
+			expanded = row.Expanded
+		}
+
+		var children []Row
+		childMatched := false
+		if len(row.Children) > 0 && (filtering || expanded) {
+			children, childMatched = dt.appendTree(nil, row.Children, depth+1, q, needle, filtering && !matched)
+		}
+		if !matched && !childMatched {
+			continue
+		}
+		if !set && !matched {
+			expanded = true
+		}
+
+		visible = true
+		row.Depth = depth
+		row.Expanded = expanded
+		row.Selected = dt.selected(row)
+		out = append(out, row)
+		if expanded {
+			out = append(out, children...)
+		}
+	}
+	return out, visible
+}
+
+// RenderDetail renders the detail template for a row. It returns "" when no
+// detail template is set. Decoded tables without WithDetailTemplate return
+// the panel they were encoded with.
+func (dt *DataTable) RenderDetail(row Row) (template.HTML, error) {
+	if dt.DetailTemplate == "" {
+		return "", nil
+	}
+	if dt.detailTmpl == nil {
+		return dt.details[row.ID], nil
+	}
+	var buf bytes.Buffer
+	if err := dt.detailTmpl.ExecuteTemplate(&buf, dt.DetailTemplate, row); err != nil {
+		return "", fmt.Errorf("datatable: render detail %q: %w", dt.DetailTemplate, err)
+	}
+	return template.HTML(buf.String()), nil
+}
+
+// renderDetails renders the detail panels of the expanded rows, keyed by row ID.
+func (dt *DataTable) renderDetails(rows []Row) (map[string]template.HTML, error) {
+	if dt.DetailTemplate == "" || (dt.detailTmpl == nil && dt.details == nil) {
+		return nil, nil
+	}
+	details := make(map[string]template.HTML)
+	for _, row := range rows {
+		if !row.Expanded {
+			continue
+		}
+		html, err := dt.RenderDetail(row)
+		if err != nil {
+			return nil, err
+		}
+		details[row.ID] = html
+	}
+	return details, nil
+}
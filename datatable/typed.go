@@ -0,0 +1,374 @@
+package datatable
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Typed is a DataTable built from a slice of Go values. Columns are derived
+// from `datatable` struct tags and each item becomes a Row keyed by its key
+// field. All DataTable methods, actions and templates work on a Typed table.
+//
+// Tag syntax is the column ID followed by flags and key=value settings:
+//
+//	type User struct {
+//	    ID      int       `datatable:"id,key,hidden"`
+//	    Name    string    `datatable:"name,sortable,filterable,label=Name"`
+//	    Joined  time.Time `datatable:"joined,sortable,label=Joined,format=date"`
+//	    Balance float64   `datatable:"balance,align=right,format=currency"`
+//	    Role    string    `datatable:"role,editable,editor=select,options=admin|member"`
+//	    Secret  string    `datatable:"-"`
+//	}
+//
+// Flags are sortable, filterable, hidden, editable and key; settings are label,
+// format, width, align, editor, options ("|"-separated select choices),
+// aggregate and filter (a FilterKind such as "facet" or "number"). When no
+// field has a datatable tag, every exported field becomes a column named after
+// the field. Without a key field, a column named "id" (or a field named ID) is
+// used, falling back to the item's index.
+type Typed[T any] struct {
+	*DataTable
+
+	// items are the source values
+	items []T
+
+	// index maps row IDs to the index of their item in items
+	index map[string]int
+
+	// fields maps column IDs to struct field indexes
+	fields map[string][]int
+
+	// keyColumn is the column ID used for Row.ID
+	keyColumn string
+
+	// keyFunc overrides keyColumn when set
+	keyFunc func(T) string
+
+	// accessors override reflection for individual columns
+	accessors map[string]func(T) any
+}
+
+// NewTyped creates a data table from a slice of structs (or struct pointers).
+// Columns from struct tags are applied before opts, so WithColumns replaces them.
+//
+// Example:
+//
+//	users := datatable.NewTyped("users", users,
+//	    datatable.WithPageSize(10),
+//	    datatable.WithMultiSelect(true),
+//	)
+//	selected := users.GetSelectedRows() // []User
+func NewTyped[T any](id string, items []T, opts ...Option) *Typed[T] {
+	columns, fields, keyColumn := structColumns(reflect.TypeFor[T]())
+
+	t := &Typed[T]{
+		fields:    fields,
+		keyColumn: keyColumn,
+		accessors: make(map[string]func(T) any),
+	}
+	t.DataTable = New(id, append([]Option{WithColumns(columns)}, opts...)...)
+	t.cellEdited = t.setItemValue
+	t.SetItems(items)
+
+	return t
+}
+
+// SetItems replaces all items and rebuilds the rows. Selection is cleared.
+func (t *Typed[T]) SetItems(items []T) {
+	t.items = items
+	t.rebuild()
+}
+
+// SetKeyFunc sets the function that derives Row.ID from an item.
+func (t *Typed[T]) SetKeyFunc(fn func(T) string) {
+	t.keyFunc = fn
+	t.rebuild()
+}
+
+// SetAccessor sets the function that extracts a column's cell value from an
+// item, replacing reflection. The column does not need a struct field.
+//
+// Example:
+//
+//	users.SetAccessor("name", func(u User) any { return u.First + " " + u.Last })
+func (t *Typed[T]) SetAccessor(columnID string, fn func(T) any) {
+	t.accessors[columnID] = fn
+	t.rebuild()
+}
+
+// Items returns all items. Committed cell edits are written back to the
+// items' fields (see CommitEdit).
+func (t *Typed[T]) Items() []T {
+	return t.items
+}
+
+// Item returns the item for a row ID.
+func (t *Typed[T]) Item(id string) (T, bool) {
+	if i, ok := t.index[id]; ok {
+		return t.items[i], true
+	}
+	var zero T
+	return zero, false
+}
+
+// GetSelectedRows returns the selected items that are loaded.
+func (t *Typed[T]) GetSelectedRows() []T {
+	var selected []T
+	for _, row := range t.DataTable.GetSelectedRows() {
+		if item, ok := t.Item(row.ID); ok {
+			selected = append(selected, item)
+		}
+	}
+	return selected
+}
+
+// GetPageItems returns the items for the current page, after filtering and sorting.
+func (t *Typed[T]) GetPageItems() []T {
+	rows := t.GetPageRows()
+	items := make([]T, 0, len(rows))
+	for _, row := range rows {
+		if item, ok := t.Item(row.ID); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// rebuild converts the items to rows and indexes the items by row ID.
+func (t *Typed[T]) rebuild() {
+	rows := t.buildRows()
+	t.index = make(map[string]int, len(rows))
+	for i, row := range rows {
+		if _, ok := t.index[row.ID]; !ok {
+			t.index[row.ID] = i
+		}
+	}
+	t.SetData(rows)
+}
+
+// buildRows converts the items to rows.
+func (t *Typed[T]) buildRows() []Row {
+	rows := make([]Row, len(t.items))
+	for i, item := range t.items {
+		data := make(map[string]any, len(t.fields)+len(t.accessors))
+		v := indirect(reflect.ValueOf(&item).Elem())
+		for id, index := range t.fields {
+			data[id] = fieldValue(v, index)
+		}
+		for id, fn := range t.accessors {
+			data[id] = fn(item)
+		}
+
+		rows[i] = Row{ID: t.rowID(i, item, data), Data: data}
+	}
+	return rows
+}
+
+// setItemValue writes a committed cell edit to the item's field. Edits of
+// columns without a field (see SetAccessor), and values the field cannot
+// hold, are kept in the row only.
+func (t *Typed[T]) setItemValue(rowID, columnID string, value any) {
+	i, ok := t.index[rowID]
+	index, isField := t.fields[columnID]
+	if _, hasAccessor := t.accessors[columnID]; !ok || !isField || hasAccessor {
+		return
+	}
+	v := indirect(reflect.ValueOf(&t.items[i]).Elem())
+	if !v.IsValid() {
+		return
+	}
+	if f, err := v.FieldByIndexErr(index); err == nil && f.CanSet() {
+		setField(f, value)
+	}
+}
+
+// rowID derives the row ID for an item.
+func (t *Typed[T]) rowID(i int, item T, data map[string]any) string {
+	if t.keyFunc != nil {
+		return t.keyFunc(item)
+	}
+	if t.keyColumn != "" {
+		if v := data[t.keyColumn]; v != nil {
+			return cellString(v)
+		}
+	}
+	return strconv.Itoa(i)
+}
+
+// structColumns derives columns, field indexes and the key column from a
+// struct type (or pointer to struct). Other types yield no columns.
+func structColumns(typ reflect.Type) ([]Column, map[string][]int, string) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	fields := make(map[string][]int)
+	if typ.Kind() != reflect.Struct {
+		return nil, fields, ""
+	}
+
+	visible := reflect.VisibleFields(typ)
+	tagged := false
+	for _, f := range visible {
+		if _, ok := f.Tag.Lookup("datatable"); ok {
+			tagged = true
+			break
+		}
+	}
+
+	var columns []Column
+	var keyColumn, idColumn string
+	for _, f := range visible {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		tag, ok := f.Tag.Lookup("datatable")
+		if tag == "-" || (tagged && !ok) {
+			continue
+		}
+
+		col, isKey := parseColumnTag(tag)
+		if col.ID == "" {
+			col.ID = f.Name
+		}
+		if col.Label == "" {
+			col.Label = f.Name
+		}
+		if _, dup := fields[col.ID]; dup {
+			continue
+		}
+
+		fields[col.ID] = f.Index
+		columns = append(columns, col)
+
+		switch {
+		case isKey && keyColumn == "":
+			keyColumn = col.ID
+		case col.ID == "id" || (idColumn == "" && f.Name == "ID"):
+			idColumn = col.ID
+		}
+	}
+
+	if keyColumn == "" {
+		keyColumn = idColumn
+	}
+	return columns, fields, keyColumn
+}
+
+// parseColumnTag parses a `datatable` struct tag into a column and reports
+// whether it marks the key field.
+func parseColumnTag(tag string) (Column, bool) {
+	parts := strings.Split(tag, ",")
+	col := Column{ID: strings.TrimSpace(parts[0])}
+	isKey := false
+
+	for _, part := range parts[1:] {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch name {
+		case "sortable":
+			col.Sortable = true
+		case "filterable":
+			if col.Filterable.Kind == "" {
+				col.Filterable.Kind = FilterText
+			}
+		case "filter":
+			col.Filterable.Kind = FilterKind(value)
+		case "hidden":
+			col.Hidden = true
+		case "editable":
+			col.Editable = true
+		case "key":
+			isKey = true
+		case "label":
+			col.Label = value
+		case "format":
+			col.Format = value
+		case "width":
+			col.Width = value
+		case "align":
+			col.Align = value
+		case "editor":
+			col.Editor = EditorKind(value)
+		case "aggregate":
+			col.Aggregate = Aggregate(value)
+		case "options":
+			for _, opt := range strings.Split(value, "|") {
+				col.EditOptions = append(col.EditOptions, EditOption{Value: opt})
+			}
+		}
+	}
+
+	return col, isKey
+}
+
+// fieldValue returns the value of a (possibly promoted) field, dereferencing
+// pointers. Nil pointers, including nil embedded structs, yield nil.
+func fieldValue(v reflect.Value, index []int) any {
+	if !v.IsValid() {
+		return nil
+	}
+	f, err := v.FieldByIndexErr(index)
+	if err != nil {
+		return nil
+	}
+	f = indirect(f)
+	if !f.IsValid() {
+		return nil
+	}
+	return f.Interface()
+}
+
+// setField sets a field, or the value a pointer field points to, from a cell
+// value of the same kind. Whole float64 numbers are converted to integer
+// fields; nil clears the field.
+func setField(f reflect.Value, value any) {
+	if value == nil {
+		f.SetZero()
+		return
+	}
+	typ := f.Type()
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if n, ok := value.(float64); ok {
+		if converted, err := convertNumber(n, reflect.Zero(typ).Interface()); err == nil {
+			value = converted
+		}
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != typ.Kind() || !v.Type().ConvertibleTo(typ) {
+		return
+	}
+	v = v.Convert(typ)
+	if f.Kind() == reflect.Pointer {
+		p := reflect.New(typ)
+		p.Elem().Set(v)
+		v = p
+	}
+	f.Set(v)
+}
+
+// indirect dereferences pointers, returning the zero Value for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// asDataTable returns the *DataTable behind a template value, which may be a
+// *DataTable or a type embedding one such as *Typed[T].
+func asDataTable(v any) (*DataTable, bool) {
+	if t, ok := v.(interface{ dataTable() *DataTable }); ok {
+		return t.dataTable(), true
+	}
+	return nil, false
+}
+
+// dataTable lets template functions accept types that embed *DataTable.
+func (dt *DataTable) dataTable() *DataTable {
+	return dt
+}
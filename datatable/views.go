@@ -0,0 +1,299 @@
+package datatable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// View is a named snapshot of a table's sort, filters, page size and column
+// layout. It is JSON-serialisable; filter operands round-trip as JSON values
+// (numbers, strings and booleans).
+type View struct {
+	// Name identifies the view
+	Name string `json:"name"`
+	// Sort lists the sort keys in priority order
+	Sort []SortKey `json:"sort,omitempty"`
+	// Filter is the text filter
+	Filter string `json:"filter,omitempty"`
+	// FilterColumn limits the text filter to one column
+	FilterColumn string `json:"filterColumn,omitempty"`
+	// ColumnFilters are the per-column filters
+	ColumnFilters []ColumnFilter `json:"columnFilters,omitempty"`
+	// PageSize is rows per page (0 for all)
+	PageSize int `json:"pageSize,omitempty"`
+	// Layout is the column order, visibility, widths and pinning
+	Layout Layout `json:"layout"`
+}
+
+// ViewStore persists saved views, keyed by table ID. Create one store per
+// user to keep views per user.
+type ViewStore interface {
+	// ListViews returns a table's views, sorted by name.
+	ListViews(ctx context.Context, table string) ([]View, error)
+	// SaveView adds a view or replaces the view with the same name.
+	SaveView(ctx context.Context, table string, view View) error
+	// DeleteView removes a view, returning ErrViewNotFound if it does not exist.
+	DeleteView(ctx context.Context, table, name string) error
+}
+
+// ErrViewNotFound is returned for views that do not exist.
+var ErrViewNotFound = errors.New("datatable: view not found")
+
+// errNoViewStore is returned by view methods without WithViewStore.
+var errNoViewStore = errors.New("datatable: saved views require WithViewStore")
+
+// SnapshotView captures the table's current state as a view.
+func (dt *DataTable) SnapshotView(name string) View {
+	return View{
+		Name:          name,
+		Sort:          append([]SortKey(nil), dt.activeSortKeys()...),
+		Filter:        dt.FilterValue,
+		FilterColumn:  dt.FilterColumn,
+		ColumnFilters: append([]ColumnFilter(nil), dt.ColumnFilters...),
+		PageSize:      dt.PageSize,
+		Layout:        dt.Layout(),
+	}
+}
+
+// ApplyView restores a view and goes to the first page. Call Load afterwards
+// when using a DataSource.
+func (dt *DataTable) ApplyView(v View) {
+	dt.ApplyLayout(v.Layout)
+	dt.PageSize = v.PageSize
+	dt.FilterValue = v.Filter
+	dt.FilterColumn = v.FilterColumn
+	dt.ColumnFilters = append([]ColumnFilter(nil), v.ColumnFilters...)
+	dt.SetSortKeys(v.Sort...)
+	dt.CurrentView = v.Name
+	dt.filtersChanged()
+}
+
+// LoadViews refreshes SavedViews from the ViewStore. Call it after New; the
+// view actions keep it up to date.
+func (dt *DataTable) LoadViews(ctx context.Context) error {
+	if dt.views == nil {
+		return errNoViewStore
+	}
+	views, err := dt.views.ListViews(ctx, dt.ID())
+	if err != nil {
+		return fmt.Errorf("datatable: list views %q: %w", dt.ID(), err)
+	}
+	dt.SavedViews = make([]string, len(views))
+	for i, v := range views {
+		dt.SavedViews[i] = v.Name
+	}
+	return nil
+}
+
+// SaveView saves the current state as a named view and makes it current.
+func (dt *DataTable) SaveView(ctx context.Context, name string) error {
+	if dt.views == nil {
+		return errNoViewStore
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("datatable: view name is empty")
+	}
+	if err := dt.views.SaveView(ctx, dt.ID(), dt.SnapshotView(name)); err != nil {
+		return fmt.Errorf("datatable: save view %q: %w", name, err)
+	}
+	dt.CurrentView = name
+	return dt.LoadViews(ctx)
+}
+
+// ApplySavedView applies a view from the ViewStore.
+func (dt *DataTable) ApplySavedView(ctx context.Context, name string) error {
+	if dt.views == nil {
+		return errNoViewStore
+	}
+	views, err := dt.views.ListViews(ctx, dt.ID())
+	if err != nil {
+		return fmt.Errorf("datatable: list views %q: %w", dt.ID(), err)
+	}
+	for _, v := range views {
+		if v.Name == name {
+			dt.ApplyView(v)
+			return nil
+		}
+	}
+	return fmt.Errorf("datatable: view %q: %w", name, ErrViewNotFound)
+}
+
+// DeleteView deletes a view from the ViewStore.
+func (dt *DataTable) DeleteView(ctx context.Context, name string) error {
+	if dt.views == nil {
+		return errNoViewStore
+	}
+	if err := dt.views.DeleteView(ctx, dt.ID(), name); err != nil {
+		return fmt.Errorf("datatable: delete view %q: %w", name, err)
+	}
+	if dt.CurrentView == name {
+		dt.CurrentView = ""
+	}
+	return dt.LoadViews(ctx)
+}
+
+// MemoryViewStore is a ViewStore held in memory, e.g. for tests or
+// single-process apps that persist views elsewhere.
+type MemoryViewStore struct {
+	mu     sync.Mutex
+	tables map[string]map[string]View
+}
+
+// NewMemoryViewStore creates an empty MemoryViewStore.
+func NewMemoryViewStore() *MemoryViewStore {
+	return &MemoryViewStore{tables: make(map[string]map[string]View)}
+}
+
+// ListViews returns a table's views, sorted by name.
+func (s *MemoryViewStore) ListViews(ctx context.Context, table string) ([]View, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	views := make([]View, 0, len(s.tables[table]))
+	for _, v := range s.tables[table] {
+		views = append(views, v)
+	}
+	sortViews(views)
+	return views, nil
+}
+
+// SaveView adds or replaces a view.
+func (s *MemoryViewStore) SaveView(ctx context.Context, table string, view View) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tables[table] == nil {
+		s.tables[table] = make(map[string]View)
+	}
+	s.tables[table][view.Name] = view
+	return nil
+}
+
+// DeleteView removes a view.
+func (s *MemoryViewStore) DeleteView(ctx context.Context, table, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tables[table][name]; !ok {
+		return ErrViewNotFound
+	}
+	delete(s.tables[table], name)
+	return nil
+}
+
+// FileViewStore is a ViewStore kept in a JSON file, mapping table IDs to
+// their views. The file is created on the first save and replaced atomically
+// on each change.
+type FileViewStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileViewStore creates a FileViewStore backed by the file at path.
+func NewFileViewStore(path string) *FileViewStore {
+	return &FileViewStore{path: path}
+}
+
+// ListViews returns a table's views, sorted by name.
+func (s *FileViewStore) ListViews(ctx context.Context, table string) ([]View, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tables, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return tables[table], nil
+}
+
+// SaveView adds or replaces a view.
+func (s *FileViewStore) SaveView(ctx context.Context, table string, view View) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tables, err := s.read()
+	if err != nil {
+		return err
+	}
+	views := tables[table]
+	replaced := false
+	for i := range views {
+		if views[i].Name == view.Name {
+			views[i] = view
+			replaced = true
+		}
+	}
+	if !replaced {
+		views = append(views, view)
+	}
+	sortViews(views)
+	tables[table] = views
+	return s.write(tables)
+}
+
+// DeleteView removes a view.
+func (s *FileViewStore) DeleteView(ctx context.Context, table, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tables, err := s.read()
+	if err != nil {
+		return err
+	}
+	views := tables[table]
+	for i := range views {
+		if views[i].Name == name {
+			tables[table] = append(views[:i], views[i+1:]...)
+			return s.write(tables)
+		}
+	}
+	return ErrViewNotFound
+}
+
+// read loads the file; a missing file has no views.
+func (s *FileViewStore) read() (map[string][]View, error) {
+	tables := make(map[string][]View)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tables, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &tables); err != nil {
+		return nil, fmt.Errorf("datatable: read views %s: %w", s.path, err)
+	}
+	return tables, nil
+}
+
+// write replaces the file through a temporary file, so readers never see a
+// partial write.
+func (s *FileViewStore) write(tables map[string][]View) error {
+	data, err := json.MarshalIndent(tables, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// sortViews sorts views by name.
+func sortViews(views []View) {
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+}
@@ -0,0 +1,47 @@
+package datatable
+
+import (
+	"github.com/livetemplate/components/base"
+)
+
+// WindowRows returns the page rows to render: GetPageRows, limited to the
+// Window in virtualised mode.
+func (dt *DataTable) WindowRows() []Row {
+	return base.WindowOf(dt.Window, dt.GetPageRows())
+}
+
+// ScrollTo moves the Window to start at the page row at offset. It does
+// nothing unless the table is virtualised.
+func (dt *DataTable) ScrollTo(offset int) {
+	if dt.Window == nil {
+		return
+	}
+	dt.Window.SetTotal(len(dt.GetPageRows()))
+	dt.Window.ScrollTo(offset)
+}
+
+// LoadMore shows the next page below the current ones in infinite mode. It
+// returns false if there are no more rows. Call Load afterwards when using
+// a DataSource; only the new page is queried.
+func (dt *DataTable) LoadMore() bool {
+	if !dt.Infinite || !dt.HasNextPage() {
+		return false
+	}
+	dt.Page++
+	dt.loadingMore = true
+	dt.resetView()
+	return true
+}
+
+// scroll handles the "scroll" action: it moves the Window and, in infinite
+// mode, loads the next page once the last row is in view.
+func (dt *DataTable) scroll(ctx *base.ActionContext) error {
+	if dt.Window != nil {
+		dt.Window.SetTotal(len(dt.GetPageRows()))
+		dt.Window.ScrollFromAction(ctx)
+	}
+	if (dt.Window == nil || dt.Window.AtEnd()) && dt.LoadMore() {
+		return dt.Load(ctx.Context())
+	}
+	return nil
+}
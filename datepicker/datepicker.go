@@ -19,6 +19,7 @@
 package datepicker
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/livetemplate/components/base"
@@ -351,6 +352,80 @@ func (rp *RangePicker) DisplayRangeValue() string {
 	return start + " - " + rp.EndDate.Format(rp.Format)
 }
 
+// Actions returns the date picker's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "toggle_datepicker" and "close_datepicker" open and close the calendar
+//   - "prev_month", "next_month" and "today" move the calendar view
+//   - "select_date" selects lvt-data-date (formatted as "2006-01-02")
+//   - "clear_date" clears the selection
+func (dp *DatePicker) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle_datepicker": func(ctx *base.ActionContext) error {
+			dp.Toggle()
+			return nil
+		},
+		"close_datepicker": func(ctx *base.ActionContext) error {
+			dp.Close()
+			return nil
+		},
+		"prev_month": func(ctx *base.ActionContext) error {
+			dp.PreviousMonth()
+			return nil
+		},
+		"next_month": func(ctx *base.ActionContext) error {
+			dp.NextMonth()
+			return nil
+		},
+		"today": func(ctx *base.ActionContext) error {
+			dp.GoToToday()
+			return nil
+		},
+		"select_date": func(ctx *base.ActionContext) error {
+			date, err := dp.parseActionDate(ctx)
+			if err != nil {
+				return err
+			}
+			dp.SelectDate(date)
+			return nil
+		},
+		"clear_date": func(ctx *base.ActionContext) error {
+			dp.Clear()
+			return nil
+		},
+	}
+}
+
+// Actions returns the range picker's action handlers.
+// "select_date" picks the start or end of the range, and "clear_range"
+// clears both dates; all other actions are shared with DatePicker.
+func (rp *RangePicker) Actions() map[string]base.ActionHandler {
+	actions := rp.DatePicker.Actions()
+	actions["select_date"] = func(ctx *base.ActionContext) error {
+		date, err := rp.parseActionDate(ctx)
+		if err != nil {
+			return err
+		}
+		rp.SelectRangeDate(date)
+		return nil
+	}
+	actions["clear_range"] = func(ctx *base.ActionContext) error {
+		rp.ClearRange()
+		return nil
+	}
+	return actions
+}
+
+// parseActionDate reads lvt-data-date in the location of the current view.
+func (dp *DatePicker) parseActionDate(ctx *base.ActionContext) (time.Time, error) {
+	raw := ctx.Data("date")
+	date, err := time.ParseInLocation("2006-01-02", raw, dp.ViewDate.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("datepicker: invalid date %q: %w", raw, err)
+	}
+	return date, nil
+}
+
 // Helper functions
 func sameDay(a, b time.Time) bool {
 	y1, m1, d1 := a.Date()
@@ -3,6 +3,8 @@ package datepicker
 import (
 	"testing"
 	"time"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -712,3 +714,54 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Expected Templates() to return a TemplateSet")
 	}
 }
+
+func TestActions(t *testing.T) {
+	dp := New("birthdate")
+	actions := dp.Actions()
+
+	ctx := base.NewActionContext("toggle_datepicker", "birthdate", nil)
+	if err := actions["toggle_datepicker"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dp.Open {
+		t.Error("expected calendar to be open")
+	}
+
+	ctx = base.NewActionContext("select_date", "birthdate", map[string]string{"date": "2024-03-15"})
+	if err := actions["select_date"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dp.Selected == nil || dp.Selected.Format("2006-01-02") != "2024-03-15" {
+		t.Errorf("expected 2024-03-15 to be selected, got %v", dp.Selected)
+	}
+
+	ctx = base.NewActionContext("select_date", "birthdate", map[string]string{"date": "not-a-date"})
+	if err := actions["select_date"](ctx); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestRangeActions(t *testing.T) {
+	rp := NewRange("stay")
+	actions := rp.Actions()
+
+	for _, date := range []string{"2024-03-20", "2024-03-15"} {
+		ctx := base.NewActionContext("select_date", "stay", map[string]string{"date": date})
+		if err := actions["select_date"](ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if rp.StartDate == nil || rp.EndDate == nil {
+		t.Fatal("expected both range dates to be set")
+	}
+	if rp.StartDate.Format("2006-01-02") != "2024-03-15" {
+		t.Errorf("expected start 2024-03-15, got %s", rp.StartDate.Format("2006-01-02"))
+	}
+
+	if err := actions["clear_range"](base.NewActionContext("clear_range", "stay", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rp.StartDate != nil {
+		t.Error("expected range to be cleared")
+	}
+}
@@ -219,3 +219,25 @@ func (d *Drawer) TransformClass() string {
 func (d *Drawer) HasTitle() bool {
 	return d.Title != ""
 }
+
+// Actions returns the drawer's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "toggle" and "show" open the drawer
+//   - "close" closes it unless the drawer is persistent
+func (d *Drawer) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle": func(ctx *base.ActionContext) error {
+			d.Toggle()
+			return nil
+		},
+		"show": func(ctx *base.ActionContext) error {
+			d.Show()
+			return nil
+		},
+		"close": func(ctx *base.ActionContext) error {
+			d.Close()
+			return nil
+		},
+	}
+}
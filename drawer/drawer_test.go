@@ -2,6 +2,8 @@ package drawer
 
 import (
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 // =============================================================================
@@ -455,3 +457,35 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Templates() returned nil")
 	}
 }
+
+// =============================================================================
+// Action Tests
+// =============================================================================
+
+func TestActions(t *testing.T) {
+	d := New("nav")
+	actions := d.Actions()
+
+	if err := actions["show"](base.NewActionContext("show", "nav", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !d.Open {
+		t.Error("expected drawer to be open after show")
+	}
+
+	if err := actions["close"](base.NewActionContext("close", "nav", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Open {
+		t.Error("expected drawer to be closed after close")
+	}
+
+	d.Persistent = true
+	d.Show()
+	if err := actions["close"](base.NewActionContext("close", "nav", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !d.Open {
+		t.Error("expected persistent drawer to stay open")
+	}
+}
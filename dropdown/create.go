@@ -0,0 +1,114 @@
+package dropdown
+
+import (
+	"errors"
+
+	"github.com/livetemplate/components/base"
+)
+
+// errNoCreatedValue is returned when the WithOnCreate hook returns an item
+// without a value or label.
+var errNoCreatedValue = errors.New("dropdown: created option has no value")
+
+// Creatable returns true if new options can be created from the search query
+// (see WithOnCreate).
+func (d *Dropdown) Creatable() bool {
+	return d.onCreate != nil
+}
+
+// canCreate returns true if query is not blank and no option's label or value
+// matches it exactly, ignoring case and diacritics.
+func (d *Dropdown) canCreate(query string) bool {
+	query = trimSpace(query)
+	if d.onCreate == nil || query == "" {
+		return false
+	}
+	folded := base.Fold(query)
+	for _, opt := range d.Options {
+		if opt.Value == query || base.Fold(opt.Label) == folded {
+			return false
+		}
+	}
+	return true
+}
+
+// create passes query to the WithOnCreate hook and adds the item it returns
+// to Options, unless an option with its value exists. An error from the hook
+// is kept in CreateError.
+func (d *Dropdown) create(query string) (Item, error) {
+	d.CreateError = ""
+	if !d.canCreate(query) {
+		return Item{}, nil
+	}
+
+	item, err := d.onCreate(trimSpace(query))
+	if err == nil && item.Value == "" && item.Label == "" {
+		err = errNoCreatedValue
+	}
+	if err != nil {
+		d.CreateError = err.Error()
+		return Item{}, err
+	}
+	if item.Value == "" {
+		item.Value = item.Label
+	}
+	if item.Label == "" {
+		item.Label = item.Value
+	}
+
+	for _, opt := range d.Options {
+		if opt.Value == item.Value {
+			return opt, nil
+		}
+	}
+	d.Options = append(d.Options, item)
+	return item, nil
+}
+
+// CanCreate returns true if the query can be created as a new option: it
+// is long enough to search and no option matches it exactly.
+func (s *Searchable) CanCreate() bool {
+	return s.searching() && s.canCreate(s.Query)
+}
+
+// Create creates an option from the query with the WithOnCreate hook, adds
+// it to Options and selects it. If the hook rejects the query, Create
+// returns its error, which is also kept in CreateError for display.
+func (s *Searchable) Create() error {
+	item, err := s.create(s.Query)
+	if err != nil || item.Value == "" {
+		return err
+	}
+	s.Select(item.Value)
+	s.ClearSearch()
+	return nil
+}
+
+// CanCreate returns true if the query can be created as a new option: no
+// option matches it exactly and MaxSelections is not reached.
+func (m *Multi) CanCreate() bool {
+	if m.MaxSelections > 0 && len(m.SelectedItems) >= m.MaxSelections {
+		return false
+	}
+	return m.canCreate(m.Query)
+}
+
+// Create creates an option from the query with the WithOnCreate hook, adds
+// it to Options and selects it, then clears the query so the next option can
+// be created. If the hook rejects the query, Create returns its error, which
+// is also kept in CreateError for display.
+func (m *Multi) Create() error {
+	if !m.CanCreate() {
+		m.CreateError = ""
+		return nil
+	}
+	item, err := m.create(m.Query)
+	if err != nil || item.Value == "" {
+		return err
+	}
+	if !m.IsSelected(item.Value) {
+		m.SelectedItems = append(m.SelectedItems, item)
+	}
+	m.Search("")
+	return nil
+}
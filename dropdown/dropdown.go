@@ -6,7 +6,8 @@
 //   - NewMulti() creates a multi-select dropdown (template: "lvt:dropdown:multi:v1")
 //
 // Required lvt-* attributes: lvt-click, lvt-click-away
-// Optional: lvt-debounce (for searchable), lvt-focus-trap
+// Optional: lvt-debounce (for searchable), lvt-scroll (for WithVirtualOptions), lvt-focus-trap,
+// lvt-keydown (for keyboard navigation)
 //
 // Example usage:
 //
@@ -20,6 +21,9 @@
 package dropdown
 
 import (
+	"time"
+	"unicode/utf8"
+
 	"github.com/livetemplate/components/base"
 )
 
@@ -28,7 +32,7 @@ type Item struct {
 	Value    string // The value sent to the server when selected
 	Label    string // The display text shown to users
 	Disabled bool   // Whether this option is disabled
-	Group    string // Optional group/category for grouped dropdowns
+	Group    string // Optional group/category for grouped dropdowns (see Groups)
 }
 
 // Dropdown is a basic single-select dropdown component.
@@ -50,6 +54,26 @@ type Dropdown struct {
 
 	// Disabled prevents user interaction
 	Disabled bool
+
+	// GroupOrder lists the Item.Group names in display order; groups not
+	// listed follow in order of their first option
+	GroupOrder []string
+
+	// CreateError is the error from the WithOnCreate hook when it rejected
+	// the last option created ("" if none)
+	CreateError string
+
+	// Highlighted is the value of the option highlighted with the keyboard
+	// ("" for none)
+	Highlighted string
+
+	// typed and typedAt are the letters typed in a row for type-ahead and
+	// when the last was typed
+	typed   string
+	typedAt time.Time
+
+	// onCreate creates an option from a search query (see WithOnCreate)
+	onCreate func(query string) (Item, error)
 }
 
 // New creates a basic single-select dropdown.
@@ -81,12 +105,18 @@ func New(id string, options []Item, opts ...Option) *Dropdown {
 
 // Toggle opens or closes the dropdown.
 func (d *Dropdown) Toggle() {
-	d.Open = !d.Open
+	if d.Open {
+		d.Close()
+		return
+	}
+	d.Open = true
 }
 
-// Close closes the dropdown.
+// Close closes the dropdown and clears the highlight.
 func (d *Dropdown) Close() {
 	d.Open = false
+	d.Highlighted = ""
+	d.typed = ""
 }
 
 // Select selects an item by value.
@@ -121,12 +151,33 @@ type Searchable struct {
 	// Query is the current search query
 	Query string
 
-	// FilteredOptions is the list of options matching the current query
-	// If nil, all options are shown
+	// FilteredOptions is the list of options matching the current query,
+	// best match first. If nil, all options are shown
 	FilteredOptions []Item
 
 	// MinChars is the minimum characters required before filtering starts
 	MinChars int
+
+	// Window limits the rendered options to the ones in view, for very long
+	// option lists (nil renders every option)
+	Window *base.Window
+
+	// PageSize is the number of options fetched per page from an
+	// OptionSource
+	PageSize int
+
+	// Loading indicates options are being fetched from the OptionSource
+	Loading bool
+
+	// NextCursor is the OptionSource cursor of the next page of options (""
+	// if every matching option is loaded)
+	NextCursor string
+
+	// source supplies the options (see WithOptionSource)
+	source OptionSource
+	// loaded is set once Options holds the OptionSource's first page for
+	// the current query
+	loaded bool
 }
 
 // NewSearchable creates a searchable dropdown.
@@ -154,37 +205,86 @@ func NewSearchable(id string, options []Item, opts ...Option) *Searchable {
 	return s
 }
 
-// Search filters options based on the query.
+// Search filters options based on the query, ignoring case and diacritics,
+// and ranks them: labels starting with the query first, then labels with a
+// word starting with it, then labels containing it, then labels containing
+// its letters in order (see base.Matcher). With an OptionSource, the options
+// are filtered by the source instead; call Load to fetch them.
 func (s *Searchable) Search(query string) {
 	s.Query = query
 	s.Open = true
+	s.loaded = false
+	s.CreateError = ""
+	s.Highlighted = ""
+	if s.Window != nil {
+		s.Window.Offset = 0
+	}
 
-	if len(query) < s.MinChars {
+	if s.source != nil || !s.searching() {
 		s.FilteredOptions = nil
 		return
 	}
 
-	s.FilteredOptions = make([]Item, 0)
-	queryLower := toLower(query)
-	for _, opt := range s.Options {
-		if contains(toLower(opt.Label), queryLower) {
-			s.FilteredOptions = append(s.FilteredOptions, opt)
-		}
+	ranked := base.Rank(query, s.Options, func(opt Item) string { return opt.Label })
+	s.FilteredOptions = make([]Item, len(ranked))
+	for i, r := range ranked {
+		s.FilteredOptions[i] = r.Item
+	}
+}
+
+// searching returns true if the query is long enough to filter options.
+func (s *Searchable) searching() bool {
+	return s.Query != "" && utf8.RuneCountInString(s.Query) >= s.MinChars
+}
+
+// Match returns how an option's label matches the query, with the matched
+// ranges of the label.
+func (s *Searchable) Match(item Item) base.Match {
+	if !s.searching() {
+		return base.Match{}
 	}
+	m, _ := base.NewMatcher(s.Query).Match(item.Label)
+	return m
 }
 
-// VisibleOptions returns the options to display (filtered if searching, all otherwise).
+// Highlight returns an option's label split into the parts that match the
+// query and the parts that don't, for rendering matches in <mark>.
+func (s *Searchable) Highlight(item Item) []base.Segment {
+	return s.Match(item).Segments(item.Label)
+}
+
+// VisibleOptions returns the options to display (filtered if searching, all
+// otherwise). With an OptionSource, the loaded options are already filtered.
 func (s *Searchable) VisibleOptions() []Item {
-	if s.Query != "" && len(s.Query) >= s.MinChars {
+	if s.source == nil && s.searching() {
 		return s.FilteredOptions
 	}
 	return s.Options
 }
 
+// WindowOptions returns the visible options to render in group order (see
+// Groups), limited to the Window if one is set.
+func (s *Searchable) WindowOptions() []Item {
+	return base.WindowOf(s.Window, groupedOptions(s.VisibleOptions(), s.GroupOrder))
+}
+
+// ScrollTo moves the Window to start at the visible option at offset.
+func (s *Searchable) ScrollTo(offset int) {
+	if s.Window == nil {
+		return
+	}
+	s.Window.SetTotal(len(s.VisibleOptions()))
+	s.Window.ScrollTo(offset)
+}
+
 // ClearSearch clears the search query and shows all options.
 func (s *Searchable) ClearSearch() {
 	s.Query = ""
 	s.FilteredOptions = nil
+	s.loaded = false
+	if s.Window != nil {
+		s.Window.Offset = 0
+	}
 }
 
 // Multi is a multi-select dropdown with checkboxes.
@@ -197,6 +297,13 @@ type Multi struct {
 
 	// MaxSelections limits how many items can be selected (0 = unlimited)
 	MaxSelections int
+
+	// CollapsedGroups tracks the collapsed option groups by name
+	CollapsedGroups map[string]bool
+
+	// Query is the current search query, entered to find or create options
+	// (see WithOnCreate)
+	Query string
 }
 
 // NewMulti creates a multi-select dropdown.
@@ -249,6 +356,28 @@ func (m *Multi) ToggleItem(value string) {
 	}
 }
 
+// Search filters the options by the query, best match first (see
+// Searchable.Search).
+func (m *Multi) Search(query string) {
+	m.Query = query
+	m.CreateError = ""
+	m.Highlighted = ""
+}
+
+// VisibleOptions returns the options matching the query, or all options
+// without one.
+func (m *Multi) VisibleOptions() []Item {
+	if trimSpace(m.Query) == "" {
+		return m.Options
+	}
+	ranked := base.Rank(m.Query, m.Options, func(opt Item) string { return opt.Label })
+	options := make([]Item, len(ranked))
+	for i, r := range ranked {
+		options[i] = r.Item
+	}
+	return options
+}
+
 // IsSelected checks if an item is currently selected.
 func (m *Multi) IsSelected(value string) bool {
 	for _, item := range m.SelectedItems {
@@ -299,34 +428,138 @@ func (m *Multi) DisplayText() string {
 	}
 }
 
-// Helper functions to avoid importing strings/strconv
-func toLower(s string) string {
-	b := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			c += 'a' - 'A'
+// Actions returns the dropdown's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "toggle" and "close" open and close the menu
+//   - "select" selects the option in lvt-data-value
+//   - "clear" clears the selection
+//   - "keydown" moves the highlight and selects with the keyboard (see
+//     KeyDown)
+func (d *Dropdown) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle": func(ctx *base.ActionContext) error {
+			d.Toggle()
+			return nil
+		},
+		"close": func(ctx *base.ActionContext) error {
+			d.Close()
+			return nil
+		},
+		"select": func(ctx *base.ActionContext) error {
+			d.Select(ctx.Data("value"))
+			return nil
+		},
+		"clear": func(ctx *base.ActionContext) error {
+			d.Clear()
+			return nil
+		},
+		"keydown": func(ctx *base.ActionContext) error {
+			d.KeyDown(ctx.Data("key"))
+			return nil
+		},
+	}
+}
+
+// Actions returns the searchable dropdown's action handlers.
+// It extends the Dropdown actions with "open", "search" (input value),
+// "clear_search", "scroll" (lvt-data-offset or scrollTop, see Window),
+// "load_more", "create", which creates an option from the query (see
+// WithOnCreate), and "keydown" (see Searchable.KeyDown). With an
+// OptionSource, "open" fetches the first page of options if it is not loaded,
+// "search" and "clear_search" fetch the first page for the new query, and
+// "load_more" appends the next page.
+func (s *Searchable) Actions() map[string]base.ActionHandler {
+	actions := s.Dropdown.Actions()
+	actions["open"] = func(ctx *base.ActionContext) error {
+		s.Open = true
+		if !s.loaded {
+			return s.Load(ctx.Context())
+		}
+		return nil
+	}
+	actions["search"] = func(ctx *base.ActionContext) error {
+		s.Search(ctx.Data("value"))
+		return s.Load(ctx.Context())
+	}
+	actions["clear_search"] = func(ctx *base.ActionContext) error {
+		s.ClearSearch()
+		return s.Load(ctx.Context())
+	}
+	actions["load_more"] = func(ctx *base.ActionContext) error {
+		return s.LoadMore(ctx.Context())
+	}
+	actions["create"] = func(ctx *base.ActionContext) error {
+		// A rejected query is shown in CreateError.
+		s.Create()
+		return nil
+	}
+	actions["keydown"] = func(ctx *base.ActionContext) error {
+		s.KeyDown(ctx.Data("key"))
+		return nil
+	}
+	actions["scroll"] = func(ctx *base.ActionContext) error {
+		if s.Window != nil {
+			s.Window.SetTotal(len(s.VisibleOptions()))
+			s.Window.ScrollFromAction(ctx)
 		}
-		b[i] = c
+		return nil
 	}
-	return string(b)
+	return actions
 }
 
-func contains(s, substr string) bool {
-	if len(substr) == 0 {
-		return true
+// Actions returns the multi-select dropdown's action handlers.
+// It extends the Dropdown actions with "toggle_item" (lvt-data-value),
+// "select_all", "clear_all", "toggle_group" and "select_group"
+// (lvt-data-group), which collapse a group and select all its options,
+// "search" (input value), "create", which creates an option from the query
+// or input value (see WithOnCreate), and "keydown" (see Multi.KeyDown).
+func (m *Multi) Actions() map[string]base.ActionHandler {
+	actions := m.Dropdown.Actions()
+	actions["toggle_item"] = func(ctx *base.ActionContext) error {
+		m.ToggleItem(ctx.Data("value"))
+		return nil
 	}
-	if len(substr) > len(s) {
-		return false
+	actions["select_all"] = func(ctx *base.ActionContext) error {
+		m.SelectAll()
+		return nil
 	}
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+	actions["clear_all"] = func(ctx *base.ActionContext) error {
+		m.ClearAll()
+		return nil
+	}
+	actions["toggle_group"] = func(ctx *base.ActionContext) error {
+		m.ToggleGroup(ctx.Data("group"))
+		return nil
+	}
+	actions["select_group"] = func(ctx *base.ActionContext) error {
+		m.SelectGroup(ctx.Data("group"))
+		return nil
+	}
+	actions["search"] = func(ctx *base.ActionContext) error {
+		m.Search(ctx.Data("value"))
+		return nil
+	}
+	actions["create"] = func(ctx *base.ActionContext) error {
+		if ctx.HasData("value") {
+			m.Query = ctx.Data("value")
 		}
+		// A rejected query is shown in CreateError.
+		m.Create()
+		return nil
 	}
-	return false
+	actions["keydown"] = func(ctx *base.ActionContext) error {
+		// Keys pressed in the search input send its value.
+		if ctx.HasData("value") && ctx.Data("value") != m.Query {
+			m.Search(ctx.Data("value"))
+		}
+		m.KeyDown(ctx.Data("key"))
+		return nil
+	}
+	return actions
 }
 
+// Helper functions to avoid importing strconv
 func itoa(n int) string {
 	if n == 0 {
 		return "0"
@@ -341,3 +574,30 @@ func itoa(n int) string {
 	}
 	return string(digits)
 }
+
+func trimSpace(s string) string {
+	start := 0
+	end := len(s)
+	for start < end && (s[start] == ' ' || s[start] == '\t' || s[start] == '\n' || s[start] == '\r') {
+		start++
+	}
+	for end > start && (s[end-1] == ' ' || s[end-1] == '\t' || s[end-1] == '\n' || s[end-1] == '\r') {
+		end--
+	}
+	return s[start:end]
+}
+
+func atoi(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
@@ -1,9 +1,14 @@
 package dropdown
 
 import (
+	"context"
+	"errors"
 	"html/template"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -211,6 +216,77 @@ func TestSearchable_SearchCaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestSearchable_SearchUnicode(t *testing.T) {
+	options := []Item{
+		{Value: "at", Label: "Österreich"},
+		{Value: "tr", Label: "İstanbul"},
+		{Value: "br", Label: "São Paulo"},
+		{Value: "de", Label: "Deutschland"},
+	}
+
+	s := NewSearchable("city", options)
+	for query, want := range map[string]string{
+		"öster":    "at",
+		"OSTER":    "at",
+		"istanbul": "tr",
+		"sao":      "br",
+	} {
+		s.Search(query)
+		if len(s.FilteredOptions) != 1 || s.FilteredOptions[0].Value != want {
+			t.Errorf("Search(%q) = %v, want %s", query, s.FilteredOptions, want)
+		}
+	}
+
+	// MinChars counts characters, not bytes.
+	s.MinChars = 2
+	s.Search("Ö")
+	if s.FilteredOptions != nil {
+		t.Error("expected no filtering below MinChars")
+	}
+}
+
+func TestSearchable_SearchRanked(t *testing.T) {
+	options := []Item{
+		{Value: "nz", Label: "New Zealand"},
+		{Value: "ca", Label: "Canada"},
+		{Value: "cd", Label: "Chad"},
+		{Value: "cn", Label: "Central African Republic"},
+		{Value: "sc", Label: "South Carolina"},
+		{Value: "ch", Label: "Switzerland"},
+	}
+
+	s := NewSearchable("country", options)
+	s.Search("ca")
+
+	var got []string
+	for _, opt := range s.FilteredOptions {
+		got = append(got, opt.Value)
+	}
+	// Prefix, then word start, then substring, then fuzzy matches.
+	want := []string{"ca", "sc", "cn", "cd"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	if m := s.Match(options[1]); m.Kind != base.MatchPrefix || len(m.Ranges) != 1 || m.Ranges[0] != (base.Range{Start: 0, End: 2}) {
+		t.Errorf("unexpected match %+v", m)
+	}
+	segments := s.Highlight(options[2])
+	if len(segments) != 4 || segments[0].Text != "C" || !segments[0].Match || segments[2].Text != "a" || !segments[2].Match {
+		t.Errorf("unexpected highlight %+v", segments)
+	}
+
+	s.ClearSearch()
+	if segments := s.Highlight(options[1]); len(segments) != 1 || segments[0].Match {
+		t.Errorf("expected no highlight without a query, got %+v", segments)
+	}
+}
+
 func TestSearchable_SearchBelowMinChars(t *testing.T) {
 	options := []Item{
 		{Value: "a", Label: "Alpha"},
@@ -626,48 +702,83 @@ func TestUnstyledTemplateRendering(t *testing.T) {
 	})
 }
 
-// Helper tests
-func TestToLower(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-	}{
-		{"HELLO", "hello"},
-		{"Hello World", "hello world"},
-		{"already lower", "already lower"},
-		{"MiXeD", "mixed"},
-		{"", ""},
+func TestDropdown_Actions(t *testing.T) {
+	options := []Item{
+		{Value: "us", Label: "United States"},
+		{Value: "ca", Label: "Canada"},
 	}
+	d := New("country", options)
+	actions := d.Actions()
 
-	for _, tc := range tests {
-		result := toLower(tc.input)
-		if result != tc.expected {
-			t.Errorf("toLower(%q) = %q, expected %q", tc.input, result, tc.expected)
-		}
+	if err := actions["toggle"](base.NewActionContext("toggle", "country", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !d.Open {
+		t.Error("expected dropdown to be open")
+	}
+
+	ctx := base.NewActionContext("select", "country", map[string]string{"value": "ca"})
+	if err := actions["select"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Value() != "ca" {
+		t.Errorf("expected 'ca' to be selected, got %q", d.Value())
 	}
 }
 
-func TestContains(t *testing.T) {
-	tests := []struct {
-		s        string
-		substr   string
-		expected bool
-	}{
-		{"hello world", "world", true},
-		{"hello world", "foo", false},
-		{"hello", "", true},
-		{"", "hello", false},
-		{"abc", "abcd", false},
+func TestSearchable_Actions(t *testing.T) {
+	options := []Item{
+		{Value: "us", Label: "United States"},
+		{Value: "ca", Label: "Canada"},
 	}
+	s := NewSearchable("country", options)
+	actions := s.Actions()
 
-	for _, tc := range tests {
-		result := contains(tc.s, tc.substr)
-		if result != tc.expected {
-			t.Errorf("contains(%q, %q) = %v, expected %v", tc.s, tc.substr, result, tc.expected)
-		}
+	ctx := base.NewActionContext("search", "country", map[string]string{"value": "can"})
+	if err := actions["search"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.VisibleOptions()) != 1 {
+		t.Errorf("expected 1 visible option, got %d", len(s.VisibleOptions()))
+	}
+
+	if err := actions["clear_search"](base.NewActionContext("clear_search", "country", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Query != "" {
+		t.Error("expected query to be cleared")
+	}
+
+	if _, ok := actions["select"]; !ok {
+		t.Error("expected inherited select action")
 	}
 }
 
+func TestMulti_Actions(t *testing.T) {
+	options := []Item{
+		{Value: "a", Label: "Alpha"},
+		{Value: "b", Label: "Beta"},
+	}
+	m := NewMulti("tags", options)
+	actions := m.Actions()
+
+	ctx := base.NewActionContext("toggle_item", "tags", map[string]string{"value": "b"})
+	if err := actions["toggle_item"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !m.IsSelected("b") {
+		t.Error("expected 'b' to be selected")
+	}
+
+	if err := actions["clear_all"](base.NewActionContext("clear_all", "tags", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.SelectedItems) != 0 {
+		t.Error("expected selection to be cleared")
+	}
+}
+
+// Helper tests
 func TestItoa(t *testing.T) {
 	tests := []struct {
 		input    int
@@ -688,3 +799,912 @@ func TestItoa(t *testing.T) {
 		}
 	}
 }
+
+func TestSearchable_VirtualOptions(t *testing.T) {
+	options := make([]Item, 1000)
+	for i := range options {
+		options[i] = Item{Value: itoa(i), Label: "Option " + itoa(i)}
+	}
+	s := NewSearchable("big", options)
+	WithVirtualOptions(10, 32)(s)
+
+	if got := s.WindowOptions(); len(got) != 15 || got[0].Value != "0" {
+		t.Fatalf("expected options 0-14, got %d from %s", len(got), got[0].Value)
+	}
+
+	if err := s.Actions()["scroll"](base.NewActionContext("scroll", "big", map[string]string{"offset": "500"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.WindowOptions(); len(got) != 20 || got[0].Value != "495" {
+		t.Errorf("expected options from 495, got %d from %s", len(got), got[0].Value)
+	}
+
+	s.Search("Option 99")
+	if s.Window.Offset != 0 {
+		t.Errorf("expected search to scroll to the top, got %d", s.Window.Offset)
+	}
+	// 11 options contain "Option 99"; 17 more contain its letters in order.
+	if got := s.WindowOptions(); len(got) != 15 || s.Window.Total != 28 {
+		t.Errorf("expected 15 of 28 matching options, got %d of %d", len(got), s.Window.Total)
+	}
+	if got := s.FilteredOptions[10].Value; got != "999" {
+		t.Errorf("expected substring matches first, got %s at 10", got)
+	}
+}
+
+func TestSearchable_VirtualTemplate(t *testing.T) {
+	ts := Templates()
+	tmpl, err := template.New("test").ParseFS(ts.FS, ts.Pattern)
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	options := make([]Item, 100)
+	for i := range options {
+		options[i] = Item{Value: itoa(i), Label: "Option " + itoa(i)}
+	}
+
+	for _, styled := range []bool{true, false} {
+		s := NewSearchable("big", options, WithStyled(styled), WithOpen(true))
+		WithVirtualOptions(10, 30)(s)
+		s.ScrollTo(50)
+
+		var buf strings.Builder
+		if err := tmpl.ExecuteTemplate(&buf, "lvt:dropdown:searchable:v1", s); err != nil {
+			t.Fatalf("failed to execute template: %v", err)
+		}
+
+		html := buf.String()
+		for _, want := range []string{
+			`lvt-scroll="scroll_big"`,
+			"max-height: 300px",
+			`style="height: 1350px"`,
+			`style="height: 1050px"`,
+			`lvt-data-value="45"`,
+			`lvt-data-value="64"`,
+		} {
+			if !strings.Contains(html, want) {
+				t.Errorf("styled=%v: expected output to contain %q", styled, want)
+			}
+		}
+		if strings.Contains(html, `lvt-data-value="44"`) || strings.Contains(html, `lvt-data-value="65"`) {
+			t.Errorf("styled=%v: expected options outside the window not to render", styled)
+		}
+	}
+}
+
+func groupTestOptions() []Item {
+	return []Item{
+		{Value: "apple", Label: "Apple", Group: "Fruit"},
+		{Value: "carrot", Label: "Carrot", Group: "Vegetables"},
+		{Value: "banana", Label: "Banana", Group: "Fruit"},
+		{Value: "other", Label: "Other"},
+		{Value: "leek", Label: "Leek", Group: "Vegetables", Disabled: true},
+		{Value: "pea", Label: "Pea", Group: "Vegetables"},
+	}
+}
+
+func groupNames(groups []OptionGroup) string {
+	var s string
+	for _, g := range groups {
+		s += "[" + g.Name + ":"
+		for _, opt := range g.Options {
+			s += " " + opt.Value
+		}
+		s += "]"
+	}
+	return s
+}
+
+func TestGroups(t *testing.T) {
+	d := New("food", groupTestOptions())
+	if !d.HasGroups() {
+		t.Error("expected grouped options")
+	}
+	if got, want := groupNames(d.Groups()), "[Fruit: apple banana][Vegetables: carrot leek pea][: other]"; got != want {
+		t.Errorf("expected groups in order of first option %s, got %s", want, got)
+	}
+
+	d = New("food", groupTestOptions(), WithGroupOrder("", "Vegetables", "Nuts"))
+	if got, want := groupNames(d.Groups()), "[: other][Vegetables: carrot leek pea][Fruit: apple banana]"; got != want {
+		t.Errorf("expected groups in GroupOrder %s, got %s", want, got)
+	}
+
+	flat := New("flat", []Item{{Value: "a", Label: "Alpha"}, {Value: "b", Label: "Beta"}})
+	if flat.HasGroups() {
+		t.Error("expected no groups")
+	}
+	if got, want := groupNames(flat.Groups()), "[: a b]"; got != want {
+		t.Errorf("expected a single unnamed group %s, got %s", want, got)
+	}
+}
+
+func TestSearchable_Groups(t *testing.T) {
+	s := NewSearchable("food", groupTestOptions(), WithGroupOrder("Vegetables"))
+	if got, want := groupNames(s.Groups()), "[Vegetables: carrot leek pea][Fruit: apple banana][: other]"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if got := s.WindowOptions(); got[0].Value != "carrot" || got[3].Value != "apple" {
+		t.Errorf("expected window options in group order, got %+v", got)
+	}
+
+	s.Search("an")
+	if got, want := groupNames(s.Groups()), "[Fruit: banana]"; got != want {
+		t.Errorf("expected empty groups to be hidden, got %s", got)
+	}
+	s.Search("zzz")
+	if len(s.Groups()) != 0 {
+		t.Errorf("expected no groups without matches, got %s", groupNames(s.Groups()))
+	}
+}
+
+func TestMulti_Groups(t *testing.T) {
+	m := NewMulti("food", groupTestOptions())
+	actions := m.Actions()
+
+	if err := actions["toggle_group"](base.NewActionContext("toggle_group", "food", map[string]string{"group": "Fruit"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if groups := m.Groups(); !groups[0].Collapsed || groups[1].Collapsed {
+		t.Errorf("expected only Fruit to be collapsed, got %+v", groups)
+	}
+	m.ToggleGroup("Fruit")
+	if m.IsGroupCollapsed("Fruit") {
+		t.Error("expected Fruit to be expanded again")
+	}
+
+	if err := actions["select_group"](base.NewActionContext("select_group", "food", map[string]string{"group": "Vegetables"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.Values(); len(got) != 2 || got[0] != "carrot" || got[1] != "pea" {
+		t.Errorf("expected the enabled vegetables to be selected, got %v", got)
+	}
+	if !m.IsGroupSelected("Vegetables") || m.IsGroupSelected("Fruit") {
+		t.Error("expected only Vegetables to be fully selected")
+	}
+
+	m.ToggleItem("apple")
+	m.SelectGroup("Vegetables")
+	if got := m.Values(); len(got) != 1 || got[0] != "apple" {
+		t.Errorf("expected selecting a selected group to deselect it, got %v", got)
+	}
+
+	m.ClearAll()
+	m.MaxSelections = 2
+	m.ToggleItem("other")
+	m.SelectGroup("Vegetables")
+	if got := m.Values(); len(got) != 2 || got[1] != "carrot" {
+		t.Errorf("expected MaxSelections to limit the group selection, got %v", got)
+	}
+}
+
+func TestGroupedTemplates(t *testing.T) {
+	ts := Templates()
+	tmpl, err := template.New("test").ParseFS(ts.FS, ts.Pattern)
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	for _, styled := range []bool{true, false} {
+		d := New("food", groupTestOptions(), WithStyled(styled), WithOpen(true))
+		s := NewSearchable("food", groupTestOptions(), WithStyled(styled), WithOpen(true))
+		m := NewMulti("food", groupTestOptions(), WithStyled(styled), WithOpen(true))
+		m.ToggleGroup("Vegetables")
+		m.SelectGroup("Fruit")
+
+		for name, v := range map[string]any{"default": d, "searchable": s, "multi": m} {
+			var buf strings.Builder
+			if err := tmpl.ExecuteTemplate(&buf, "lvt:dropdown:"+name+":v1", v); err != nil {
+				t.Fatalf("failed to execute template: %v", err)
+			}
+			html := buf.String()
+			for _, want := range []string{
+				`role="group" aria-labelledby="food-group-0"`,
+				`id="food-group-0"`,
+				`id="food-group-1"`,
+				">Fruit<",
+			} {
+				if !strings.Contains(html, want) {
+					t.Errorf("%s (styled=%v): expected output to contain %q", name, styled, want)
+				}
+			}
+			if strings.Count(html, `role="group"`) != 2 {
+				t.Errorf("%s (styled=%v): expected ungrouped options without a group", name, styled)
+			}
+			if strings.Index(html, `"banana"`) > strings.Index(html, `"other"`) {
+				t.Errorf("%s (styled=%v): expected options in group order", name, styled)
+			}
+			if name != "multi" {
+				continue
+			}
+			for _, want := range []string{
+				`lvt-click="toggle_group_food" lvt-data-group="Vegetables" aria-expanded="false"`,
+				`lvt-click="select_group_food" lvt-data-group="Fruit"`,
+				"Deselect all",
+			} {
+				if !strings.Contains(strings.Join(strings.Fields(html), " "), want) {
+					t.Errorf("multi (styled=%v): expected output to contain %q", styled, want)
+				}
+			}
+			if strings.Contains(html, `lvt-data-value="carrot"`) {
+				t.Errorf("multi (styled=%v): expected collapsed group options to be hidden", styled)
+			}
+		}
+	}
+}
+
+func TestSearchable_HighlightTemplate(t *testing.T) {
+	ts := Templates()
+	tmpl, err := template.New("test").ParseFS(ts.FS, ts.Pattern)
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	options := []Item{
+		{Value: "at", Label: "Österreich"},
+		{Value: "au", Label: "Australia"},
+	}
+
+	for _, styled := range []bool{true, false} {
+		s := NewSearchable("country", options, WithStyled(styled))
+		s.Search("oster")
+
+		var buf strings.Builder
+		if err := tmpl.ExecuteTemplate(&buf, "lvt:dropdown:searchable:v1", s); err != nil {
+			t.Fatalf("failed to execute template: %v", err)
+		}
+
+		html := buf.String()
+		if !strings.Contains(html, ">Öster</mark>reich") {
+			t.Errorf("styled=%v: expected the match to be highlighted", styled)
+		}
+		if strings.Contains(html, "Australia") {
+			t.Errorf("styled=%v: expected non-matching options not to render", styled)
+		}
+	}
+}
+
+func sourceTestItems(n int) []Item {
+	items := make([]Item, n)
+	for i := range items {
+		items[i] = Item{Value: itoa(i), Label: "Customer " + itoa(i)}
+	}
+	return items
+}
+
+func TestMemoryOptionSource(t *testing.T) {
+	src := NewMemoryOptionSource(sourceTestItems(25))
+	ctx := context.Background()
+
+	page, err := src.Query(ctx, OptionQuery{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Options) != 10 || page.Options[0].Value != "0" || page.Next != "10" {
+		t.Fatalf("unexpected first page: %d options, next %q", len(page.Options), page.Next)
+	}
+
+	page, err = src.Query(ctx, OptionQuery{Cursor: "20", Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Options) != 5 || page.Next != "" {
+		t.Errorf("expected the last 5 options, got %d, next %q", len(page.Options), page.Next)
+	}
+
+	page, _ = src.Query(ctx, OptionQuery{Search: "customer 2", Limit: 3})
+	if len(page.Options) != 3 || page.Options[0].Value != "2" || page.Next != "3" {
+		t.Errorf("expected ranked matches, got %v, next %q", page.Options, page.Next)
+	}
+
+	if _, err := src.Query(ctx, OptionQuery{Cursor: "x"}); err == nil {
+		t.Error("expected an error for an invalid cursor")
+	}
+
+	items, err := src.Lookup(ctx, []string{"7", "missing", "3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || items[0].Label != "Customer 3" || items[1].Label != "Customer 7" {
+		t.Errorf("unexpected lookup %v", items)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := src.Query(cancelled, OptionQuery{}); err == nil {
+		t.Error("expected an error for a cancelled context")
+	}
+}
+
+func TestSearchable_OptionSource(t *testing.T) {
+	s := NewSearchable("customer", nil)
+	WithOptionSource(NewMemoryOptionSource(sourceTestItems(120)), 0)(s)
+	WithSelectedValue("99")(s)
+
+	if !s.HasOptionSource() || s.PageSize != DefaultPageSize {
+		t.Fatalf("expected an option source with the default page size, got %d", s.PageSize)
+	}
+	if s.Selected == nil || s.Selected.Value != "99" || s.Selected.Label != "" {
+		t.Fatalf("expected an unresolved selection, got %+v", s.Selected)
+	}
+
+	actions := s.Actions()
+	if err := actions["open"](base.NewActionContext("open", "customer", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Options) != 50 || !s.HasMore() || s.Loading {
+		t.Fatalf("expected the first page, got %d options, more %v", len(s.Options), s.HasMore())
+	}
+	if s.Selected.Label != "Customer 99" {
+		t.Errorf("expected the selected label to be looked up, got %q", s.Selected.Label)
+	}
+
+	if err := actions["load_more"](base.NewActionContext("load_more", "customer", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Options) != 100 || s.Options[50].Value != "50" {
+		t.Fatalf("expected the second page appended, got %d options", len(s.Options))
+	}
+	if err := actions["load_more"](base.NewActionContext("load_more", "customer", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Options) != 120 || s.HasMore() {
+		t.Errorf("expected every option loaded, got %d, more %v", len(s.Options), s.HasMore())
+	}
+
+	// Reopening keeps the loaded pages.
+	s.Close()
+	if err := actions["open"](base.NewActionContext("open", "customer", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Options) != 120 {
+		t.Errorf("expected the loaded options to be kept, got %d", len(s.Options))
+	}
+
+	if err := actions["search"](base.NewActionContext("search", "customer", map[string]string{"value": "customer 11"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 11 prefix matches, then "Customer 101" fuzzily.
+	visible := s.VisibleOptions()
+	if len(visible) != 12 || visible[0].Value != "11" || visible[11].Value != "101" || s.HasMore() {
+		t.Errorf("expected the source's matches, got %d from %v", len(visible), visible)
+	}
+
+	if err := actions["clear_search"](base.NewActionContext("clear_search", "customer", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Options) != 50 || !s.HasMore() {
+		t.Errorf("expected the first page again, got %d options", len(s.Options))
+	}
+}
+
+type failingOptionSource struct{}
+
+func (failingOptionSource) Query(ctx context.Context, q OptionQuery) (OptionPage, error) {
+	return OptionPage{}, errors.New("connection refused")
+}
+
+func (failingOptionSource) Lookup(ctx context.Context, values []string) ([]Item, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestSearchable_OptionSourceError(t *testing.T) {
+	s := NewSearchable("customer", nil)
+	WithOptionSource(failingOptionSource{}, 10)(s)
+
+	err := s.Load(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `"customer"`) || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected a wrapped error, got %v", err)
+	}
+	if s.Loading {
+		t.Error("expected Loading to be cleared after an error")
+	}
+
+	// Without a source, loading does nothing.
+	plain := NewSearchable("plain", []Item{{Value: "a", Label: "Alpha"}})
+	if err := plain.Load(context.Background()); err != nil || plain.HasMore() {
+		t.Errorf("unexpected load without a source: %v", err)
+	}
+}
+
+func TestSearchable_OptionSourceTemplate(t *testing.T) {
+	ts := Templates()
+	tmpl, err := template.New("test").ParseFS(ts.FS, ts.Pattern)
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	for _, styled := range []bool{true, false} {
+		s := NewSearchable("customer", nil, WithStyled(styled), WithOpen(true))
+		WithOptionSource(NewMemoryOptionSource(sourceTestItems(30)), 10)(s)
+		WithSelectedValue("25")(s)
+		if err := s.Load(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var buf strings.Builder
+		if err := tmpl.ExecuteTemplate(&buf, "lvt:dropdown:searchable:v1", s); err != nil {
+			t.Fatalf("failed to execute template: %v", err)
+		}
+		html := buf.String()
+		for _, want := range []string{`lvt-click="load_more_customer"`, `value="Customer 25"`, `lvt-data-value="9"`} {
+			if !strings.Contains(html, want) {
+				t.Errorf("styled=%v: expected output to contain %q", styled, want)
+			}
+		}
+
+		s.Options = nil
+		s.Loading = true
+		buf.Reset()
+		if err := tmpl.ExecuteTemplate(&buf, "lvt:dropdown:searchable:v1", s); err != nil {
+			t.Fatalf("failed to execute template: %v", err)
+		}
+		html = buf.String()
+		if !strings.Contains(html, "Loading...") || !strings.Contains(html, `aria-busy="true"`) || strings.Contains(html, "No results found") {
+			t.Errorf("styled=%v: expected a loading state", styled)
+		}
+	}
+}
+
+// createTag is a WithOnCreate hook that normalises tags to lower case and
+// rejects short ones.
+func createTag(query string) (Item, error) {
+	if len(query) < 3 {
+		return Item{}, errors.New("tags need at least 3 characters")
+	}
+	value := []byte(query)
+	for i, c := range value {
+		if c >= 'A' && c <= 'Z' {
+			value[i] = c + 'a' - 'A'
+		}
+	}
+	return Item{Value: string(value), Label: query}, nil
+}
+
+func TestSearchable_Create(t *testing.T) {
+	s := NewSearchable("tag", []Item{{Value: "go", Label: "Go"}, {Value: "rust", Label: "Rust"}},
+		WithOnCreate(createTag))
+
+	if !s.Creatable() {
+		t.Fatal("expected a creatable dropdown")
+	}
+	s.Search("GO")
+	if s.CanCreate() {
+		t.Error("expected no create entry for an exact match")
+	}
+	s.Search("  ")
+	if s.CanCreate() {
+		t.Error("expected no create entry for a blank query")
+	}
+
+	s.Search("ts")
+	if !s.CanCreate() {
+		t.Fatal("expected a create entry")
+	}
+	if err := s.Actions()["create"](base.NewActionContext("create", "tag", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.CreateError != "tags need at least 3 characters" || len(s.Options) != 2 || s.Selected != nil {
+		t.Errorf("expected the query to be rejected, got %q", s.CreateError)
+	}
+
+	s.Search(" TypeScript ")
+	if s.CreateError != "" {
+		t.Error("expected a new search to clear the error")
+	}
+	if err := s.Actions()["create"](base.NewActionContext("create", "tag", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Options) != 3 || s.Options[2] != (Item{Value: "typescript", Label: "TypeScript"}) {
+		t.Fatalf("expected the created option to be added, got %v", s.Options)
+	}
+	if s.Value() != "typescript" || s.Open || s.Query != "" {
+		t.Errorf("expected the created option to be selected, got %q", s.Value())
+	}
+
+	// A hook normalising to an existing value selects that option.
+	s.Search("RUST!")
+	s.onCreate = func(query string) (Item, error) { return Item{Value: "rust"}, nil }
+	if err := s.Create(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.Options) != 3 || s.Value() != "rust" || s.Selected.Label != "Rust" {
+		t.Errorf("expected the existing option to be selected, got %v", s.Selected)
+	}
+
+	plain := NewSearchable("plain", nil)
+	plain.Search("anything")
+	if plain.Creatable() || plain.CanCreate() || plain.Create() != nil {
+		t.Error("expected no creation without WithOnCreate")
+	}
+}
+
+func TestMulti_Create(t *testing.T) {
+	m := NewMulti("tags", []Item{{Value: "go", Label: "Go"}}, WithOnCreate(createTag))
+	WithMaxSelections(3)(m)
+	actions := m.Actions()
+
+	for _, name := range []string{"Python", "Elixir"} {
+		if err := actions["search"](base.NewActionContext("search", "tags", map[string]string{"value": name})); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := actions["create"](base.NewActionContext("create", "tags", nil)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(m.Options) != 3 || len(m.SelectedItems) != 2 || m.Query != "" {
+		t.Fatalf("expected two created and selected options, got %v", m.SelectedItems)
+	}
+
+	// Enter in the search input sends the value with the action.
+	if err := actions["create"](base.NewActionContext("create", "tags", map[string]string{"value": "ab"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.CreateError == "" || m.Query != "ab" {
+		t.Errorf("expected the short tag to be rejected, got %q", m.CreateError)
+	}
+
+	m.Search("py")
+	if !m.CanCreate() {
+		t.Error("expected a create entry for a partial match")
+	}
+	if got := m.VisibleOptions(); len(got) != 1 || got[0].Value != "python" {
+		t.Errorf("expected the search to filter options, got %v", got)
+	}
+	m.Search("")
+	if len(m.VisibleOptions()) != 3 {
+		t.Error("expected every option without a query")
+	}
+
+	if err := actions["create"](base.NewActionContext("create", "tags", map[string]string{"value": "Haskell"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.Search("Zig")
+	if m.CanCreate() || m.Create() != nil || len(m.Options) != 4 {
+		t.Errorf("expected no creation at MaxSelections, got %d options", len(m.Options))
+	}
+}
+
+func TestCreateTemplates(t *testing.T) {
+	ts := Templates()
+	tmpl, err := template.New("test").ParseFS(ts.FS, ts.Pattern)
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	for _, styled := range []bool{true, false} {
+		s := NewSearchable("tag", []Item{{Value: "go", Label: "Go"}}, WithOnCreate(createTag), WithStyled(styled))
+		s.Search("Zig")
+		s.CreateError = "tags need at least 3 characters"
+
+		var buf strings.Builder
+		if err := tmpl.ExecuteTemplate(&buf, "lvt:dropdown:searchable:v1", s); err != nil {
+			t.Fatalf("failed to execute template: %v", err)
+		}
+		html := buf.String()
+		for _, want := range []string{`lvt-click="create_tag"`, "Create “Zig”", `role="alert"`, "at least 3 characters"} {
+			if !strings.Contains(html, want) {
+				t.Errorf("searchable styled=%v: expected output to contain %q", styled, want)
+			}
+		}
+		if strings.Contains(html, "No results found") {
+			t.Errorf("searchable styled=%v: expected the create entry instead of no results", styled)
+		}
+
+		m := NewMulti("tags", []Item{{Value: "go", Label: "Go"}}, WithOnCreate(createTag), WithStyled(styled), WithOpen(true))
+		m.Search("Zig")
+
+		buf.Reset()
+		if err := tmpl.ExecuteTemplate(&buf, "lvt:dropdown:multi:v1", m); err != nil {
+			t.Fatalf("failed to execute template: %v", err)
+		}
+		html = buf.String()
+		for _, want := range []string{`lvt-input="search_tags"`, `value="Zig"`, `lvt-keydown="keydown_tags"`, `lvt-click="create_tags"`, "Create “Zig”"} {
+			if !strings.Contains(html, want) {
+				t.Errorf("multi styled=%v: expected output to contain %q", styled, want)
+			}
+		}
+		if strings.Contains(html, `lvt-data-value="go"`) {
+			t.Errorf("multi styled=%v: expected options not matching the query to be hidden", styled)
+		}
+
+		plain := NewMulti("plain", []Item{{Value: "go", Label: "Go"}}, WithStyled(styled), WithOpen(true))
+		buf.Reset()
+		if err := tmpl.ExecuteTemplate(&buf, "lvt:dropdown:multi:v1", plain); err != nil {
+			t.Fatalf("failed to execute template: %v", err)
+		}
+		if strings.Contains(buf.String(), "search_plain") {
+			t.Errorf("multi styled=%v: expected no search input without WithOnCreate", styled)
+		}
+	}
+}
+
+func keyboardTestOptions() []Item {
+	return []Item{
+		{Value: "ar", Label: "Argentina"},
+		{Value: "at", Label: "Austria", Disabled: true},
+		{Value: "au", Label: "Australia"},
+		{Value: "be", Label: "Belgium"},
+		{Value: "bo", Label: "Bolivia"},
+		{Value: "br", Label: "Brazil"},
+		{Value: "ca", Label: "Canada", Disabled: true},
+	}
+}
+
+func TestDropdown_KeyDown(t *testing.T) {
+	d := New("country", keyboardTestOptions(), WithSelected("be"))
+	keydown := d.Actions()["keydown"]
+	press := func(key string) {
+		t.Helper()
+		if err := keydown(base.NewActionContext("keydown", "country", map[string]string{"key": key})); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	press("ArrowDown")
+	if !d.Open || d.Highlighted != "be" {
+		t.Fatalf("expected opening to highlight the selection, got %q", d.Highlighted)
+	}
+	if d.ActiveDescendant() != "country-option-be" {
+		t.Errorf("unexpected active descendant %q", d.ActiveDescendant())
+	}
+
+	steps := []struct {
+		key, want string
+	}{
+		{"ArrowDown", "bo"},
+		{"ArrowDown", "br"},
+		{"ArrowDown", "ar"}, // skips the disabled last option and wraps
+		{"ArrowDown", "au"}, // skips a disabled option
+		{"ArrowUp", "ar"},
+		{"ArrowUp", "br"},
+		{"Home", "ar"},
+		{"End", "br"},
+		{"PageUp", "ar"},
+		{"PageDown", "br"},
+	}
+	for _, step := range steps {
+		press(step.key)
+		if d.Highlighted != step.want {
+			t.Fatalf("%s: expected %q highlighted, got %q", step.key, step.want, d.Highlighted)
+		}
+	}
+
+	press("Enter")
+	if d.Value() != "br" || d.Open {
+		t.Errorf("expected Enter to select and close, got %q", d.Value())
+	}
+
+	press(" ")
+	if !d.Open || d.Highlighted != "br" {
+		t.Errorf("expected Space to open the menu, got %q", d.Highlighted)
+	}
+	press("Escape")
+	if d.Open || d.Highlighted != "" || d.ActiveDescendant() != "" {
+		t.Error("expected Escape to close the menu and clear the highlight")
+	}
+
+	// Without a selection, ArrowUp opens at the last enabled option.
+	d.Clear()
+	press("ArrowUp")
+	if d.Highlighted != "br" {
+		t.Errorf("expected the last enabled option, got %q", d.Highlighted)
+	}
+}
+
+func TestDropdown_TypeAhead(t *testing.T) {
+	options := append(keyboardTestOptions(), Item{Value: "ch", Label: "Česko"})
+	d := New("country", options)
+	now := time.Now()
+
+	type step struct {
+		key, want string
+		pause     bool
+	}
+	for _, st := range []step{
+		{key: "b", want: "be"},
+		{key: "r", want: "br"}, // "br" within the timeout
+		{key: "a", want: "ar", pause: true},
+		{key: "a", want: "au"}, // repeating a letter cycles, skipping Austria
+		{key: "a", want: "ar"},
+		{key: "c", want: "ch", pause: true}, // diacritics are ignored; Canada is disabled
+		{key: "z", want: "ch", pause: true},
+	} {
+		if st.pause {
+			now = now.Add(2 * typeAheadTimeout)
+		} else {
+			now = now.Add(typeAheadTimeout / 2)
+		}
+		if !d.typeAhead(d.navigableOptions(), st.key, now) {
+			t.Fatalf("expected %q to be handled", st.key)
+		}
+		if d.Highlighted != st.want {
+			t.Fatalf("typing %q: expected %q highlighted, got %q", st.key, st.want, d.Highlighted)
+		}
+	}
+
+	if d.typeAhead(d.navigableOptions(), "Shift", now) {
+		t.Error("expected non-printable keys to be ignored")
+	}
+
+	d.Close()
+	d.KeyDown("b")
+	if !d.Open || d.Highlighted == "" {
+		t.Error("expected type-ahead to open the menu")
+	}
+}
+
+func TestSearchable_KeyDown(t *testing.T) {
+	options := make([]Item, 100)
+	for i := range options {
+		options[i] = Item{Value: itoa(i), Label: "Option " + itoa(i), Disabled: i == 1}
+	}
+	s := NewSearchable("big", options)
+	WithVirtualOptions(10, 30)(s)
+	keydown := s.Actions()["keydown"]
+	press := func(key string) {
+		t.Helper()
+		if err := keydown(base.NewActionContext("keydown", "big", map[string]string{"key": key})); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	press("ArrowDown")
+	press("ArrowDown")
+	if !s.Open || s.Highlighted != "2" {
+		t.Fatalf("expected the disabled option to be skipped, got %q", s.Highlighted)
+	}
+	press("End")
+	if s.Highlighted != "99" || s.Window.Offset != 90 {
+		t.Errorf("expected the last option scrolled into view, got %q at %d", s.Highlighted, s.Window.Offset)
+	}
+
+	s.Search("Option 4")
+	if s.Highlighted != "" {
+		t.Error("expected a new search to clear the highlight")
+	}
+	press("ArrowDown")
+	press("PageDown")
+	if s.Highlighted != "49" {
+		t.Errorf("expected PageDown to stop at the last match, got %q", s.Highlighted)
+	}
+	press("Enter")
+	if s.Value() != "49" || s.Open {
+		t.Errorf("expected Enter to select the highlighted option, got %q", s.Value())
+	}
+
+	// Typing goes to the search input.
+	before := s.Highlighted
+	press("x")
+	if s.Highlighted != before {
+		t.Errorf("expected no type-ahead, got %q", s.Highlighted)
+	}
+
+	c := NewSearchable("tag", []Item{{Value: "go", Label: "Go"}}, WithOnCreate(createTag))
+	c.Search("Zig")
+	c.KeyDown("Enter")
+	if c.Value() != "zig" {
+		t.Errorf("expected Enter without a highlight to create the query, got %q", c.Value())
+	}
+}
+
+func TestMulti_KeyDown(t *testing.T) {
+	m := NewMulti("regions", groupTestOptions())
+	m.ToggleGroup("Europe")
+	keydown := m.Actions()["keydown"]
+	press := func(key string) {
+		t.Helper()
+		if err := keydown(base.NewActionContext("keydown", "regions", map[string]string{"key": key})); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var visible []string
+	for _, opt := range m.navigableOptions() {
+		if opt.Group == "Europe" {
+			t.Fatal("expected collapsed groups to be skipped")
+		}
+		visible = append(visible, opt.Value)
+	}
+
+	press("Home")
+	first := m.Highlighted
+	if !m.Open || first == "" || first != visible[0] {
+		t.Fatalf("expected the first option highlighted, got %q", first)
+	}
+	press("Enter")
+	press("ArrowDown")
+	press(" ")
+	if !m.Open || len(m.SelectedItems) != 2 || !m.IsSelected(first) {
+		t.Errorf("expected Enter and Space to toggle options and keep the menu open, got %v", m.Values())
+	}
+	press(" ")
+	if len(m.SelectedItems) != 1 {
+		t.Errorf("expected Space to deselect, got %v", m.Values())
+	}
+
+	c := NewMulti("tags", []Item{{Value: "go", Label: "Go"}, {Value: "gleam", Label: "Gleam"}}, WithOnCreate(createTag), WithOpen(true))
+	ckeydown := c.Actions()["keydown"]
+	if err := ckeydown(base.NewActionContext("keydown", "tags", map[string]string{"key": "ArrowDown", "value": "gl"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Query != "gl" || c.Highlighted != "gleam" {
+		t.Errorf("expected the input value to filter before navigating, got %q on %q", c.Highlighted, c.Query)
+	}
+	if err := ckeydown(base.NewActionContext("keydown", "tags", map[string]string{"key": "Enter", "value": "gl"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.IsSelected("gleam") || len(c.Options) != 2 {
+		t.Errorf("expected Enter to toggle the highlighted option, got %v", c.Values())
+	}
+	if err := ckeydown(base.NewActionContext("keydown", "tags", map[string]string{"key": "Enter", "value": "Zig"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.IsSelected("zig") {
+		t.Errorf("expected Enter without a highlight to create the query, got %v", c.Values())
+	}
+	c.Highlighted = ""
+	c.KeyDown("q")
+	if c.Highlighted != "" {
+		t.Error("expected no type-ahead in a creatable dropdown")
+	}
+}
+
+func TestOptionID(t *testing.T) {
+	d := New("country", nil)
+	for value, want := range map[string]string{
+		"us":       "country-option-us",
+		"new-york": "country-option-new-york",
+		"a b/c":    "country-option-a_20b_2fc",
+		"é":        "country-option-_c3_a9",
+	} {
+		if got := d.OptionID(value); got != want {
+			t.Errorf("OptionID(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestKeyboardTemplates(t *testing.T) {
+	ts := Templates()
+	tmpl, err := template.New("test").ParseFS(ts.FS, ts.Pattern)
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	for _, styled := range []bool{true, false} {
+		d := New("country", keyboardTestOptions(), WithStyled(styled))
+		d.KeyDown("ArrowDown")
+		s := NewSearchable("city", keyboardTestOptions(), WithStyled(styled))
+		s.KeyDown("ArrowDown")
+		m := NewMulti("tags", keyboardTestOptions(), WithStyled(styled))
+		m.KeyDown("ArrowDown")
+
+		for _, tc := range []struct {
+			name string
+			data any
+			id   string
+		}{
+			{"lvt:dropdown:default:v1", d, "country"},
+			{"lvt:dropdown:searchable:v1", s, "city"},
+			{"lvt:dropdown:multi:v1", m, "tags"},
+		} {
+			var buf strings.Builder
+			if err := tmpl.ExecuteTemplate(&buf, tc.name, tc.data); err != nil {
+				t.Fatalf("failed to execute %s: %v", tc.name, err)
+			}
+			html := buf.String()
+			for _, want := range []string{
+				`lvt-keydown="keydown_` + tc.id + `"`,
+				`aria-activedescendant="` + tc.id + `-option-ar"`,
+				`aria-controls="` + tc.id + `-listbox"`,
+				`id="` + tc.id + `-listbox"`,
+				`id="` + tc.id + `-option-ar"`,
+				`id="` + tc.id + `-option-br"`,
+			} {
+				if !strings.Contains(html, want) {
+					t.Errorf("%s styled=%v: expected output to contain %q", tc.name, styled, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package dropdown
+
+// OptionGroup is a group of options sharing an Item.Group, rendered under a
+// group header.
+type OptionGroup struct {
+	// Name is the Item.Group of the options ("" for ungrouped options, which
+	// are rendered without a header)
+	Name string
+
+	// Options are the group's options, in their original order
+	Options []Item
+
+	// Collapsed hides the group's options (Multi only)
+	Collapsed bool
+}
+
+// HasGroups returns true if any option has a Group.
+func (d *Dropdown) HasGroups() bool {
+	for _, opt := range d.Options {
+		if opt.Group != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Groups returns the options grouped by Item.Group. Groups are ordered by
+// GroupOrder, then by their first option; a dropdown without groups returns
+// a single unnamed group.
+func (d *Dropdown) Groups() []OptionGroup {
+	return groupItems(d.Options, d.GroupOrder)
+}
+
+// Groups returns the visible options grouped by Item.Group (see
+// Dropdown.Groups). Groups with no options matching the search are omitted;
+// with a Window, only the groups of the options in view are returned.
+func (s *Searchable) Groups() []OptionGroup {
+	return groupItems(s.WindowOptions(), s.GroupOrder)
+}
+
+// Groups returns the options matching the query grouped by Item.Group (see
+// Dropdown.Groups), with Collapsed set from CollapsedGroups.
+func (m *Multi) Groups() []OptionGroup {
+	groups := groupItems(m.VisibleOptions(), m.GroupOrder)
+	for i := range groups {
+		groups[i].Collapsed = m.IsGroupCollapsed(groups[i].Name)
+	}
+	return groups
+}
+
+// ToggleGroup collapses or expands a group.
+func (m *Multi) ToggleGroup(name string) {
+	if m.CollapsedGroups == nil {
+		m.CollapsedGroups = make(map[string]bool)
+	}
+	if m.CollapsedGroups[name] {
+		delete(m.CollapsedGroups, name)
+	} else {
+		m.CollapsedGroups[name] = true
+	}
+}
+
+// IsGroupCollapsed checks if a group is collapsed.
+func (m *Multi) IsGroupCollapsed(name string) bool {
+	return m.CollapsedGroups[name]
+}
+
+// IsGroupSelected checks if every enabled option in a group is selected.
+func (m *Multi) IsGroupSelected(name string) bool {
+	found := false
+	for _, opt := range m.Options {
+		if opt.Group != name || opt.Disabled {
+			continue
+		}
+		if !m.IsSelected(opt.Value) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// SelectGroup selects every enabled option in a group, up to MaxSelections,
+// or deselects them if they are all selected.
+func (m *Multi) SelectGroup(name string) {
+	if m.IsGroupSelected(name) {
+		selected := m.SelectedItems[:0]
+		for _, item := range m.SelectedItems {
+			if item.Group != name {
+				selected = append(selected, item)
+			}
+		}
+		m.SelectedItems = selected
+		return
+	}
+
+	for _, opt := range m.Options {
+		if opt.Group != name || opt.Disabled || m.IsSelected(opt.Value) {
+			continue
+		}
+		if m.MaxSelections > 0 && len(m.SelectedItems) >= m.MaxSelections {
+			return
+		}
+		m.SelectedItems = append(m.SelectedItems, opt)
+	}
+}
+
+// groupedOptions returns items in group order (see Dropdown.Groups).
+func groupedOptions(items []Item, order []string) []Item {
+	groups := groupItems(items, order)
+	if len(groups) <= 1 {
+		return items
+	}
+	ordered := make([]Item, 0, len(items))
+	for _, group := range groups {
+		ordered = append(ordered, group.Options...)
+	}
+	return ordered
+}
+
+// groupItems groups items by Item.Group, ordered by order and then by first
+// appearance, omitting empty groups.
+func groupItems(items []Item, order []string) []OptionGroup {
+	var groups []OptionGroup
+	index := make(map[string]int)
+	for _, name := range order {
+		if _, ok := index[name]; !ok {
+			index[name] = len(groups)
+			groups = append(groups, OptionGroup{Name: name})
+		}
+	}
+	for _, item := range items {
+		i, ok := index[item.Group]
+		if !ok {
+			i = len(groups)
+			index[item.Group] = i
+			groups = append(groups, OptionGroup{Name: item.Group})
+		}
+		groups[i].Options = append(groups[i].Options, item)
+	}
+
+	nonEmpty := groups[:0]
+	for _, group := range groups {
+		if len(group.Options) > 0 {
+			nonEmpty = append(nonEmpty, group)
+		}
+	}
+	return nonEmpty
+}
@@ -0,0 +1,340 @@
+package dropdown
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/livetemplate/components/base"
+)
+
+// pageStep is the number of options PageUp and PageDown move the highlight.
+const pageStep = 10
+
+// typeAheadTimeout is how long letters typed in a row are matched together
+// for type-ahead.
+const typeAheadTimeout = time.Second
+
+// IsHighlighted checks if the option with the given value is highlighted.
+func (d *Dropdown) IsHighlighted(value string) bool {
+	return d.Highlighted != "" && d.Highlighted == value
+}
+
+// OptionID returns the element ID of the option with the given value, for
+// aria-activedescendant.
+func (d *Dropdown) OptionID(value string) string {
+	const hex = "0123456789abcdef"
+	id := []byte(d.ID() + "-option-")
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' {
+			id = append(id, c)
+			continue
+		}
+		id = append(id, '_', hex[c>>4], hex[c&0xf])
+	}
+	return string(id)
+}
+
+// ActiveDescendant returns the element ID of the highlighted option while the
+// menu is open, or "".
+func (d *Dropdown) ActiveDescendant() string {
+	if !d.Open || d.Highlighted == "" {
+		return ""
+	}
+	return d.OptionID(d.Highlighted)
+}
+
+// navigableOptions returns the options in display order.
+func (d *Dropdown) navigableOptions() []Item {
+	return groupedOptions(d.Options, d.GroupOrder)
+}
+
+// HighlightNext moves the highlight to the next enabled option, wrapping to
+// the first.
+func (d *Dropdown) HighlightNext() {
+	d.moveHighlight(d.navigableOptions(), "ArrowDown")
+}
+
+// HighlightPrevious moves the highlight to the previous enabled option,
+// wrapping to the last.
+func (d *Dropdown) HighlightPrevious() {
+	d.moveHighlight(d.navigableOptions(), "ArrowUp")
+}
+
+// KeyDown handles a key pressed on the dropdown button:
+//   - ArrowDown, ArrowUp, Home, End, PageDown and PageUp move the highlight
+//     over enabled options, opening the menu if it is closed
+//   - Enter and Space select the highlighted option, or open the menu
+//   - Escape closes the menu
+//   - other printable keys jump to the next option whose label starts with
+//     the letters typed in a row (type-ahead)
+func (d *Dropdown) KeyDown(key string) {
+	items := d.navigableOptions()
+	switch {
+	case key == "Escape":
+		d.Close()
+	case !d.Open && (isNavigationKey(key) || key == "Enter" || key == " "):
+		d.openHighlighted(items, key)
+	case key == "Enter" || key == " ":
+		if d.Highlighted != "" {
+			d.Select(d.Highlighted)
+		}
+	case isNavigationKey(key):
+		d.moveHighlight(items, key)
+	default:
+		if d.typeAhead(items, key, time.Now()) {
+			d.Open = true
+		}
+	}
+}
+
+// navigableOptions returns the visible options in display order.
+func (s *Searchable) navigableOptions() []Item {
+	return groupedOptions(s.VisibleOptions(), s.GroupOrder)
+}
+
+// HighlightNext moves the highlight to the next enabled visible option,
+// wrapping to the first.
+func (s *Searchable) HighlightNext() {
+	items := s.navigableOptions()
+	s.moveHighlight(items, "ArrowDown")
+	s.scrollToHighlighted(items)
+}
+
+// HighlightPrevious moves the highlight to the previous enabled visible
+// option, wrapping to the last.
+func (s *Searchable) HighlightPrevious() {
+	items := s.navigableOptions()
+	s.moveHighlight(items, "ArrowUp")
+	s.scrollToHighlighted(items)
+}
+
+// KeyDown handles a key pressed in the search input. Navigation keys move
+// the highlight as in Dropdown.KeyDown, keeping it in the Window; Enter
+// selects the highlighted option, or creates one from the query (see
+// WithOnCreate); Escape closes the menu. Other keys are typed into the
+// search, so there is no type-ahead.
+func (s *Searchable) KeyDown(key string) {
+	items := s.navigableOptions()
+	switch {
+	case key == "Escape":
+		s.Close()
+	case isNavigationKey(key):
+		if !s.Open {
+			s.openHighlighted(items, key)
+		} else {
+			s.moveHighlight(items, key)
+		}
+		s.scrollToHighlighted(items)
+	case key == "Enter":
+		if s.Highlighted != "" && s.Open {
+			s.Select(s.Highlighted)
+		} else if s.CanCreate() {
+			s.Create()
+		}
+	}
+}
+
+// scrollToHighlighted keeps the highlighted option in the Window.
+func (s *Searchable) scrollToHighlighted(items []Item) {
+	if s.Window == nil {
+		return
+	}
+	if i := indexOfValue(items, s.Highlighted); i >= 0 {
+		s.Window.SetTotal(len(items))
+		s.Window.ScrollIntoView(i)
+	}
+}
+
+// navigableOptions returns the options matching the query in display order,
+// without the options of collapsed groups.
+func (m *Multi) navigableOptions() []Item {
+	var items []Item
+	for _, group := range m.Groups() {
+		if !group.Collapsed {
+			items = append(items, group.Options...)
+		}
+	}
+	return items
+}
+
+// HighlightNext moves the highlight to the next enabled option, wrapping to
+// the first.
+func (m *Multi) HighlightNext() {
+	m.moveHighlight(m.navigableOptions(), "ArrowDown")
+}
+
+// HighlightPrevious moves the highlight to the previous enabled option,
+// wrapping to the last.
+func (m *Multi) HighlightPrevious() {
+	m.moveHighlight(m.navigableOptions(), "ArrowUp")
+}
+
+// KeyDown handles a key pressed on the dropdown button or search input, as
+// Dropdown.KeyDown does, except that Enter and Space toggle the highlighted
+// option and keep the menu open. Without a highlighted option, Enter creates
+// one from the query (see WithOnCreate). Creatable dropdowns type into their
+// search input instead, so they have no type-ahead.
+func (m *Multi) KeyDown(key string) {
+	items := m.navigableOptions()
+	switch {
+	case key == "Escape":
+		m.Close()
+	case !m.Open && (isNavigationKey(key) || key == "Enter" || key == " "):
+		m.openHighlighted(items, key)
+	case key == "Enter" || key == " ":
+		if m.Highlighted != "" {
+			m.ToggleItem(m.Highlighted)
+		} else if key == "Enter" {
+			m.Create()
+		}
+	case isNavigationKey(key):
+		m.moveHighlight(items, key)
+	case !m.Creatable():
+		if m.typeAhead(items, key, time.Now()) {
+			m.Open = true
+		}
+	}
+}
+
+// openHighlighted opens the menu, highlighting the selected option or else
+// the first enabled option (the last for ArrowUp and End).
+func (d *Dropdown) openHighlighted(items []Item, key string) {
+	d.Open = true
+	if i := indexOfValue(items, d.Value()); i >= 0 && !items[i].Disabled {
+		d.Highlighted = items[i].Value
+		return
+	}
+	d.Highlighted = ""
+	if key == "ArrowUp" || key == "End" {
+		d.moveHighlight(items, "End")
+	} else {
+		d.moveHighlight(items, "Home")
+	}
+}
+
+// isNavigationKey returns true for the keys that move the highlight.
+func isNavigationKey(key string) bool {
+	switch key {
+	case "ArrowDown", "ArrowUp", "Home", "End", "PageDown", "PageUp":
+		return true
+	}
+	return false
+}
+
+// moveHighlight moves the highlight over the enabled items for a navigation
+// key. Arrow keys wrap around; the other keys stop at the first and last
+// enabled items.
+func (d *Dropdown) moveHighlight(items []Item, key string) {
+	current := indexOfValue(items, d.Highlighted)
+	var i int
+	switch key {
+	case "ArrowDown":
+		i = nextEnabled(items, current+1, 1, true)
+	case "ArrowUp":
+		if current < 0 {
+			current = len(items)
+		}
+		i = nextEnabled(items, current-1, -1, true)
+	case "Home":
+		i = nextEnabled(items, 0, 1, false)
+	case "End":
+		i = nextEnabled(items, len(items)-1, -1, false)
+	case "PageDown":
+		i = nextEnabled(items, min(current+pageStep, len(items)-1), 1, false)
+		if i < 0 {
+			i = nextEnabled(items, len(items)-1, -1, false)
+		}
+	case "PageUp":
+		if current < 0 {
+			current = len(items)
+		}
+		i = nextEnabled(items, max(current-pageStep, 0), -1, false)
+		if i < 0 {
+			i = nextEnabled(items, 0, 1, false)
+		}
+	default:
+		return
+	}
+	if i >= 0 {
+		d.Highlighted = items[i].Value
+	}
+}
+
+// typeAhead highlights the next enabled item whose label starts with the
+// letters typed within typeAheadTimeout of each other, ignoring case and
+// diacritics. Typing the same letter repeatedly cycles through the items
+// starting with it. It returns false if key is not a printable character.
+func (d *Dropdown) typeAhead(items []Item, key string, now time.Time) bool {
+	if utf8.RuneCountInString(key) != 1 || len(items) == 0 {
+		return false
+	}
+	if now.Sub(d.typedAt) > typeAheadTimeout {
+		d.typed = ""
+	}
+	d.typed += key
+	d.typedAt = now
+
+	prefix := base.Fold(d.typed)
+	start := indexOfValue(items, d.Highlighted)
+	if repeated(prefix) {
+		// Cycle through the items starting with the letter.
+		prefix = prefix[:utf8.RuneLen([]rune(prefix)[0])]
+		start++
+	} else if start < 0 {
+		start = 0
+	}
+
+	for k := 0; k < len(items); k++ {
+		i := (start + k) % len(items)
+		label := base.Fold(items[i].Label)
+		if !items[i].Disabled && len(label) >= len(prefix) && label[:len(prefix)] == prefix {
+			d.Highlighted = items[i].Value
+			return true
+		}
+	}
+	return true
+}
+
+// repeated returns true if s is one letter, typed one or more times.
+func repeated(s string) bool {
+	var first rune
+	for i, r := range s {
+		if i == 0 {
+			first = r
+		} else if r != first {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// nextEnabled returns the index of the first enabled item from index i in
+// direction dir (1 or -1), wrapping around if wrap is set, or -1.
+func nextEnabled(items []Item, i, dir int, wrap bool) int {
+	for k := 0; k < len(items); k++ {
+		if wrap {
+			i = (i%len(items) + len(items)) % len(items)
+		} else if i < 0 || i >= len(items) {
+			return -1
+		}
+		if !items[i].Disabled {
+			return i
+		}
+		i += dir
+	}
+	return -1
+}
+
+// indexOfValue returns the index of the item with the given value, or -1.
+func indexOfValue(items []Item, value string) int {
+	if value == "" {
+		return -1
+	}
+	for i, item := range items {
+		if item.Value == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -1,5 +1,9 @@
 package dropdown
 
+import (
+	"github.com/livetemplate/components/base"
+)
+
 // Option is a functional option for configuring dropdowns.
 type Option func(*Dropdown)
 
@@ -36,6 +40,35 @@ func WithOpen(open bool) Option {
 	}
 }
 
+// WithGroupOrder sets the display order of option groups by Item.Group name.
+// Groups not listed follow in order of their first option.
+func WithGroupOrder(groups ...string) Option {
+	return func(d *Dropdown) {
+		d.GroupOrder = groups
+	}
+}
+
+// WithOnCreate lets Searchable and Multi dropdowns create options: when the
+// search query matches no option exactly, a "Create" entry passes the
+// trimmed query to fn. fn can validate and normalise it into a new Item,
+// which is added to Options and selected, or reject it with an error, whose
+// message is shown in CreateError.
+//
+// Example:
+//
+//	dropdown.WithOnCreate(func(query string) (dropdown.Item, error) {
+//	    tag, err := tags.Create(ctx, query)
+//	    if err != nil {
+//	        return dropdown.Item{}, err
+//	    }
+//	    return dropdown.Item{Value: tag.ID, Label: tag.Name}, nil
+//	})
+func WithOnCreate(fn func(query string) (Item, error)) Option {
+	return func(d *Dropdown) {
+		d.onCreate = fn
+	}
+}
+
 // WithStyled enables Tailwind CSS styling for the component.
 // When false, renders semantic HTML without styling classes.
 func WithStyled(styled bool) Option {
@@ -61,6 +94,42 @@ func WithQuery(query string) SearchableOption {
 	}
 }
 
+// WithVirtualOptions renders only the options in view, size options of
+// itemHeight pixels, for lists of thousands of options. The list sends
+// "scroll" actions as it scrolls.
+func WithVirtualOptions(size, itemHeight int) SearchableOption {
+	return func(s *Searchable) {
+		s.Window = base.NewWindow(size, itemHeight)
+	}
+}
+
+// WithOptionSource fetches options from source, pageSize options at a time
+// (DefaultPageSize if 0), instead of filtering Options. Call Load to fetch
+// the first page.
+func WithOptionSource(source OptionSource, pageSize int) SearchableOption {
+	return func(s *Searchable) {
+		if pageSize <= 0 {
+			pageSize = DefaultPageSize
+		}
+		s.source = source
+		s.PageSize = pageSize
+	}
+}
+
+// WithSelectedValue pre-selects an item by value, even if it is not among
+// the options; with an OptionSource, Load looks up its label.
+func WithSelectedValue(value string) SearchableOption {
+	return func(s *Searchable) {
+		for i := range s.Options {
+			if s.Options[i].Value == value {
+				s.Selected = &s.Options[i]
+				return
+			}
+		}
+		s.Selected = &Item{Value: value}
+	}
+}
+
 // MultiOption is a functional option for configuring multi-select dropdowns.
 type MultiOption func(*Multi)
 
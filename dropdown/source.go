@@ -0,0 +1,216 @@
+package dropdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/livetemplate/components/base"
+)
+
+// DefaultPageSize is the number of options a Searchable fetches from an
+// OptionSource per page, unless WithOptionSource sets another.
+const DefaultPageSize = 50
+
+// OptionQuery describes the options a Searchable needs from an OptionSource.
+type OptionQuery struct {
+	// Search is the search text ("" for every option)
+	Search string
+	// Cursor is the Next cursor of the previous page ("" for the first page)
+	Cursor string
+	// Limit is the maximum number of options to return (0 for all)
+	Limit int
+}
+
+// OptionPage is a page of options.
+type OptionPage struct {
+	// Options are the options on the page, best match first
+	Options []Item
+	// Next is the cursor of the following page ("" if this is the last page)
+	Next string
+}
+
+// OptionSource supplies options to a Searchable a page at a time, so option
+// sets too large to preload (all customers, all SKUs) can be searched where
+// they live.
+//
+// Example:
+//
+//	s := dropdown.NewSearchable("customer", nil,
+//	    dropdown.WithPlaceholder("Search customers..."),
+//	)
+//	dropdown.WithOptionSource(customerSource, 25)(s)
+//	dropdown.WithSelectedValue(order.CustomerID)(s)
+//	if err := s.Load(ctx); err != nil {
+//	    return err
+//	}
+type OptionSource interface {
+	// Query returns a page of the options matching q.
+	Query(ctx context.Context, q OptionQuery) (OptionPage, error)
+
+	// Lookup returns the options with the given values, to label selected
+	// values that are not loaded. Unknown values are left out.
+	Lookup(ctx context.Context, values []string) ([]Item, error)
+}
+
+// errInvalidCursor is returned by MemoryOptionSource for a cursor it did not
+// issue.
+var errInvalidCursor = errors.New("dropdown: invalid cursor")
+
+// MemoryOptionSource is an OptionSource over a slice of items, matched and
+// ranked like Searchable.Search. Cursors are offsets into the matches.
+type MemoryOptionSource struct {
+	// Items is the full option set
+	Items []Item
+}
+
+// NewMemoryOptionSource creates an OptionSource over items.
+func NewMemoryOptionSource(items []Item) *MemoryOptionSource {
+	return &MemoryOptionSource{Items: items}
+}
+
+// Query returns a page of the items matching the search.
+func (m *MemoryOptionSource) Query(ctx context.Context, q OptionQuery) (OptionPage, error) {
+	if err := ctx.Err(); err != nil {
+		return OptionPage{}, err
+	}
+
+	start := 0
+	if q.Cursor != "" {
+		n, ok := atoi(q.Cursor)
+		if !ok || n < 0 {
+			return OptionPage{}, errInvalidCursor
+		}
+		start = n
+	}
+
+	ranked := base.Rank(q.Search, m.Items, func(item Item) string { return item.Label })
+	start = min(start, len(ranked))
+	end := len(ranked)
+	if q.Limit > 0 {
+		end = min(start+q.Limit, end)
+	}
+
+	page := OptionPage{Options: make([]Item, 0, end-start)}
+	for _, r := range ranked[start:end] {
+		page.Options = append(page.Options, r.Item)
+	}
+	if end < len(ranked) {
+		page.Next = itoa(end)
+	}
+	return page, nil
+}
+
+// Lookup returns the items with the given values.
+func (m *MemoryOptionSource) Lookup(ctx context.Context, values []string) ([]Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(values))
+	for _, v := range values {
+		wanted[v] = true
+	}
+	var items []Item
+	for _, item := range m.Items {
+		if wanted[item.Value] {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// HasOptionSource returns true if options come from an OptionSource.
+func (s *Searchable) HasOptionSource() bool {
+	return s.source != nil
+}
+
+// HasMore returns true if the OptionSource has more options matching the
+// query than are loaded.
+func (s *Searchable) HasMore() bool {
+	return s.source != nil && s.NextCursor != ""
+}
+
+// Load fetches the first page of options matching the query from the
+// OptionSource into Options, and looks up the label of a selected value that
+// is not on the page. Load does nothing without an OptionSource.
+//
+// The "open", "search" and "clear_search" actions load automatically; call
+// Load after changing the query directly.
+func (s *Searchable) Load(ctx context.Context) error {
+	if s.source == nil {
+		return nil
+	}
+
+	page, err := s.query(ctx, "")
+	if err != nil {
+		return err
+	}
+	s.Options = page.Options
+	s.NextCursor = page.Next
+	s.FilteredOptions = nil
+	s.loaded = true
+	if s.Window != nil {
+		s.Window.Offset = 0
+	}
+	return s.resolveSelected(ctx)
+}
+
+// LoadMore appends the next page of options from the OptionSource to
+// Options. It does nothing if every matching option is loaded.
+func (s *Searchable) LoadMore(ctx context.Context) error {
+	if !s.HasMore() {
+		return nil
+	}
+
+	page, err := s.query(ctx, s.NextCursor)
+	if err != nil {
+		return err
+	}
+	s.Options = append(s.Options, page.Options...)
+	s.NextCursor = page.Next
+	return nil
+}
+
+// query fetches the page of options at cursor, setting Loading while the
+// OptionSource is queried.
+func (s *Searchable) query(ctx context.Context, cursor string) (OptionPage, error) {
+	s.Loading = true
+	defer func() { s.Loading = false }()
+
+	q := OptionQuery{Cursor: cursor, Limit: s.PageSize}
+	if s.searching() {
+		q.Search = s.Query
+	}
+	page, err := s.source.Query(ctx, q)
+	if err != nil {
+		return OptionPage{}, fmt.Errorf("dropdown: query %q: %w", s.ID(), err)
+	}
+	return page, nil
+}
+
+// resolveSelected looks up the selected item in the OptionSource if only its
+// value is known and it is not among the loaded options.
+func (s *Searchable) resolveSelected(ctx context.Context) error {
+	if s.Selected == nil || s.Selected.Label != "" {
+		return nil
+	}
+	for i := range s.Options {
+		if s.Options[i].Value == s.Selected.Value {
+			s.Selected = &s.Options[i]
+			return nil
+		}
+	}
+
+	items, err := s.source.Lookup(ctx, []string{s.Selected.Value})
+	if err != nil {
+		return fmt.Errorf("dropdown: lookup %q: %w", s.ID(), err)
+	}
+	for i := range items {
+		if items[i].Value == s.Selected.Value {
+			s.Selected = &items[i]
+			return nil
+		}
+	}
+	return nil
+}
@@ -1,3 +1,5 @@
 module github.com/livetemplate/components
 
 go 1.25.3
+
+require github.com/mattn/go-sqlite3 v1.14.33
@@ -203,6 +203,17 @@ func (m *Menu) SelectIndex(index int) string {
 	return item.ID
 }
 
+// SelectItem selects a clickable item by ID and closes the menu.
+// Returns the item ID, or empty string if the item is missing or not clickable.
+func (m *Menu) SelectItem(id string) string {
+	for i, item := range m.ClickableItems() {
+		if item.ID == id {
+			return m.SelectIndex(i)
+		}
+	}
+	return ""
+}
+
 // ClickableItems returns items that can be clicked (excludes dividers, headers, disabled).
 func (m *Menu) ClickableItems() []Item {
 	var clickable []Item
@@ -266,6 +277,29 @@ func (m *Menu) SetItemDisabled(id string, disabled bool) {
 	}
 }
 
+// Actions returns the menu's action handlers for the LiveTemplate framework.
+// ContextMenu shares these handlers.
+//
+// Handled actions:
+//   - "toggle" and "close" open and close the menu
+//   - "select" selects the item in lvt-data-item and closes the menu
+func (m *Menu) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle": func(ctx *base.ActionContext) error {
+			m.Toggle()
+			return nil
+		},
+		"close": func(ctx *base.ActionContext) error {
+			m.Close()
+			return nil
+		},
+		"select": func(ctx *base.ActionContext) error {
+			m.SelectItem(ctx.Data("item"))
+			return nil
+		},
+	}
+}
+
 // ContextMenu methods
 
 // ShowAt shows the context menu at the specified position.
@@ -334,6 +368,30 @@ func (nm *NavMenu) GetItem(id string) *Item {
 	return nil
 }
 
+// Actions returns the navigation menu's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "toggle_submenu" toggles the submenu in lvt-data-submenu
+//   - "close_submenu" closes the open submenu
+//   - "select" marks the item in lvt-data-item as active
+func (nm *NavMenu) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle_submenu": func(ctx *base.ActionContext) error {
+			nm.ToggleSubmenu(ctx.Data("submenu"))
+			return nil
+		},
+		"close_submenu": func(ctx *base.ActionContext) error {
+			nm.CloseSubmenu()
+			return nil
+		},
+		"select": func(ctx *base.ActionContext) error {
+			nm.SetActive(ctx.Data("item"))
+			nm.CloseSubmenu()
+			return nil
+		},
+	}
+}
+
 // Helper functions for templates
 
 // IsDivider checks if item is a divider.
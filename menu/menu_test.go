@@ -2,6 +2,8 @@ package menu
 
 import (
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -539,3 +541,66 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Expected Templates() to return a TemplateSet")
 	}
 }
+
+func TestSelectItem(t *testing.T) {
+	items := []Item{
+		{ID: "edit", Label: "Edit"},
+		{ID: "div", Type: ItemTypeDivider},
+		{ID: "delete", Label: "Delete", Disabled: true},
+	}
+	m := New("actions", WithItems(items))
+	m.Open = true
+
+	if got := m.SelectItem("delete"); got != "" {
+		t.Errorf("expected disabled item to be ignored, got %q", got)
+	}
+	if got := m.SelectItem("edit"); got != "edit" {
+		t.Errorf("expected 'edit', got %q", got)
+	}
+	if m.Open {
+		t.Error("expected menu to close after selection")
+	}
+}
+
+func TestActions(t *testing.T) {
+	m := New("actions", WithItems([]Item{{ID: "edit", Label: "Edit"}}))
+	actions := m.Actions()
+
+	if err := actions["toggle"](base.NewActionContext("toggle", "actions", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !m.Open {
+		t.Error("expected menu to be open")
+	}
+
+	ctx := base.NewActionContext("select", "actions", map[string]string{"item": "edit"})
+	if err := actions["select"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Open {
+		t.Error("expected menu to close after select")
+	}
+}
+
+func TestNavMenuActions(t *testing.T) {
+	nm := NewNav("main", WithNavItems([]Item{
+		{ID: "products", Label: "Products", Type: ItemTypeSubmenu, Items: []Item{{ID: "widgets", Label: "Widgets"}}},
+	}))
+	actions := nm.Actions()
+
+	ctx := base.NewActionContext("toggle_submenu", "main", map[string]string{"submenu": "products"})
+	if err := actions["toggle_submenu"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !nm.IsSubmenuOpen("products") {
+		t.Error("expected products submenu to be open")
+	}
+
+	ctx = base.NewActionContext("select", "main", map[string]string{"item": "widgets"})
+	if err := actions["select"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !nm.IsActive("widgets") || nm.OpenSubmenuID != "" {
+		t.Error("expected widgets active and submenu closed")
+	}
+}
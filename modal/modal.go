@@ -104,6 +104,26 @@ func (m *Modal) Toggle() {
 	m.Open = !m.Open
 }
 
+// Actions returns the modal's action handlers for the LiveTemplate framework.
+//
+// Handled actions: "show", "hide" and "toggle".
+func (m *Modal) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"show": func(ctx *base.ActionContext) error {
+			m.Show()
+			return nil
+		},
+		"hide": func(ctx *base.ActionContext) error {
+			m.Hide()
+			return nil
+		},
+		"toggle": func(ctx *base.ActionContext) error {
+			m.Toggle()
+			return nil
+		},
+	}
+}
+
 // HasTitle returns true if modal has a title.
 func (m *Modal) HasTitle() bool {
 	return m.Title != ""
@@ -154,6 +174,12 @@ type ConfirmModal struct {
 
 	// Icon shows an icon in the dialog
 	Icon string
+
+	// onConfirm is called when the user confirms
+	onConfirm func() error
+
+	// onCancel is called when the user cancels
+	onCancel func() error
 }
 
 // NewConfirm creates a confirmation dialog.
@@ -190,6 +216,49 @@ func (c *ConfirmModal) Hide() {
 	c.Open = false
 }
 
+// Confirm runs the confirm callback and closes the dialog.
+// If the callback returns an error the dialog stays open.
+func (c *ConfirmModal) Confirm() error {
+	if c.onConfirm != nil {
+		if err := c.onConfirm(); err != nil {
+			return err
+		}
+	}
+	c.Hide()
+	return nil
+}
+
+// Cancel closes the dialog and runs the cancel callback.
+func (c *ConfirmModal) Cancel() error {
+	c.Hide()
+	if c.onCancel != nil {
+		return c.onCancel()
+	}
+	return nil
+}
+
+// Actions returns the confirm dialog's action handlers for the LiveTemplate framework.
+//
+// Handled actions: "show", "hide", "confirm" and "cancel".
+func (c *ConfirmModal) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"show": func(ctx *base.ActionContext) error {
+			c.Show()
+			return nil
+		},
+		"hide": func(ctx *base.ActionContext) error {
+			c.Hide()
+			return nil
+		},
+		"confirm": func(ctx *base.ActionContext) error {
+			return c.Confirm()
+		},
+		"cancel": func(ctx *base.ActionContext) error {
+			return c.Cancel()
+		},
+	}
+}
+
 // HasTitle returns true if dialog has a title.
 func (c *ConfirmModal) HasTitle() bool {
 	return c.Title != ""
@@ -299,6 +368,26 @@ func (s *SheetModal) Toggle() {
 	s.Open = !s.Open
 }
 
+// Actions returns the sheet's action handlers for the LiveTemplate framework.
+//
+// Handled actions: "show", "hide" and "toggle".
+func (s *SheetModal) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"show": func(ctx *base.ActionContext) error {
+			s.Show()
+			return nil
+		},
+		"hide": func(ctx *base.ActionContext) error {
+			s.Hide()
+			return nil
+		},
+		"toggle": func(ctx *base.ActionContext) error {
+			s.Toggle()
+			return nil
+		},
+	}
+}
+
 // HasTitle returns true if sheet has a title.
 func (s *SheetModal) HasTitle() bool {
 	return s.Title != ""
@@ -1,7 +1,10 @@
 package modal
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 // =============================================================================
@@ -546,3 +549,85 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Templates() returned nil")
 	}
 }
+
+// =============================================================================
+// Action Tests
+// =============================================================================
+
+func TestModalActions(t *testing.T) {
+	m := New("settings")
+	actions := m.Actions()
+
+	if err := actions["show"](base.NewActionContext("show", "settings", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !m.Open {
+		t.Error("expected modal to be open")
+	}
+	if err := actions["hide"](base.NewActionContext("hide", "settings", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Open {
+		t.Error("expected modal to be closed")
+	}
+}
+
+func TestConfirmModalActions(t *testing.T) {
+	confirmed := false
+	c := NewConfirm("delete", WithOnConfirm(func() error {
+		confirmed = true
+		return nil
+	}))
+	c.Show()
+
+	if err := c.Actions()["confirm"](base.NewActionContext("confirm", "delete", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !confirmed {
+		t.Error("expected confirm callback to run")
+	}
+	if c.Open {
+		t.Error("expected dialog to close after confirm")
+	}
+}
+
+func TestConfirmModalConfirmError(t *testing.T) {
+	c := NewConfirm("delete", WithOnConfirm(func() error {
+		return errors.New("cannot delete")
+	}))
+	c.Show()
+
+	if err := c.Confirm(); err == nil {
+		t.Error("expected callback error to be returned")
+	}
+	if !c.Open {
+		t.Error("expected dialog to stay open when confirm fails")
+	}
+}
+
+func TestConfirmModalCancel(t *testing.T) {
+	cancelled := false
+	c := NewConfirm("delete", WithOnCancel(func() error {
+		cancelled = true
+		return nil
+	}))
+	c.Show()
+
+	if err := c.Actions()["cancel"](base.NewActionContext("cancel", "delete", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cancelled || c.Open {
+		t.Error("expected cancel callback to run and dialog to close")
+	}
+}
+
+func TestSheetModalActions(t *testing.T) {
+	s := NewSheet("filters")
+
+	if err := s.Actions()["toggle"](base.NewActionContext("toggle", "filters", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.Open {
+		t.Error("expected sheet to be open")
+	}
+}
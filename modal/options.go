@@ -118,6 +118,21 @@ func WithConfirmIcon(icon string) ConfirmOption {
 	}
 }
 
+// WithOnConfirm sets a callback run by the "confirm" action.
+// Returning an error keeps the dialog open.
+func WithOnConfirm(fn func() error) ConfirmOption {
+	return func(c *ConfirmModal) {
+		c.onConfirm = fn
+	}
+}
+
+// WithOnCancel sets a callback run by the "cancel" action.
+func WithOnCancel(fn func() error) ConfirmOption {
+	return func(c *ConfirmModal) {
+		c.onCancel = fn
+	}
+}
+
 // WithConfirmStyled enables Tailwind CSS styling.
 func WithConfirmStyled(styled bool) ConfirmOption {
 	return func(c *ConfirmModal) {
@@ -123,6 +123,26 @@ func (p *Popover) Toggle() {
 	p.Open = !p.Open
 }
 
+// Actions returns the popover's action handlers for the LiveTemplate framework.
+//
+// Handled actions: "show", "hide" and "toggle".
+func (p *Popover) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"show": func(ctx *base.ActionContext) error {
+			p.Show()
+			return nil
+		},
+		"hide": func(ctx *base.ActionContext) error {
+			p.Hide()
+			return nil
+		},
+		"toggle": func(ctx *base.ActionContext) error {
+			p.Toggle()
+			return nil
+		},
+	}
+}
+
 // IsTop returns true if position starts with "top".
 func (p *Popover) IsTop() bool {
 	return p.Position == PositionTop ||
@@ -2,6 +2,8 @@ package popover
 
 import (
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 // =============================================================================
@@ -462,3 +464,31 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Templates() returned nil")
 	}
 }
+
+// =============================================================================
+// Action Tests
+// =============================================================================
+
+func TestActions(t *testing.T) {
+	p := New("help")
+	actions := p.Actions()
+
+	for _, name := range []string{"show", "hide", "toggle"} {
+		if _, ok := actions[name]; !ok {
+			t.Errorf("expected %q action", name)
+		}
+	}
+
+	if err := actions["toggle"](base.NewActionContext("toggle", "help", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !p.Open {
+		t.Error("expected popover to be visible after toggle")
+	}
+	if err := actions["hide"](base.NewActionContext("hide", "help", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Open {
+		t.Error("expected popover to be hidden after hide")
+	}
+}
@@ -191,6 +191,28 @@ func (r *Rating) Leave() {
 	r.HoverValue = -1
 }
 
+// Actions returns the rating's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "click" sets the rating to lvt-data-star
+//   - "hover" previews lvt-data-star; "leave" ends the preview
+func (r *Rating) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"click": func(ctx *base.ActionContext) error {
+			r.Click(ctx.DataInt("star"))
+			return nil
+		},
+		"hover": func(ctx *base.ActionContext) error {
+			r.Hover(ctx.DataInt("star"))
+			return nil
+		},
+		"leave": func(ctx *base.ActionContext) error {
+			r.Leave()
+			return nil
+		},
+	}
+}
+
 // DisplayValue returns the value to display (hover or actual).
 func (r *Rating) DisplayValue() float64 {
 	if r.HoverValue >= 0 {
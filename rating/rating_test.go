@@ -2,6 +2,8 @@ package rating
 
 import (
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -399,3 +401,31 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Expected Templates() to return a TemplateSet")
 	}
 }
+
+func TestActions(t *testing.T) {
+	r := New("score")
+	actions := r.Actions()
+
+	ctx := base.NewActionContext("hover", "score", map[string]string{"star": "4"})
+	if err := actions["hover"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.HoverValue != 4 {
+		t.Errorf("expected HoverValue 4, got %v", r.HoverValue)
+	}
+
+	ctx = base.NewActionContext("click", "score", map[string]string{"star": "3"})
+	if err := actions["click"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Value != 3 {
+		t.Errorf("expected Value 3, got %v", r.Value)
+	}
+
+	if err := actions["leave"](base.NewActionContext("leave", "score", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.HoverValue != -1 {
+		t.Errorf("expected HoverValue -1 after leave, got %v", r.HoverValue)
+	}
+}
@@ -159,6 +159,28 @@ func (t *Tabs) Previous() {
 	}
 }
 
+// Actions returns the tabs' action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "select_tab" activates the tab in lvt-data-tab
+//   - "next_tab" and "prev_tab" move to the adjacent enabled tab
+func (t *Tabs) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"select_tab": func(ctx *base.ActionContext) error {
+			t.SetActive(ctx.Data("tab"))
+			return nil
+		},
+		"next_tab": func(ctx *base.ActionContext) error {
+			t.Next()
+			return nil
+		},
+		"prev_tab": func(ctx *base.ActionContext) error {
+			t.Previous()
+			return nil
+		},
+	}
+}
+
 // findActiveIndex returns the index of the active tab.
 func (t *Tabs) findActiveIndex() int {
 	for i, tab := range t.Items {
@@ -4,6 +4,8 @@ import (
 	"html/template"
 	"strings"
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -455,3 +457,27 @@ func TestTabWithIcon(t *testing.T) {
 		t.Error("expected icon in output")
 	}
 }
+
+func TestActions(t *testing.T) {
+	items := []Tab{
+		{ID: "general", Label: "General"},
+		{ID: "security", Label: "Security"},
+	}
+	tb := New("settings", items)
+	actions := tb.Actions()
+
+	ctx := base.NewActionContext("select_tab", "settings", map[string]string{"tab": "security"})
+	if err := actions["select_tab"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tb.IsActive("security") {
+		t.Errorf("expected 'security' to be active, got %q", tb.ActiveID)
+	}
+
+	if err := actions["next_tab"](base.NewActionContext("next_tab", "settings", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tb.IsActive("general") {
+		t.Errorf("expected next_tab to wrap to 'general', got %q", tb.ActiveID)
+	}
+}
@@ -160,6 +160,54 @@ func (t *TagsInput) SetInput(value string) {
 	}
 }
 
+// Actions returns the tags input's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "input_tag" updates the input from the input value
+//   - "keydown_tag" adds the input as a tag on Enter and removes the last
+//     tag on Backspace when the input is empty
+//   - "focus_tag" and "blur_tag" show and hide suggestions
+//   - "remove_tag" removes the tag in lvt-data-value
+//   - "select_suggestion" adds the suggestion in lvt-data-value
+func (t *TagsInput) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"input_tag": func(ctx *base.ActionContext) error {
+			t.SetInput(ctx.Data("value"))
+			return nil
+		},
+		"keydown_tag": func(ctx *base.ActionContext) error {
+			if ctx.HasData("value") {
+				t.Input = ctx.Data("value")
+			}
+			switch ctx.Data("key") {
+			case "Enter":
+				t.AddTag(t.Input)
+			case "Backspace":
+				if t.Input == "" {
+					t.RemoveLast()
+				}
+			}
+			return nil
+		},
+		"focus_tag": func(ctx *base.ActionContext) error {
+			t.ShowSuggestions = true
+			return nil
+		},
+		"blur_tag": func(ctx *base.ActionContext) error {
+			t.ShowSuggestions = false
+			return nil
+		},
+		"remove_tag": func(ctx *base.ActionContext) error {
+			t.RemoveTag(ctx.Data("value"))
+			return nil
+		},
+		"select_suggestion": func(ctx *base.ActionContext) error {
+			t.AddTag(ctx.Data("value"))
+			return nil
+		},
+	}
+}
+
 // Values returns all tag values as a slice.
 func (t *TagsInput) Values() []string {
 	values := make([]string, len(t.Tags))
@@ -185,29 +233,31 @@ func (t *TagsInput) CanAddMore() bool {
 }
 
 // FilteredSuggestions returns suggestions that match the current input
-// and aren't already tags.
+// and aren't already tags, best match first. Matching ignores case and
+// diacritics and ranks prefix matches first (see base.Matcher).
 func (t *TagsInput) FilteredSuggestions() []string {
 	if t.Input == "" || len(t.Suggestions) == 0 {
 		return nil
 	}
 
-	inputLower := toLower(t.Input)
 	filtered := make([]string, 0)
-
-	for _, s := range t.Suggestions {
+	for _, r := range base.Rank(t.Input, t.Suggestions, func(s string) string { return s }) {
 		// Skip if already a tag
-		if t.HasTag(s) {
-			continue
-		}
-		// Match if input is prefix
-		if len(inputLower) <= len(s) && toLower(s[:len(inputLower)]) == inputLower {
-			filtered = append(filtered, s)
+		if !t.HasTag(r.Item) {
+			filtered = append(filtered, r.Item)
 		}
 	}
 
 	return filtered
 }
 
+// Highlight returns a suggestion split into the parts that match the input
+// and the parts that don't, for rendering matches in <mark>.
+func (t *TagsInput) Highlight(suggestion string) []base.Segment {
+	match, _ := base.NewMatcher(t.Input).Match(suggestion)
+	return match.Segments(suggestion)
+}
+
 // Helper functions
 func trimSpace(s string) string {
 	start := 0
@@ -259,15 +309,3 @@ func split(s, sep string) []string {
 	}
 	return result
 }
-
-func toLower(s string) string {
-	b := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			c += 'a' - 'A'
-		}
-		b[i] = c
-	}
-	return string(b)
-}
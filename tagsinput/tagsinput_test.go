@@ -4,6 +4,8 @@ import (
 	"html/template"
 	"strings"
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -307,6 +309,29 @@ func TestTagsInput_FilteredSuggestionsExcludesExisting(t *testing.T) {
 	}
 }
 
+func TestTagsInput_FilteredSuggestionsRanked(t *testing.T) {
+	ti := New("test", WithSuggestions("mongodb", "Go", "golang", "Gödel", "django"))
+
+	ti.Input = "go"
+	suggestions := ti.FilteredSuggestions()
+
+	// Prefix matches (shortest first), then substring matches.
+	expected := []string{"Go", "Gödel", "golang", "mongodb", "django"}
+	if len(suggestions) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, suggestions)
+	}
+	for i := range expected {
+		if suggestions[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, suggestions)
+		}
+	}
+
+	segments := ti.Highlight("Gödel")
+	if len(segments) != 2 || segments[0].Text != "Gö" || !segments[0].Match {
+		t.Errorf("unexpected highlight %+v", segments)
+	}
+}
+
 func TestTagsInput_FilteredSuggestionsEmpty(t *testing.T) {
 	ti := New("test", WithSuggestions("golang", "python"))
 
@@ -447,3 +472,68 @@ func TestSplit(t *testing.T) {
 		}
 	}
 }
+
+func TestActions(t *testing.T) {
+	ti := New("skills", WithTags("go"), WithSuggestions("python", "rust"))
+	actions := ti.Actions()
+
+	run := func(name string, data map[string]string) {
+		t.Helper()
+		if err := actions[name](base.NewActionContext(name, "skills", data)); err != nil {
+			t.Fatalf("%s returned error: %v", name, err)
+		}
+	}
+
+	run("input_tag", map[string]string{"value": "elixir"})
+	run("keydown_tag", map[string]string{"key": "Enter"})
+	if !ti.HasTag("elixir") || ti.Input != "" {
+		t.Errorf("expected Enter to add 'elixir', got tags %v input %q", ti.Values(), ti.Input)
+	}
+
+	run("keydown_tag", map[string]string{"key": "Backspace"})
+	if ti.HasTag("elixir") {
+		t.Error("expected Backspace on empty input to remove the last tag")
+	}
+
+	run("select_suggestion", map[string]string{"value": "rust"})
+	run("remove_tag", map[string]string{"value": "go"})
+	if ti.HasTag("go") || !ti.HasTag("rust") {
+		t.Errorf("expected tags [rust], got %v", ti.Values())
+	}
+
+	run("focus_tag", nil)
+	if !ti.ShowSuggestions {
+		t.Error("expected focus_tag to show suggestions")
+	}
+	run("blur_tag", nil)
+	if ti.ShowSuggestions {
+		t.Error("expected blur_tag to hide suggestions")
+	}
+}
+
+func TestSuggestionHighlightTemplate(t *testing.T) {
+	ts := Templates()
+	tmpl, err := template.New("test").ParseFS(ts.FS, ts.Pattern)
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+
+	for _, styled := range []bool{true, false} {
+		ti := New("skills", WithSuggestions("Gödel", "python"), WithStyled(styled))
+		ti.Input = "god"
+		ti.ShowSuggestions = true
+
+		var buf strings.Builder
+		if err := tmpl.ExecuteTemplate(&buf, "lvt:tagsinput:default:v1", ti); err != nil {
+			t.Fatalf("failed to execute template: %v", err)
+		}
+
+		html := buf.String()
+		if !strings.Contains(html, ">Göd</mark>el") {
+			t.Errorf("styled=%v: expected the match to be highlighted", styled)
+		}
+		if strings.Contains(html, "python") {
+			t.Errorf("styled=%v: expected non-matching suggestions not to render", styled)
+		}
+	}
+}
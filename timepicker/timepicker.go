@@ -282,6 +282,21 @@ func (tp *TimePicker) DecrementMinute() {
 	tp.HasValue = true
 }
 
+// IncrementSecond increments the second.
+func (tp *TimePicker) IncrementSecond() {
+	tp.Second = (tp.Second + 1) % 60
+	tp.HasValue = true
+}
+
+// DecrementSecond decrements the second.
+func (tp *TimePicker) DecrementSecond() {
+	tp.Second--
+	if tp.Second < 0 {
+		tp.Second = 59
+	}
+	tp.HasValue = true
+}
+
 // Get24Hour returns the hour in 24-hour format.
 func (tp *TimePicker) Get24Hour() int {
 	if tp.Use24Hour {
@@ -363,6 +378,63 @@ func (tp *TimePicker) SetNow() {
 	tp.HasValue = true
 }
 
+// Actions returns the time picker's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "toggle" and "close" open and close the picker
+//   - "inc_hour", "dec_hour", "inc_minute", "dec_minute", "inc_second" and
+//     "dec_second" step the time
+//   - "set_period" sets AM/PM from lvt-data-period
+//   - "now" and "clear" set and reset the value
+func (tp *TimePicker) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle": func(ctx *base.ActionContext) error {
+			tp.Toggle()
+			return nil
+		},
+		"close": func(ctx *base.ActionContext) error {
+			tp.Close()
+			return nil
+		},
+		"inc_hour": func(ctx *base.ActionContext) error {
+			tp.IncrementHour()
+			return nil
+		},
+		"dec_hour": func(ctx *base.ActionContext) error {
+			tp.DecrementHour()
+			return nil
+		},
+		"inc_minute": func(ctx *base.ActionContext) error {
+			tp.IncrementMinute()
+			return nil
+		},
+		"dec_minute": func(ctx *base.ActionContext) error {
+			tp.DecrementMinute()
+			return nil
+		},
+		"inc_second": func(ctx *base.ActionContext) error {
+			tp.IncrementSecond()
+			return nil
+		},
+		"dec_second": func(ctx *base.ActionContext) error {
+			tp.DecrementSecond()
+			return nil
+		},
+		"set_period": func(ctx *base.ActionContext) error {
+			tp.SetPeriod(ctx.Data("period"))
+			return nil
+		},
+		"now": func(ctx *base.ActionContext) error {
+			tp.SetNow()
+			return nil
+		},
+		"clear": func(ctx *base.ActionContext) error {
+			tp.Clear()
+			return nil
+		},
+	}
+}
+
 // DurationPicker methods
 
 // Toggle opens or closes the picker.
@@ -454,6 +526,72 @@ func (dp *DurationPicker) DecrementMinutes() {
 	dp.HasValue = true
 }
 
+// IncrementSeconds increments seconds.
+func (dp *DurationPicker) IncrementSeconds() {
+	dp.Seconds++
+	if dp.Seconds > 59 {
+		dp.Seconds = 0
+	}
+	dp.HasValue = true
+}
+
+// DecrementSeconds decrements seconds.
+func (dp *DurationPicker) DecrementSeconds() {
+	dp.Seconds--
+	if dp.Seconds < 0 {
+		dp.Seconds = 59
+	}
+	dp.HasValue = true
+}
+
+// Actions returns the duration picker's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "toggle" and "close" open and close the picker
+//   - "inc_hours", "dec_hours", "inc_minutes", "dec_minutes", "inc_seconds"
+//     and "dec_seconds" step the duration
+//   - "clear" resets the duration
+func (dp *DurationPicker) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle": func(ctx *base.ActionContext) error {
+			dp.Toggle()
+			return nil
+		},
+		"close": func(ctx *base.ActionContext) error {
+			dp.Close()
+			return nil
+		},
+		"inc_hours": func(ctx *base.ActionContext) error {
+			dp.IncrementHours()
+			return nil
+		},
+		"dec_hours": func(ctx *base.ActionContext) error {
+			dp.DecrementHours()
+			return nil
+		},
+		"inc_minutes": func(ctx *base.ActionContext) error {
+			dp.IncrementMinutes()
+			return nil
+		},
+		"dec_minutes": func(ctx *base.ActionContext) error {
+			dp.DecrementMinutes()
+			return nil
+		},
+		"inc_seconds": func(ctx *base.ActionContext) error {
+			dp.IncrementSeconds()
+			return nil
+		},
+		"dec_seconds": func(ctx *base.ActionContext) error {
+			dp.DecrementSeconds()
+			return nil
+		},
+		"clear": func(ctx *base.ActionContext) error {
+			dp.Clear()
+			return nil
+		},
+	}
+}
+
 // TotalMinutes returns the total duration in minutes.
 func (dp *DurationPicker) TotalMinutes() int {
 	return dp.Hours*60 + dp.Minutes
@@ -2,6 +2,8 @@ package timepicker
 
 import (
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -535,3 +537,50 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Expected Templates() to return a TemplateSet")
 	}
 }
+
+func TestIncrementDecrementSecond(t *testing.T) {
+	tp := New("test")
+
+	tp.DecrementSecond()
+	if tp.Second != 59 {
+		t.Errorf("expected DecrementSecond to wrap to 59, got %d", tp.Second)
+	}
+	tp.IncrementSecond()
+	if tp.Second != 0 {
+		t.Errorf("expected IncrementSecond to wrap to 0, got %d", tp.Second)
+	}
+}
+
+func TestActions(t *testing.T) {
+	tp := New("meeting")
+	actions := tp.Actions()
+
+	for _, name := range []string{"toggle", "close", "inc_hour", "dec_hour", "inc_minute", "dec_minute", "inc_second", "dec_second", "set_period", "now", "clear"} {
+		if _, ok := actions[name]; !ok {
+			t.Errorf("expected %q action", name)
+		}
+	}
+
+	ctx := base.NewActionContext("set_period", "meeting", map[string]string{"period": "PM"})
+	if err := actions["set_period"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tp.Period != "PM" {
+		t.Errorf("expected Period PM, got %q", tp.Period)
+	}
+}
+
+func TestDurationActions(t *testing.T) {
+	dp := NewDuration("timer")
+	actions := dp.Actions()
+
+	if err := actions["inc_hours"](base.NewActionContext("inc_hours", "timer", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := actions["dec_seconds"](base.NewActionContext("dec_seconds", "timer", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dp.Hours != 1 || dp.Seconds != 59 {
+		t.Errorf("expected 1h and 59s, got %dh %ds", dp.Hours, dp.Seconds)
+	}
+}
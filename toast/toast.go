@@ -155,6 +155,24 @@ func (c *Container) DismissAll() {
 	c.Messages = make([]Message, 0)
 }
 
+// Actions returns the toast container's action handlers for the LiveTemplate framework.
+//
+// Handled actions:
+//   - "dismiss_toast" removes the toast in lvt-data-toast
+//   - "dismiss_all" removes every toast
+func (c *Container) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"dismiss_toast": func(ctx *base.ActionContext) error {
+			c.Dismiss(ctx.Data("toast"))
+			return nil
+		},
+		"dismiss_all": func(ctx *base.ActionContext) error {
+			c.DismissAll()
+			return nil
+		},
+	}
+}
+
 // Count returns the number of active toasts.
 func (c *Container) Count() int {
 	return len(c.Messages)
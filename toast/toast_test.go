@@ -4,6 +4,8 @@ import (
 	"html/template"
 	"strings"
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 func TestNew(t *testing.T) {
@@ -368,3 +370,25 @@ func TestEmptyContainerRendering(t *testing.T) {
 		t.Error("expected empty container to render")
 	}
 }
+
+func TestActions(t *testing.T) {
+	c := New("notifications")
+	c.Add(Message{ID: "t1", Title: "Saved"})
+	c.Add(Message{ID: "t2", Title: "Synced"})
+	actions := c.Actions()
+
+	ctx := base.NewActionContext("dismiss_toast", "notifications", map[string]string{"toast": "t1"})
+	if err := actions["dismiss_toast"](ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Count() != 1 {
+		t.Errorf("expected 1 toast after dismiss, got %d", c.Count())
+	}
+
+	if err := actions["dismiss_all"](base.NewActionContext("dismiss_all", "notifications", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.HasMessages() {
+		t.Error("expected no toasts after dismiss_all")
+	}
+}
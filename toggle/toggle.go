@@ -115,6 +115,18 @@ func (t *Toggle) SetChecked(checked bool) {
 	}
 }
 
+// Actions returns the toggle's action handlers for the LiveTemplate framework.
+//
+// Handled actions: "toggle".
+func (t *Toggle) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle": func(ctx *base.ActionContext) error {
+			t.Toggle()
+			return nil
+		},
+	}
+}
+
 // IsOn returns true if toggle is checked.
 func (t *Toggle) IsOn() bool {
 	return t.Checked
@@ -280,6 +292,18 @@ func (c *Checkbox) SetIndeterminate(indeterminate bool) {
 	}
 }
 
+// Actions returns the checkbox's action handlers for the LiveTemplate framework.
+//
+// Handled actions: "toggle".
+func (c *Checkbox) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"toggle": func(ctx *base.ActionContext) error {
+			c.Toggle()
+			return nil
+		},
+	}
+}
+
 // HasLabel returns true if checkbox has a label.
 func (c *Checkbox) HasLabel() bool {
 	return c.Label != ""
@@ -2,6 +2,8 @@ package toggle
 
 import (
 	"testing"
+
+	"github.com/livetemplate/components/base"
 )
 
 // =============================================================================
@@ -442,3 +444,30 @@ func TestTemplates(t *testing.T) {
 		t.Fatal("Templates() returned nil")
 	}
 }
+
+// =============================================================================
+// Action Tests
+// =============================================================================
+
+func TestToggleActions(t *testing.T) {
+	tg := New("notify")
+
+	if err := tg.Actions()["toggle"](base.NewActionContext("toggle", "notify", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tg.Checked {
+		t.Error("expected toggle to be checked")
+	}
+}
+
+func TestCheckboxActions(t *testing.T) {
+	c := NewCheckbox("terms")
+	c.SetIndeterminate(true)
+
+	if err := c.Actions()["toggle"](base.NewActionContext("toggle", "terms", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.Checked || c.Indeterminate {
+		t.Error("expected checkbox to be checked and no longer indeterminate")
+	}
+}
@@ -119,6 +119,26 @@ func (t *Tooltip) Toggle() {
 	t.Visible = !t.Visible
 }
 
+// Actions returns the tooltip's action handlers for the LiveTemplate framework.
+//
+// Handled actions: "show", "hide" and "toggle".
+func (t *Tooltip) Actions() map[string]base.ActionHandler {
+	return map[string]base.ActionHandler{
+		"show": func(ctx *base.ActionContext) error {
+			t.Show()
+			return nil
+		},
+		"hide": func(ctx *base.ActionContext) error {
+			t.Hide()
+			return nil
+		},
+		"toggle": func(ctx *base.ActionContext) error {
+			t.Toggle()
+			return nil
+		},
+	}
+}
+
 // IsTop returns true if position starts with "top".
 func (t *Tooltip) IsTop() bool {
 	return t.Position == PositionTop ||